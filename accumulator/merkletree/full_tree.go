@@ -0,0 +1,280 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrEmptyTree       = errors.New("merkle tree has no leaves")
+	ErrIndexOutOfRange = errors.New("leaf index out of range")
+	ErrNoIndices       = errors.New("no leaf index to prove")
+)
+
+// minParallelLayer is the layer size under which the nodes of a layer are
+// computed sequentially.
+const minParallelLayer = 1 << 10
+
+// FullTree is a Merkle tree which keeps all its layers in memory. It produces
+// the same root as a Tree fed with the same leaves, and can therefore produce
+// an arbitrary number of proofs after construction, for single leaves or for
+// sets of leaves (see MultiProof).
+//
+// When the number of nodes in a layer is odd, the last node is promoted as is
+// to the next layer, which matches the way orphans are merged in Tree.
+type FullTree struct {
+	newHash func() hash.Hash
+
+	// leaves holds the data of the leaves, layers[0] the leaf hashes and
+	// layers[len(layers)-1] the root.
+	leaves [][]byte
+	layers [][][]byte
+}
+
+// MultiProof is a compressed proof that a set of leaves belongs to a
+// FullTree. Siblings which can be recomputed from the opened leaves are not
+// part of the proof.
+type MultiProof struct {
+	// Indices of the opened leaves, sorted in increasing order without
+	// duplicates.
+	Indices []uint64
+	// Leaves contains the data of the opened leaves, in the order of Indices.
+	Leaves [][]byte
+	// Siblings contains the nodes needed to recompute the root, layer by layer
+	// starting from the leaves, and from left to right within a layer.
+	Siblings [][]byte
+	// NumLeaves is the number of leaves in the tree.
+	NumLeaves uint64
+}
+
+// NewFullTree builds the Merkle tree of the given leaves. Layers are computed
+// in parallel, each go routine using its own hash obtained from newHash.
+// The leaves are not copied.
+func NewFullTree(newHash func() hash.Hash, leaves [][]byte) (*FullTree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrEmptyTree
+	}
+	t := &FullTree{
+		newHash: newHash,
+		leaves:  leaves,
+	}
+
+	layer := make([][]byte, len(leaves))
+	execute(len(leaves), func(start, end int) {
+		h := newHash()
+		for i := start; i < end; i++ {
+			layer[i] = leafSum(h, leaves[i])
+		}
+	})
+	t.layers = append(t.layers, layer)
+
+	for len(layer) > 1 {
+		prev := layer
+		layer = make([][]byte, (len(prev)+1)/2)
+		execute(len(prev)/2, func(start, end int) {
+			h := newHash()
+			for i := start; i < end; i++ {
+				layer[i] = nodeSum(h, prev[2*i], prev[2*i+1])
+			}
+		})
+		if len(prev)%2 == 1 {
+			// the last node is an orphan, it is promoted
+			layer[len(layer)-1] = prev[len(prev)-1]
+		}
+		t.layers = append(t.layers, layer)
+	}
+
+	return t, nil
+}
+
+// Root returns the Merkle root of the tree.
+func (t *FullTree) Root() []byte {
+	root := t.layers[len(t.layers)-1][0]
+	// Return a copy to prevent leaking a pointer to internal data.
+	return append(root[:0:0], root...)
+}
+
+// NumLeaves returns the number of leaves in the tree.
+func (t *FullTree) NumLeaves() uint64 {
+	return uint64(len(t.leaves))
+}
+
+// Prove returns a proof that the leaf at the given index is part of the tree.
+// The proof set has the same layout as the one returned by Tree.Prove, and
+// can be checked with VerifyProof.
+func (t *FullTree) Prove(index uint64) (proofSet [][]byte, err error) {
+	if index >= t.NumLeaves() {
+		return nil, ErrIndexOutOfRange
+	}
+	proofSet = append(proofSet, t.leaves[index])
+
+	// The siblings are collected from the leaves up. A promoted node has no
+	// sibling in its layer, in which case nothing is added to the proof.
+	i := index
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		if s := i ^ 1; s < uint64(len(layer)) {
+			proofSet = append(proofSet, layer[s])
+		}
+		i >>= 1
+	}
+	return proofSet, nil
+}
+
+// ProveMulti returns a compressed proof that the leaves at the given indices
+// are part of the tree. Indices may be given in any order and may contain
+// duplicates.
+func (t *FullTree) ProveMulti(indices []uint64) (*MultiProof, error) {
+	if len(indices) == 0 {
+		return nil, ErrNoIndices
+	}
+	sorted := sortedUnique(indices)
+	if sorted[len(sorted)-1] >= t.NumLeaves() {
+		return nil, ErrIndexOutOfRange
+	}
+
+	proof := &MultiProof{
+		Indices:   sorted,
+		Leaves:    make([][]byte, len(sorted)),
+		NumLeaves: t.NumLeaves(),
+	}
+	for i, idx := range sorted {
+		proof.Leaves[i] = t.leaves[idx]
+	}
+
+	known := append([]uint64(nil), sorted...)
+	for _, layer := range t.layers[:len(t.layers)-1] {
+		n := uint64(len(layer))
+		next := known[:0]
+		for j := 0; j < len(known); j++ {
+			i := known[j]
+			if s := i ^ 1; s < n {
+				if s == i+1 && j+1 < len(known) && known[j+1] == s {
+					// the sibling is known, no need to send it
+					j++
+				} else {
+					proof.Siblings = append(proof.Siblings, layer[s])
+				}
+			}
+			next = append(next, i>>1)
+		}
+		known = next
+	}
+
+	return proof, nil
+}
+
+// VerifyMultiProof returns true if the leaves in proof are part of the tree
+// with the given Merkle root. It checks all the leaves at once, and accepts
+// a proof for a single leaf as well as for the whole set of leaves.
+func VerifyMultiProof(h hash.Hash, merkleRoot []byte, proof *MultiProof) bool {
+	if merkleRoot == nil || proof == nil || proof.NumLeaves == 0 {
+		return false
+	}
+	if len(proof.Indices) == 0 || len(proof.Indices) != len(proof.Leaves) {
+		return false
+	}
+	for i := range proof.Indices {
+		if proof.Indices[i] >= proof.NumLeaves {
+			return false
+		}
+		if i > 0 && proof.Indices[i] <= proof.Indices[i-1] {
+			return false
+		}
+	}
+
+	indices := append([]uint64(nil), proof.Indices...)
+	nodes := make([][]byte, len(proof.Leaves))
+	for i := range proof.Leaves {
+		nodes[i] = leafSum(h, proof.Leaves[i])
+	}
+
+	siblings := proof.Siblings
+	for n := proof.NumLeaves; n > 1; n = (n + 1) / 2 {
+		nextIndices, nextNodes := indices[:0], nodes[:0]
+		for j := 0; j < len(indices); j++ {
+			i, node := indices[j], nodes[j]
+			switch s := i ^ 1; {
+			case s >= n:
+				// promoted orphan
+			case s == i+1 && j+1 < len(indices) && indices[j+1] == s:
+				node = nodeSum(h, node, nodes[j+1])
+				j++
+			default:
+				if len(siblings) == 0 {
+					return false
+				}
+				if s < i {
+					node = nodeSum(h, siblings[0], node)
+				} else {
+					node = nodeSum(h, node, siblings[0])
+				}
+				siblings = siblings[1:]
+			}
+			nextIndices = append(nextIndices, i>>1)
+			nextNodes = append(nextNodes, node)
+		}
+		indices, nodes = nextIndices, nextNodes
+	}
+
+	// all the siblings must have been consumed
+	if len(siblings) != 0 {
+		return false
+	}
+	return bytes.Equal(nodes[0], merkleRoot)
+}
+
+// BatchVerifyProofs checks a list of proofs produced by Tree.Prove or
+// FullTree.Prove against the same Merkle root. It returns the indices (in
+// the list of proofs) of the proofs that failed.
+func BatchVerifyProofs(h hash.Hash, merkleRoot []byte, proofSets [][][]byte, proofIndices []uint64, numLeaves uint64) (failed []int) {
+	if len(proofSets) != len(proofIndices) {
+		panic("number of proofs and number of indices don't match")
+	}
+	for i := range proofSets {
+		if !VerifyProof(h, merkleRoot, proofSets[i], proofIndices[i], numLeaves) {
+			failed = append(failed, i)
+		}
+	}
+	return
+}
+
+// sortedUnique returns a sorted copy of indices, without duplicates.
+func sortedUnique(indices []uint64) []uint64 {
+	res := append([]uint64(nil), indices...)
+	sort.Slice(res, func(i, j int) bool { return res[i] < res[j] })
+	k := 1
+	for i := 1; i < len(res); i++ {
+		if res[i] != res[k-1] {
+			res[k] = res[i]
+			k++
+		}
+	}
+	return res[:k]
+}
+
+// execute runs work on [0, n), in parallel if n is large enough.
+func execute(n int, work func(int, int)) {
+	if n < minParallelLayer {
+		work(0, n)
+		return
+	}
+	parallel.Execute(n, work)
+}
@@ -0,0 +1,147 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merkletree
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLeaves(n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaves[i] = make([]byte, 8)
+		binary.BigEndian.PutUint64(leaves[i], uint64(i)*0x9e3779b97f4a7c15)
+	}
+	return leaves
+}
+
+func TestFullTreeRoot(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5, 7, 8, 13, 64, 1000, 3000} {
+		leaves := testLeaves(n)
+
+		tree := New(sha256.New())
+		for _, l := range leaves {
+			tree.Push(l)
+		}
+
+		full, err := NewFullTree(sha256.New, leaves)
+		require.NoError(t, err)
+		assert.True(t, bytes.Equal(tree.Root(), full.Root()), "n=%d", n)
+	}
+}
+
+func TestFullTreeProve(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 5, 7, 8, 13} {
+		leaves := testLeaves(n)
+		full, err := NewFullTree(sha256.New, leaves)
+		require.NoError(t, err)
+
+		proofSets := make([][][]byte, n)
+		proofIndices := make([]uint64, n)
+		for i := 0; i < n; i++ {
+			// must match the proofs of the streaming tree
+			tree := New(sha256.New())
+			require.NoError(t, tree.SetIndex(uint64(i)))
+			for _, l := range leaves {
+				tree.Push(l)
+			}
+			_, expected, _, _ := tree.Prove()
+
+			proofSets[i], err = full.Prove(uint64(i))
+			require.NoError(t, err)
+			proofIndices[i] = uint64(i)
+			assert.Equal(t, expected, proofSets[i], "n=%d i=%d", n, i)
+		}
+		assert.Empty(t, BatchVerifyProofs(sha256.New(), full.Root(), proofSets, proofIndices, uint64(n)))
+
+		if n > 1 {
+			proofSets[0] = proofSets[n-1]
+			assert.Equal(t, []int{0}, BatchVerifyProofs(sha256.New(), full.Root(), proofSets, proofIndices, uint64(n)))
+		}
+	}
+
+	_, err := NewFullTree(sha256.New, nil)
+	assert.ErrorIs(t, err, ErrEmptyTree)
+}
+
+func TestFullTreeMultiProof(t *testing.T) {
+	const n = 37
+	leaves := testLeaves(n)
+	full, err := NewFullTree(sha256.New, leaves)
+	require.NoError(t, err)
+	root := full.Root()
+
+	for _, indices := range [][]uint64{
+		{0},
+		{n - 1},
+		{3, 3, 2},
+		{36, 0, 17, 18, 19},
+		{1, 5, 9, 12, 30, 31, 32, 33, 34, 35, 36},
+	} {
+		proof, err := full.ProveMulti(indices)
+		require.NoError(t, err)
+		assert.True(t, VerifyMultiProof(sha256.New(), root, proof), "indices=%v", indices)
+
+		// the compressed proof can't be larger than the sum of single proofs
+		nbSiblings := 0
+		for _, i := range proof.Indices {
+			p, err := full.Prove(i)
+			require.NoError(t, err)
+			nbSiblings += len(p) - 1
+		}
+		assert.LessOrEqual(t, len(proof.Siblings), nbSiblings)
+
+		// tampering with the proof must be detected
+		proof.Leaves[0] = []byte("wrong leaf")
+		assert.False(t, VerifyMultiProof(sha256.New(), root, proof))
+	}
+
+	// all the leaves: no sibling needed
+	all := make([]uint64, n)
+	for i := range all {
+		all[i] = uint64(i)
+	}
+	proof, err := full.ProveMulti(all)
+	require.NoError(t, err)
+	assert.Empty(t, proof.Siblings)
+	assert.True(t, VerifyMultiProof(sha256.New(), root, proof))
+
+	// malformed proofs
+	proof, err = full.ProveMulti([]uint64{4, 20})
+	require.NoError(t, err)
+	proof.Siblings = append(proof.Siblings, proof.Siblings[0])
+	assert.False(t, VerifyMultiProof(sha256.New(), root, proof))
+	proof.Siblings = proof.Siblings[:len(proof.Siblings)-2]
+	assert.False(t, VerifyMultiProof(sha256.New(), root, proof))
+
+	_, err = full.ProveMulti([]uint64{n})
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	_, err = full.ProveMulti(nil)
+	assert.ErrorIs(t, err, ErrNoIndices)
+}
+
+func BenchmarkNewFullTree(b *testing.B) {
+	leaves := testLeaves(1 << 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = NewFullTree(sha256.New, leaves)
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"crypto/subtle"
 	"errors"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
 	"io"
 	"math/big"
 )
@@ -28,6 +30,9 @@ var errWrongSize = errors.New("wrong size buffer")
 var errRBiggerThanRMod = errors.New("r >= r_mod")
 var errSBiggerThanRMod = errors.New("s >= r_mod")
 var errZero = errors.New("zero value")
+var errInvalidDER = errors.New("invalid DER encoding")
+
+var halfOrder = new(big.Int).Rsh(order, 1)
 
 // Bytes returns the binary representation of the public key
 // follows https://tools.ietf.org/html/rfc8032#section-3.1
@@ -91,7 +96,7 @@ func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
 	return n, nil
 }
 
-// Bytes returns the binary representation of sig
+// Bytes returns the compact binary representation of sig
 // as a byte array of size 2*sizeFr r||s
 func (sig *Signature) Bytes() []byte {
 	var res [sizeSignature]byte
@@ -134,3 +139,57 @@ func (sig *Signature) SetBytes(buf []byte) (int, error) {
 	n += sizeFr
 	return n, nil
 }
+
+// BytesDER returns the DER encoding of sig, as the ASN.1 SEQUENCE of the
+// INTEGERs r and s used by X.509, TLS or Bitcoin.
+func (sig *Signature) BytesDER() []byte {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(new(big.Int).SetBytes(sig.R[:]))
+		b.AddASN1BigInt(new(big.Int).SetBytes(sig.S[:]))
+	})
+	return b.BytesOrPanic()
+}
+
+// SetBytesDER sets sig from its DER encoding. The encoding must be strict: the
+// lengths and the integers are minimally encoded, and buf has no trailing
+// data. It returns the number of bytes read from buf.
+func (sig *Signature) SetBytesDER(buf []byte) (int, error) {
+	var r, s big.Int
+	var inner cryptobyte.String
+	input := cryptobyte.String(buf)
+	if !input.ReadASN1(&inner, asn1.SEQUENCE) || !input.Empty() ||
+		!inner.ReadASN1Integer(&r) || !inner.ReadASN1Integer(&s) || !inner.Empty() {
+		return 0, errInvalidDER
+	}
+	if r.Sign() <= 0 || s.Sign() <= 0 {
+		return 0, errZero
+	}
+	if r.Cmp(order) >= 0 {
+		return 0, errRBiggerThanRMod
+	}
+	if s.Cmp(order) >= 0 {
+		return 0, errSBiggerThanRMod
+	}
+	r.FillBytes(sig.R[:])
+	s.FillBytes(sig.S[:])
+	return len(buf), nil
+}
+
+// IsLowS returns true if s ≤ (order-1)/2. Since {r, -s} is also a valid
+// signature, protocols such as Bitcoin and Ethereum only accept the low-S one.
+func (sig *Signature) IsLowS() bool {
+	return new(big.Int).SetBytes(sig.S[:]).Cmp(halfOrder) <= 0
+}
+
+// NormalizeS replaces s with order - s if s > (order-1)/2, so that the
+// signature is low-S. It returns true if s was replaced, in which case the
+// y parity bit of the public key recovery information is flipped.
+func (sig *Signature) NormalizeS() bool {
+	s := new(big.Int).SetBytes(sig.S[:])
+	if s.Cmp(halfOrder) <= 0 {
+		return false
+	}
+	s.Sub(order, s).FillBytes(sig.S[:])
+	return true
+}
@@ -62,3 +62,45 @@ func TestSerialization(t *testing.T) {
 
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
+
+func TestSerializationDER(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] ECDSA DER serialization: SetBytesDER(BytesDER()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			bSig, _ := privKey.Sign([]byte("testing DER"), nil)
+
+			var sig, end Signature
+			if _, err := sig.SetBytes(bSig); err != nil {
+				return false
+			}
+			der := sig.BytesDER()
+			n, err := end.SetBytesDER(der)
+			if err != nil || n != len(der) {
+				return false
+			}
+			if subtle.ConstantTimeCompare(end.Bytes(), bSig) != 1 {
+				return false
+			}
+
+			// trailing data and non-minimal lengths are rejected
+			if _, err = end.SetBytesDER(append(der, 0)); err == nil {
+				return false
+			}
+			long := append([]byte{der[0], 0x81}, der[1:]...)
+			_, err = end.SetBytesDER(long)
+			return err != nil
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
@@ -0,0 +1,492 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrElementExists       = errors.New("element is already accumulated")
+	ErrElementNotFound     = errors.New("element is not accumulated")
+	ErrTrapdoorElement     = errors.New("element is the opposite of the trapdoor")
+	ErrMemberDeleted       = errors.New("the witnessed element has been deleted")
+	ErrNonMemberAdded      = errors.New("the witnessed element has been added")
+	ErrInvalidUpdate       = errors.New("update is inconsistent with the witness")
+	ErrVerifyMembership    = errors.New("can't verify membership witness")
+	ErrVerifyNonMembership = errors.New("can't verify non-membership witness")
+	ErrZeroNbWitnesses     = errors.New("number of witnesses is zero")
+)
+
+// Digest value of the accumulator, [∏ᵢ(α+xᵢ)]G₁.
+type Digest = curve.G1Affine
+
+// MembershipWitness proves that Element is accumulated.
+//
+// W = [∏_{xᵢ≠y}(α+xᵢ)]G₁ where y is the witnessed element.
+type MembershipWitness struct {
+	Element fr.Element
+	W       curve.G1Affine
+}
+
+// NonMembershipWitness proves that Element is not accumulated.
+//
+// Writing f = ∏ᵢ(X+xᵢ) = q⋅(X+y) + r where y is the witnessed element,
+// W = [q(α)]G₁ and R = r = f(-y) ≠ 0.
+type NonMembershipWitness struct {
+	Element fr.Element
+	W       curve.G1Affine
+	R       fr.Element
+}
+
+// Update is published each time an element is added to or deleted from the
+// accumulator, so that witnesses can be updated without the trapdoor.
+type Update struct {
+	Element  fr.Element
+	Deleted  bool
+	Previous Digest // value of the accumulator before the update
+	Acc      Digest // value of the accumulator after the update
+}
+
+// Manager holds the trapdoor α of the accumulator. It maintains the accumulated
+// set and creates witnesses in constant time (linear time for non-membership).
+//
+// The accumulator and the witnesses are compatible with the ones computed from a
+// KZG SRS generated with the same α.
+type Manager struct {
+	alpha    fr.Element
+	product  fr.Element // ∏ᵢ(α+xᵢ)
+	elements map[fr.Element]struct{}
+	acc      Digest
+	vk       kzg.VerifyingKey
+}
+
+// NewManager returns a Manager of an empty accumulator, using bAlpha as trapdoor.
+func NewManager(bAlpha *big.Int) *Manager {
+	m := &Manager{
+		elements: make(map[fr.Element]struct{}),
+	}
+	m.alpha.SetBigInt(bAlpha)
+	m.product.SetOne()
+
+	_, _, gen1Aff, gen2Aff := curve.Generators()
+	m.acc = gen1Aff
+	m.vk.G1 = gen1Aff
+	m.vk.G2[0] = gen2Aff
+	var alpha big.Int
+	m.alpha.BigInt(&alpha)
+	m.vk.G2[1].ScalarMultiplication(&gen2Aff, &alpha)
+
+	return m
+}
+
+// Accumulator returns the current value of the accumulator.
+func (m *Manager) Accumulator() Digest {
+	return m.acc
+}
+
+// VerifyingKey returns the key used to verify the witnesses.
+func (m *Manager) VerifyingKey() kzg.VerifyingKey {
+	return m.vk
+}
+
+// Contains returns true if x is accumulated.
+func (m *Manager) Contains(x fr.Element) bool {
+	_, ok := m.elements[x]
+	return ok
+}
+
+// Len returns the number of accumulated elements.
+func (m *Manager) Len() int {
+	return len(m.elements)
+}
+
+// Add accumulates x and returns the corresponding update.
+func (m *Manager) Add(x fr.Element) (Update, error) {
+	if m.Contains(x) {
+		return Update{}, ErrElementExists
+	}
+	alphaPlusX, err := m.alphaPlus(x)
+	if err != nil {
+		return Update{}, err
+	}
+	update := Update{Element: x, Previous: m.acc}
+	m.product.Mul(&m.product, &alphaPlusX)
+	m.elements[x] = struct{}{}
+	m.acc = baseMul(m.product)
+	update.Acc = m.acc
+	return update, nil
+}
+
+// Delete removes x from the accumulator and returns the corresponding update.
+func (m *Manager) Delete(x fr.Element) (Update, error) {
+	if !m.Contains(x) {
+		return Update{}, ErrElementNotFound
+	}
+	alphaPlusX, err := m.alphaPlus(x)
+	if err != nil {
+		return Update{}, err
+	}
+	update := Update{Element: x, Deleted: true, Previous: m.acc}
+	m.product.Div(&m.product, &alphaPlusX)
+	delete(m.elements, x)
+	m.acc = baseMul(m.product)
+	update.Acc = m.acc
+	return update, nil
+}
+
+// AddBatch accumulates the elements of xs one after the other. It stops at the
+// first error, and returns the updates of the elements added so far.
+func (m *Manager) AddBatch(xs []fr.Element) ([]Update, error) {
+	updates := make([]Update, 0, len(xs))
+	for i := range xs {
+		u, err := m.Add(xs[i])
+		if err != nil {
+			return updates, err
+		}
+		updates = append(updates, u)
+	}
+	return updates, nil
+}
+
+// MembershipWitness returns a witness that y is accumulated.
+func (m *Manager) MembershipWitness(y fr.Element) (MembershipWitness, error) {
+	if !m.Contains(y) {
+		return MembershipWitness{}, ErrElementNotFound
+	}
+	alphaPlusY, err := m.alphaPlus(y)
+	if err != nil {
+		return MembershipWitness{}, err
+	}
+	var w fr.Element
+	w.Div(&m.product, &alphaPlusY)
+	return MembershipWitness{Element: y, W: baseMul(w)}, nil
+}
+
+// NonMembershipWitness returns a witness that y is not accumulated.
+func (m *Manager) NonMembershipWitness(y fr.Element) (NonMembershipWitness, error) {
+	if m.Contains(y) {
+		return NonMembershipWitness{}, ErrElementExists
+	}
+	alphaPlusY, err := m.alphaPlus(y)
+	if err != nil {
+		return NonMembershipWitness{}, err
+	}
+
+	// r = f(-y) = ∏ᵢ(xᵢ-y)
+	res := NonMembershipWitness{Element: y}
+	res.R.SetOne()
+	var tmp fr.Element
+	for x := range m.elements {
+		tmp.Sub(&x, &y)
+		res.R.Mul(&res.R, &tmp)
+	}
+
+	// q(α) = (f(α)-r)/(α+y)
+	tmp.Sub(&m.product, &res.R).Div(&tmp, &alphaPlusY)
+	res.W = baseMul(tmp)
+	return res, nil
+}
+
+// alphaPlus returns α+x, and an error if it is zero.
+func (m *Manager) alphaPlus(x fr.Element) (fr.Element, error) {
+	var res fr.Element
+	res.Add(&m.alpha, &x)
+	if res.IsZero() {
+		return res, ErrTrapdoorElement
+	}
+	return res, nil
+}
+
+// Accumulate computes the accumulator of set using a KZG proving key.
+// len(pk.G1) must be larger than len(set).
+func Accumulate(set []fr.Element, pk kzg.ProvingKey) (Digest, error) {
+	return kzg.Commit(polyFromRoots(set), pk)
+}
+
+// ProveMembership computes a witness that y is in set using a KZG proving key.
+func ProveMembership(set []fr.Element, y fr.Element, pk kzg.ProvingKey) (MembershipWitness, error) {
+	q, r := divideByXPlus(polyFromRoots(set), y)
+	if !r.IsZero() {
+		return MembershipWitness{}, ErrElementNotFound
+	}
+	res := MembershipWitness{Element: y}
+	if len(q) == 0 {
+		return res, nil
+	}
+	var err error
+	res.W, err = kzg.Commit(q, pk)
+	return res, err
+}
+
+// ProveNonMembership computes a witness that y is not in set using a KZG proving key.
+func ProveNonMembership(set []fr.Element, y fr.Element, pk kzg.ProvingKey) (NonMembershipWitness, error) {
+	q, r := divideByXPlus(polyFromRoots(set), y)
+	if r.IsZero() {
+		return NonMembershipWitness{}, ErrElementExists
+	}
+	res := NonMembershipWitness{Element: y, R: r}
+	if len(q) == 0 {
+		// the set is empty, q = 0
+		return res, nil
+	}
+	var err error
+	res.W, err = kzg.Commit(q, pk)
+	return res, err
+}
+
+// VerifyMembership checks that w.Element is accumulated in acc.
+func VerifyMembership(acc *Digest, w *MembershipWitness, vk kzg.VerifyingKey) error {
+
+	// [y]W - Acc
+	var tmp curve.G1Jac
+	tmp.FromAffine(&w.W)
+	tmp.ScalarMultiplication(&tmp, w.Element.BigInt(new(big.Int)))
+	var accJac curve.G1Jac
+	accJac.FromAffine(acc)
+	tmp.SubAssign(&accJac)
+	var lhs curve.G1Affine
+	lhs.FromJacobian(&tmp)
+
+	// e(W, [α]G₂).e([y]W - Acc, G₂) == 1
+	check, err := curve.PairingCheck(
+		[]curve.G1Affine{w.W, lhs},
+		[]curve.G2Affine{vk.G2[1], vk.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyMembership
+	}
+	return nil
+}
+
+// VerifyNonMembership checks that w.Element is not accumulated in acc.
+func VerifyNonMembership(acc *Digest, w *NonMembershipWitness, vk kzg.VerifyingKey) error {
+	if w.R.IsZero() {
+		return ErrVerifyNonMembership
+	}
+
+	// [y]W + [r]G₁ - Acc
+	var tmp, rG1 curve.G1Jac
+	tmp.FromAffine(&w.W)
+	tmp.ScalarMultiplication(&tmp, w.Element.BigInt(new(big.Int)))
+	rG1.ScalarMultiplicationAffine(&vk.G1, w.R.BigInt(new(big.Int)))
+	tmp.AddAssign(&rG1)
+	var accJac curve.G1Jac
+	accJac.FromAffine(acc)
+	tmp.SubAssign(&accJac)
+	var lhs curve.G1Affine
+	lhs.FromJacobian(&tmp)
+
+	// e(W, [α]G₂).e([y]W + [r]G₁ - Acc, G₂) == 1
+	check, err := curve.PairingCheck(
+		[]curve.G1Affine{w.W, lhs},
+		[]curve.G2Affine{vk.G2[1], vk.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyNonMembership
+	}
+	return nil
+}
+
+// BatchVerifyMembership checks a list of membership witnesses against the same
+// accumulator with a single pairing check, using a random linear combination of
+// the verification equations.
+func BatchVerifyMembership(acc *Digest, witnesses []MembershipWitness, vk kzg.VerifyingKey) error {
+	if len(witnesses) == 0 {
+		return ErrZeroNbWitnesses
+	}
+	if len(witnesses) == 1 {
+		return VerifyMembership(acc, &witnesses[0], vk)
+	}
+
+	// sample random numbers λᵢ
+	n := len(witnesses)
+	points := make([]curve.G1Affine, n+1)
+	lambdas := make([]fr.Element, n)
+	scalars := make([]fr.Element, n+1)
+	var sumLambdas fr.Element
+	for i := range witnesses {
+		if _, err := lambdas[i].SetRandom(); err != nil {
+			return err
+		}
+		points[i] = witnesses[i].W
+		scalars[i].Mul(&lambdas[i], &witnesses[i].Element)
+		sumLambdas.Add(&sumLambdas, &lambdas[i])
+	}
+	points[n] = *acc
+	scalars[n].Neg(&sumLambdas)
+
+	// ∑ᵢλᵢWᵢ and ∑ᵢλᵢyᵢWᵢ - (∑ᵢλᵢ)Acc
+	var foldedW, lhs curve.G1Affine
+	config := ecc.MultiExpConfig{}
+	if _, err := foldedW.MultiExp(points[:n], lambdas, config); err != nil {
+		return err
+	}
+	if _, err := lhs.MultiExp(points, scalars, config); err != nil {
+		return err
+	}
+
+	check, err := curve.PairingCheck(
+		[]curve.G1Affine{foldedW, lhs},
+		[]curve.G2Affine{vk.G2[1], vk.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyMembership
+	}
+	return nil
+}
+
+// Update applies the accumulator updates to the witness, in order.
+func (w *MembershipWitness) Update(updates ...Update) error {
+	var d fr.Element
+	for i := range updates {
+		u := &updates[i]
+		if u.Element.Equal(&w.Element) {
+			if u.Deleted {
+				return ErrMemberDeleted
+			}
+			return ErrInvalidUpdate
+		}
+		d.Sub(&u.Element, &w.Element)
+		if u.Deleted {
+			// W' = (W - Acc')/(x-y)
+			w.W = divStep(&w.W, &u.Acc, d)
+		} else {
+			// W' = Acc + (x-y)W
+			w.W = mulStep(&w.W, &u.Previous, d)
+		}
+	}
+	return nil
+}
+
+// Update applies the accumulator updates to the witness, in order.
+func (w *NonMembershipWitness) Update(updates ...Update) error {
+	var d fr.Element
+	for i := range updates {
+		u := &updates[i]
+		if u.Element.Equal(&w.Element) {
+			if !u.Deleted {
+				return ErrNonMemberAdded
+			}
+			return ErrInvalidUpdate
+		}
+		d.Sub(&u.Element, &w.Element)
+		if u.Deleted {
+			// W' = (W - Acc')/(x-y), r' = r/(x-y)
+			w.W = divStep(&w.W, &u.Acc, d)
+			w.R.Div(&w.R, &d)
+		} else {
+			// W' = Acc + (x-y)W, r' = r(x-y)
+			w.W = mulStep(&w.W, &u.Previous, d)
+			w.R.Mul(&w.R, &d)
+		}
+	}
+	return nil
+}
+
+// UpdateMembershipWitnesses applies the accumulator updates to all the
+// witnesses in parallel. It returns the first error encountered, in which case
+// the witnesses are left in an undefined state.
+func UpdateMembershipWitnesses(witnesses []MembershipWitness, updates ...Update) error {
+	errs := make([]error, len(witnesses))
+	parallel.Execute(len(witnesses), func(start, end int) {
+		for i := start; i < end; i++ {
+			errs[i] = witnesses[i].Update(updates...)
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mulStep returns acc + d⋅w
+func mulStep(w, acc *curve.G1Affine, d fr.Element) curve.G1Affine {
+	var res curve.G1Jac
+	res.ScalarMultiplicationAffine(w, d.BigInt(new(big.Int)))
+	res.AddMixed(acc)
+	var resAff curve.G1Affine
+	resAff.FromJacobian(&res)
+	return resAff
+}
+
+// divStep returns (w - acc)/d
+func divStep(w, acc *curve.G1Affine, d fr.Element) curve.G1Affine {
+	var res, accJac curve.G1Jac
+	res.FromAffine(w)
+	accJac.FromAffine(acc)
+	res.SubAssign(&accJac)
+	d.Inverse(&d)
+	res.ScalarMultiplication(&res, d.BigInt(new(big.Int)))
+	var resAff curve.G1Affine
+	resAff.FromJacobian(&res)
+	return resAff
+}
+
+// baseMul returns [s]G₁
+func baseMul(s fr.Element) curve.G1Affine {
+	var res curve.G1Affine
+	res.ScalarMultiplicationBase(s.BigInt(new(big.Int)))
+	return res
+}
+
+// polyFromRoots returns the coefficients of ∏ᵢ(X+xᵢ), in canonical basis
+func polyFromRoots(set []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(set)+1)
+	res[0].SetOne()
+	var tmp fr.Element
+	for i := range set {
+		// res ← res⋅(X+xᵢ)
+		for j := i + 1; j > 0; j-- {
+			tmp.Mul(&res[j], &set[i])
+			res[j].Add(&tmp, &res[j-1])
+		}
+		res[0].Mul(&res[0], &set[i])
+	}
+	return res
+}
+
+// divideByXPlus returns q, r such that f = q⋅(X+y) + r
+func divideByXPlus(f []fr.Element, y fr.Element) ([]fr.Element, fr.Element) {
+	var minusY, r fr.Element
+	minusY.Neg(&y)
+	q := make([]fr.Element, len(f)-1)
+	r = f[len(f)-1]
+	for i := len(f) - 2; i >= 0; i-- {
+		q[i] = r
+		r.Mul(&r, &minusY).Add(&r, &f[i])
+	}
+	return q, r
+}
@@ -0,0 +1,215 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+// Test SRS re-used across tests of the accumulator
+var testSrs *kzg.SRS
+var bAlpha *big.Int
+
+func init() {
+	const srsSize = 32
+	bAlpha = new(big.Int).SetInt64(42)
+	testSrs, _ = kzg.NewSRS(srsSize, bAlpha)
+}
+
+func randomSet(t *testing.T, size int) []fr.Element {
+	res := make([]fr.Element, size)
+	for i := range res {
+		_, err := res[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return res
+}
+
+func TestAccumulatorManager(t *testing.T) {
+	assert := require.New(t)
+
+	set := randomSet(t, 10)
+	m := NewManager(bAlpha)
+	_, err := m.AddBatch(set)
+	assert.NoError(err)
+	assert.Equal(len(set), m.Len())
+
+	// same accumulator with and without the trapdoor
+	acc, err := Accumulate(set, testSrs.Pk)
+	assert.NoError(err)
+	expected := m.Accumulator()
+	assert.True(acc.Equal(&expected))
+	vk := m.VerifyingKey()
+	assert.Equal(testSrs.Vk, vk)
+
+	_, err = m.Add(set[3])
+	assert.ErrorIs(err, ErrElementExists)
+
+	// membership
+	for i := range set {
+		w, err := m.MembershipWitness(set[i])
+		assert.NoError(err)
+		assert.NoError(VerifyMembership(&acc, &w, vk))
+
+		wPublic, err := ProveMembership(set, set[i], testSrs.Pk)
+		assert.NoError(err)
+		assert.True(w.W.Equal(&wPublic.W))
+	}
+
+	// non-membership
+	var y fr.Element
+	y.SetRandom()
+	_, err = m.MembershipWitness(y)
+	assert.ErrorIs(err, ErrElementNotFound)
+	nw, err := m.NonMembershipWitness(y)
+	assert.NoError(err)
+	assert.NoError(VerifyNonMembership(&acc, &nw, vk))
+	nwPublic, err := ProveNonMembership(set, y, testSrs.Pk)
+	assert.NoError(err)
+	assert.True(nw.W.Equal(&nwPublic.W))
+	assert.True(nw.R.Equal(&nwPublic.R))
+
+	_, err = m.NonMembershipWitness(set[0])
+	assert.ErrorIs(err, ErrElementExists)
+
+	// wrong witnesses
+	w, err := m.MembershipWitness(set[0])
+	assert.NoError(err)
+	w.Element = y
+	assert.ErrorIs(VerifyMembership(&acc, &w, vk), ErrVerifyMembership)
+	nw.Element = set[0]
+	assert.ErrorIs(VerifyNonMembership(&acc, &nw, vk), ErrVerifyNonMembership)
+}
+
+func TestAccumulatorEmptySet(t *testing.T) {
+	assert := require.New(t)
+
+	m := NewManager(bAlpha)
+	acc := m.Accumulator()
+	var y fr.Element
+	y.SetRandom()
+
+	nw, err := ProveNonMembership(nil, y, testSrs.Pk)
+	assert.NoError(err)
+	assert.NoError(VerifyNonMembership(&acc, &nw, m.VerifyingKey()))
+
+	_, err = ProveMembership(nil, y, testSrs.Pk)
+	assert.ErrorIs(err, ErrElementNotFound)
+}
+
+func TestAccumulatorWitnessUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	set := randomSet(t, 8)
+	m := NewManager(bAlpha)
+	_, err := m.AddBatch(set[:4])
+	assert.NoError(err)
+	vk := m.VerifyingKey()
+
+	witnesses := make([]MembershipWitness, 4)
+	for i := range witnesses {
+		witnesses[i], err = m.MembershipWitness(set[i])
+		assert.NoError(err)
+	}
+	var y fr.Element
+	y.SetRandom()
+	nw, err := m.NonMembershipWitness(y)
+	assert.NoError(err)
+
+	// add some elements and delete some others
+	updates, err := m.AddBatch(set[4:])
+	assert.NoError(err)
+	u, err := m.Delete(set[5])
+	assert.NoError(err)
+	updates = append(updates, u)
+	u, err = m.Delete(set[3])
+	assert.NoError(err)
+	updates = append(updates, u)
+	_, err = m.Delete(set[3])
+	assert.ErrorIs(err, ErrElementNotFound)
+
+	acc := m.Accumulator()
+
+	// the witness of a deleted element can't be updated
+	assert.ErrorIs(UpdateMembershipWitnesses(witnesses, updates...), ErrMemberDeleted)
+	witnesses = witnesses[:3]
+	for i := range witnesses {
+		witnesses[i], err = m.MembershipWitness(set[i])
+		assert.NoError(err)
+	}
+	assert.NoError(BatchVerifyMembership(&acc, witnesses, vk))
+
+	// update the witnesses
+	for i := range witnesses {
+		w, err := ProveMembership(set[:4], set[i], testSrs.Pk)
+		assert.NoError(err)
+		assert.NoError(w.Update(updates...))
+		assert.True(w.W.Equal(&witnesses[i].W))
+		assert.NoError(VerifyMembership(&acc, &w, vk))
+	}
+	assert.NoError(nw.Update(updates...))
+	assert.NoError(VerifyNonMembership(&acc, &nw, vk))
+
+	// adding the element invalidates the non-membership witness
+	u, err = m.Add(y)
+	assert.NoError(err)
+	assert.ErrorIs(nw.Update(u), ErrNonMemberAdded)
+}
+
+func TestBatchVerifyMembership(t *testing.T) {
+	assert := require.New(t)
+
+	set := randomSet(t, 6)
+	m := NewManager(bAlpha)
+	_, err := m.AddBatch(set)
+	assert.NoError(err)
+	acc := m.Accumulator()
+
+	witnesses := make([]MembershipWitness, len(set))
+	for i := range set {
+		witnesses[i], err = m.MembershipWitness(set[i])
+		assert.NoError(err)
+	}
+	assert.NoError(BatchVerifyMembership(&acc, witnesses, m.VerifyingKey()))
+
+	witnesses[2].W = witnesses[3].W
+	assert.ErrorIs(BatchVerifyMembership(&acc, witnesses, m.VerifyingKey()), ErrVerifyMembership)
+
+	assert.ErrorIs(BatchVerifyMembership(&acc, nil, m.VerifyingKey()), ErrZeroNbWitnesses)
+}
+
+func BenchmarkVerifyMembership(b *testing.B) {
+	set := make([]fr.Element, 16)
+	for i := range set {
+		set[i].SetRandom()
+	}
+	m := NewManager(bAlpha)
+	m.AddBatch(set)
+	acc := m.Accumulator()
+	w, _ := m.MembershipWitness(set[0])
+	vk := m.VerifyingKey()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyMembership(&acc, &w, vk)
+	}
+}
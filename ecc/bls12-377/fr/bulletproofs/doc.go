@@ -0,0 +1,29 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package bulletproofs implements the inner product argument and the range proofs
+// of Bulletproofs (Bünz et al., S&P 2018) on the G1 group of bls12-377.
+//
+// The scheme is transparent: the generators of a Setup are derived with HashToG1 from
+// a domain separation tag, there is no trapdoor. Proofs are logarithmic in the size
+// of the proven statement and are made non-interactive with a fiat-shamir transcript.
+//
+// Range proofs show that committed values lie in [0, 2ⁿ), for n a power of two up to 64.
+// Several values can be proven at once in an aggregated proof, and several proofs
+// can be checked together with a single multi-scalar multiplication (see BatchVerifyRange).
+//
+// See https://eprint.iacr.org/2017/1066.pdf
+package bulletproofs
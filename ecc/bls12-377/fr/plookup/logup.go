@@ -0,0 +1,568 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package plookup
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrLogUpVerification   = errors.New("log-derivative lookup verification failed")
+	ErrNoLookup            = errors.New("there must be at least one vector to look up")
+	ErrMalformedLogUpProof = errors.New("the number of digests or claimed values is inconsistent")
+)
+
+// ProofLogUp is a log-derivative (LogUp) lookup proof, showing that the rows
+// of several tables f₀, f₁, ... all appear in a table t.
+//
+// With T and Fₖ the random linear combinations of the columns of t and fₖ, and
+// m the multiplicities of the rows of t in the fₖ, the proof shows that
+//
+//	∑ᵢ∑ₖ 1/(β-Fₖ(ωⁱ)) = ∑ᵢ m(ωⁱ)/(β-T(ωⁱ))
+//
+// through the helper polynomials hₖ = 1/(β-Fₖ), hₜ = m/(β-T) and the running
+// sum z(ωX) = z(X) + ∑ₖhₖ(X) - hₜ(X).
+type ProofLogUp struct {
+
+	// size of the system
+	size uint64
+
+	// generator of the fft domain, used for shifting the evaluation point
+	g fr.Element
+
+	// Commitments to the columns of the fₖ and of t
+	fs [][]kzg.Digest
+	ts []kzg.Digest
+
+	// Commitment to the multiplicities of the rows of t
+	m kzg.Digest
+
+	// Commitments to the helper polynomials 1/(β-Fₖ) and m/(β-T)
+	hs []kzg.Digest
+	ht kzg.Digest
+
+	// Commitments to the running sum z and to the quotient q
+	z, q kzg.Digest
+
+	// Batch opening proof of fs, ts, m, hs, ht, z, q (in that order)
+	BatchedProof kzg.BatchOpeningProof
+
+	// Opening proof of z shifted by g
+	ShiftedProof kzg.OpeningProof
+}
+
+// ProveLogUpVector generates a proof that all the entries of the vectors in f
+// are entries of t. Entries may be looked up any number of times.
+func ProveLogUpVector(pk kzg.ProvingKey, f []fr.Vector, t fr.Vector) (ProofLogUp, error) {
+	tables := make([][]fr.Vector, len(f))
+	for k := range f {
+		tables[k] = []fr.Vector{f[k]}
+	}
+	return ProveLogUpTables(pk, tables, []fr.Vector{t})
+}
+
+// ProveLogUpTables generates a proof that the rows of the tables in f are rows
+// of t. The tables are given by columns: f[k][c] is the c-th column of the k-th
+// table. All the tables must have the same number of columns, and the columns
+// of a table must have the same size, but the tables in f may have different
+// numbers of rows.
+//
+// For instance, if t is the truth table of the XOR function, t[0][i] XOR t[1][i] = t[2][i],
+// and f[k][0][j] XOR f[k][1][j] = f[k][2][j] for all the rows j of each f[k].
+func ProveLogUpTables(pk kzg.ProvingKey, f [][]fr.Vector, t []fr.Vector) (ProofLogUp, error) {
+
+	// res
+	var proof ProofLogUp
+	var err error
+
+	// check the sizes and get the number of rows of the system
+	nbRows, err := checkLogUpSizes(f, t)
+	if err != nil {
+		return proof, err
+	}
+	nbColumns := len(t)
+
+	// hash function used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "lambda", "beta", "gamma", "nu")
+
+	// create domains
+	domainSmall := fft.NewDomain(uint64(nbRows))
+	domainBig := fft.NewDomain(2 * domainSmall.Cardinality)
+	n := int(domainSmall.Cardinality)
+	proof.size = domainSmall.Cardinality
+	proof.g.Set(&domainSmall.Generator)
+
+	// pad the tables with the first row of t, which doesn't change the lookup
+	lt := padColumns(t, t, n)
+	lf := make([][][]fr.Element, len(f))
+	for k := range f {
+		lf[k] = padColumns(f[k], t, n)
+	}
+
+	lm, err := multiplicities(lf, lt)
+	if err != nil {
+		return proof, err
+	}
+
+	// commit to the columns and the multiplicities
+	ct := make([][]fr.Element, nbColumns)
+	proof.ts = make([]kzg.Digest, nbColumns)
+	for c := range lt {
+		ct[c] = lagrangeToCanonical(lt[c], domainSmall)
+		if proof.ts[c], err = kzg.Commit(ct[c], pk); err != nil {
+			return proof, err
+		}
+	}
+	cf := make([][][]fr.Element, len(lf))
+	proof.fs = make([][]kzg.Digest, len(lf))
+	for k := range lf {
+		cf[k] = make([][]fr.Element, nbColumns)
+		proof.fs[k] = make([]kzg.Digest, nbColumns)
+		for c := range lf[k] {
+			cf[k][c] = lagrangeToCanonical(lf[k][c], domainSmall)
+			if proof.fs[k][c], err = kzg.Commit(cf[k][c], pk); err != nil {
+				return proof, err
+			}
+		}
+	}
+	cm := lagrangeToCanonical(lm, domainSmall)
+	if proof.m, err = kzg.Commit(cm, pk); err != nil {
+		return proof, err
+	}
+
+	// derive lambda, beta
+	lambda, err := deriveRandomness(&fs, "lambda", logUpColumnDigests(&proof)...)
+	if err != nil {
+		return proof, err
+	}
+	beta, err := deriveRandomness(&fs, "beta")
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the helper polynomials hₖ = 1/(β-Fₖ), hₜ = m/(β-T) and the running sum z
+	lhs := make([][]fr.Element, len(lf))
+	for k := range lf {
+		lhs[k] = foldColumns(lf[k], lambda)
+		for i := range lhs[k] {
+			lhs[k][i].Sub(&beta, &lhs[k][i])
+		}
+		lhs[k] = fr.BatchInvert(lhs[k])
+	}
+	lht := foldColumns(lt, lambda)
+	for i := range lht {
+		lht[i].Sub(&beta, &lht[i])
+	}
+	lht = fr.BatchInvert(lht)
+	for i := range lht {
+		lht[i].Mul(&lht[i], &lm[i])
+	}
+	lz := make([]fr.Element, n)
+	for i := 0; i < n-1; i++ {
+		lz[i+1].Sub(&lz[i], &lht[i])
+		for k := range lhs {
+			lz[i+1].Add(&lz[i+1], &lhs[k][i])
+		}
+	}
+
+	chs := make([][]fr.Element, len(lhs))
+	proof.hs = make([]kzg.Digest, len(lhs))
+	for k := range lhs {
+		chs[k] = lagrangeToCanonical(lhs[k], domainSmall)
+		if proof.hs[k], err = kzg.Commit(chs[k], pk); err != nil {
+			return proof, err
+		}
+	}
+	cht := lagrangeToCanonical(lht, domainSmall)
+	if proof.ht, err = kzg.Commit(cht, pk); err != nil {
+		return proof, err
+	}
+	cz := lagrangeToCanonical(lz, domainSmall)
+	if proof.z, err = kzg.Commit(cz, pk); err != nil {
+		return proof, err
+	}
+
+	// derive gamma, used to fold the constraints
+	helpers := []*kzg.Digest{&proof.ht, &proof.z}
+	for k := range proof.hs {
+		helpers = append(helpers, &proof.hs[k])
+	}
+	gamma, err := deriveRandomness(&fs, "gamma", helpers...)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the constraints on the big domain
+	_lt := make([][]fr.Element, nbColumns)
+	for c := range ct {
+		_lt[c] = evaluateOnCosetBig(ct[c], domainBig)
+	}
+	_lT := foldColumns(_lt, lambda)
+	_lht := evaluateOnCosetBig(cht, domainBig)
+	_lm := evaluateOnCosetBig(cm, domainBig)
+	_lz := evaluateOnCosetBig(cz, domainBig)
+
+	// constraint on z: z(gX) - z(X) - ∑ₖhₖ(X) + hₜ(X)
+	nBig := len(_lz)
+	num := make([]fr.Element, nBig)
+	for i := range num {
+		num[i].Sub(&_lz[(i+2)%nBig], &_lz[i]).Add(&num[i], &_lht[i])
+	}
+
+	// constraint on hₜ: hₜ(X)(β-T(X)) - m(X)
+	var tmp fr.Element
+	for i := range _lT {
+		tmp.Sub(&beta, &_lT[i]).Mul(&tmp, &_lht[i]).Sub(&tmp, &_lm[i])
+		_lT[i].Mul(&tmp, &gamma)
+	}
+
+	// constraints on hₖ: hₖ(X)(β-Fₖ(X)) - 1
+	var one, gammaPow fr.Element
+	one.SetOne()
+	gammaPow.Square(&gamma)
+	_lfk := make([][]fr.Element, nbColumns)
+	for k := range chs {
+		for c := range cf[k] {
+			_lfk[c] = evaluateOnCosetBig(cf[k][c], domainBig)
+		}
+		_lF := foldColumns(_lfk, lambda)
+		_lh := evaluateOnCosetBig(chs[k], domainBig)
+		for i := range num {
+			num[i].Sub(&num[i], &_lh[i])
+			tmp.Sub(&beta, &_lF[i]).Mul(&tmp, &_lh[i]).Sub(&tmp, &one).Mul(&tmp, &gammaPow)
+			_lT[i].Add(&_lT[i], &tmp)
+		}
+		gammaPow.Mul(&gammaPow, &gamma)
+	}
+
+	// divide by Xⁿ-1 and go back to the canonical basis
+	xnMinusOne := evaluateXnMinusOneDomainBig(domainBig)
+	xnMinusOne[0].Inverse(&xnMinusOne[0])
+	xnMinusOne[1].Inverse(&xnMinusOne[1])
+	for i := range num {
+		num[i].Add(&num[i], &_lT[i]).Mul(&num[i], &xnMinusOne[i%2])
+	}
+	domainBig.FFTInverse(num, fft.DIF, fft.OnCoset())
+	fft.BitReverse(num)
+	cq := num[:n]
+	if proof.q, err = kzg.Commit(cq, pk); err != nil {
+		return proof, err
+	}
+
+	// build the opening proofs
+	nu, err := deriveRandomness(&fs, "nu", &proof.q)
+	if err != nil {
+		return proof, err
+	}
+	polynomials := make([][]fr.Element, 0, len(proof.fs)*(nbColumns+1)+nbColumns+4)
+	for k := range cf {
+		polynomials = append(polynomials, cf[k]...)
+	}
+	polynomials = append(polynomials, ct...)
+	polynomials = append(polynomials, cm)
+	polynomials = append(polynomials, chs...)
+	polynomials = append(polynomials, cht, cz, cq)
+	proof.BatchedProof, err = kzg.BatchOpenSinglePoint(
+		polynomials,
+		logUpDigests(&proof),
+		nu,
+		hFunc,
+		pk,
+	)
+	if err != nil {
+		return proof, err
+	}
+
+	nu.Mul(&nu, &domainSmall.Generator)
+	proof.ShiftedProof, err = kzg.Open(cz, nu, pk)
+
+	return proof, err
+}
+
+// VerifyLogUp verifies that a ProofLogUp proof is correct.
+func VerifyLogUp(vk kzg.VerifyingKey, proof ProofLogUp) error {
+
+	// check the shape of the proof
+	nbTables, nbColumns := len(proof.fs), len(proof.ts)
+	if nbTables == 0 || nbColumns == 0 || len(proof.hs) != nbTables {
+		return ErrMalformedLogUpProof
+	}
+	for k := range proof.fs {
+		if len(proof.fs[k]) != nbColumns {
+			return ErrMalformedLogUpProof
+		}
+	}
+	if len(proof.BatchedProof.ClaimedValues) != nbTables*(nbColumns+1)+nbColumns+4 {
+		return ErrMalformedLogUpProof
+	}
+
+	// hash function that is used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "lambda", "beta", "gamma", "nu")
+
+	// derive the various challenges
+	lambda, err := deriveRandomness(&fs, "lambda", logUpColumnDigests(&proof)...)
+	if err != nil {
+		return err
+	}
+	beta, err := deriveRandomness(&fs, "beta")
+	if err != nil {
+		return err
+	}
+	helpers := []*kzg.Digest{&proof.ht, &proof.z}
+	for k := range proof.hs {
+		helpers = append(helpers, &proof.hs[k])
+	}
+	gamma, err := deriveRandomness(&fs, "gamma", helpers...)
+	if err != nil {
+		return err
+	}
+	nu, err := deriveRandomness(&fs, "nu", &proof.q)
+	if err != nil {
+		return err
+	}
+
+	// check opening proofs
+	err = kzg.BatchVerifySinglePoint(
+		logUpDigests(&proof),
+		&proof.BatchedProof,
+		nu,
+		hFunc,
+		vk,
+	)
+	if err != nil {
+		return err
+	}
+
+	// shift the point and verify shifted proof
+	var shiftedNu fr.Element
+	shiftedNu.Mul(&nu, &proof.g)
+	if err = kzg.Verify(&proof.z, &proof.ShiftedProof, shiftedNu, vk); err != nil {
+		return err
+	}
+
+	// check the generator is correct
+	var checkOrder, one fr.Element
+	one.SetOne()
+	checkOrder.Exp(proof.g, big.NewInt(int64(proof.size/2)))
+	if checkOrder.Equal(&one) {
+		return ErrGenerator
+	}
+	checkOrder.Square(&checkOrder)
+	if !checkOrder.Equal(&one) {
+		return ErrGenerator
+	}
+
+	// claimed values of fs, ts, m, hs, ht, z, q
+	claimed := proof.BatchedProof.ClaimedValues
+	f := make([][]fr.Element, nbTables)
+	for k := range f {
+		f[k] = claimed[:nbColumns]
+		claimed = claimed[nbColumns:]
+	}
+	t := claimed[:nbColumns]
+	claimed = claimed[nbColumns:]
+	m := claimed[0]
+	hs := claimed[1 : 1+nbTables]
+	ht, z, q := claimed[1+nbTables], claimed[2+nbTables], claimed[3+nbTables]
+
+	// z(gν) - z(ν) - ∑ₖhₖ(ν) + hₜ(ν) + γ(hₜ(ν)(β-T(ν)) - m(ν)) + ∑ₖγ²⁺ᵏ(hₖ(ν)(β-Fₖ(ν)) - 1)
+	var num, tmp, gammaPow fr.Element
+	num.Sub(&proof.ShiftedProof.ClaimedValue, &z).Add(&num, &ht)
+	T := foldValues(t, lambda)
+	tmp.Sub(&beta, &T).Mul(&tmp, &ht).Sub(&tmp, &m).Mul(&tmp, &gamma)
+	num.Add(&num, &tmp)
+	gammaPow.Square(&gamma)
+	for k := range hs {
+		num.Sub(&num, &hs[k])
+		F := foldValues(f[k], lambda)
+		tmp.Sub(&beta, &F).Mul(&tmp, &hs[k]).Sub(&tmp, &one).Mul(&tmp, &gammaPow)
+		num.Add(&num, &tmp)
+		gammaPow.Mul(&gammaPow, &gamma)
+	}
+
+	// (νⁿ-1) * q(ν)
+	var nun fr.Element
+	nun.Exp(nu, big.NewInt(int64(proof.size))).Sub(&nun, &one).Mul(&nun, &q)
+	if !num.Equal(&nun) {
+		return ErrLogUpVerification
+	}
+
+	return nil
+}
+
+// checkLogUpSizes checks the shapes of f and t and returns the size of the
+// largest column.
+func checkLogUpSizes(f [][]fr.Vector, t []fr.Vector) (int, error) {
+	if len(f) == 0 || len(t) == 0 {
+		return 0, ErrNoLookup
+	}
+	nbRows := len(t[0])
+	if nbRows == 0 {
+		return 0, ErrIncompatibleSize
+	}
+	for c := range t {
+		if len(t[c]) != len(t[0]) {
+			return 0, ErrIncompatibleSize
+		}
+	}
+	for k := range f {
+		if len(f[k]) != len(t) || len(f[k][0]) == 0 {
+			return 0, ErrIncompatibleSize
+		}
+		for c := range f[k] {
+			if len(f[k][c]) != len(f[k][0]) {
+				return 0, ErrIncompatibleSize
+			}
+		}
+		if len(f[k][0]) > nbRows {
+			nbRows = len(f[k][0])
+		}
+	}
+	return nbRows, nil
+}
+
+// padColumns copies the columns to vectors of size n, padded with the first
+// row of t.
+func padColumns(columns, t []fr.Vector, n int) [][]fr.Element {
+	res := make([][]fr.Element, len(columns))
+	for c := range columns {
+		res[c] = make([]fr.Element, n)
+		copy(res[c], columns[c])
+		for i := len(columns[c]); i < n; i++ {
+			res[c][i] = t[c][0]
+		}
+	}
+	return res
+}
+
+// multiplicities returns the vector m such that m[i] is the number of
+// occurrences of the i-th row of t in the tables f. A row appearing several
+// times in t has all its occurrences counted at its first index.
+func multiplicities(f [][][]fr.Element, t [][]fr.Element) ([]fr.Element, error) {
+	rowKey := func(columns [][]fr.Element, i int) string {
+		key := make([]byte, 0, len(columns)*fr.Bytes)
+		for c := range columns {
+			b := columns[c][i].Bytes()
+			key = append(key, b[:]...)
+		}
+		return string(key)
+	}
+
+	n := len(t[0])
+	index := make(map[string]int, n)
+	for i := n - 1; i >= 0; i-- {
+		index[rowKey(t, i)] = i
+	}
+
+	counts := make([]uint64, n)
+	for k := range f {
+		for i := range f[k][0] {
+			j, ok := index[rowKey(f[k], i)]
+			if !ok {
+				return nil, ErrNotInTable
+			}
+			counts[j]++
+		}
+	}
+
+	res := make([]fr.Element, n)
+	for i := range counts {
+		res[i].SetUint64(counts[i])
+	}
+	return res, nil
+}
+
+// foldColumns returns ∑ᵢλⁱcolumns[i].
+func foldColumns(columns [][]fr.Element, lambda fr.Element) []fr.Element {
+	res := make([]fr.Element, len(columns[0]))
+	copy(res, columns[len(columns)-1])
+	for c := len(columns) - 2; c >= 0; c-- {
+		for i := range res {
+			res[i].Mul(&res[i], &lambda).Add(&res[i], &columns[c][i])
+		}
+	}
+	return res
+}
+
+// foldValues returns ∑ᵢλⁱvalues[i].
+func foldValues(values []fr.Element, lambda fr.Element) fr.Element {
+	res := values[len(values)-1]
+	for c := len(values) - 2; c >= 0; c-- {
+		res.Mul(&res, &lambda).Add(&res, &values[c])
+	}
+	return res
+}
+
+// lagrangeToCanonical returns the coefficients of the polynomial whose
+// evaluations on the domain are l.
+func lagrangeToCanonical(l []fr.Element, domain *fft.Domain) []fr.Element {
+	res := make([]fr.Element, len(l))
+	copy(res, l)
+	domain.FFTInverse(res, fft.DIF)
+	fft.BitReverse(res)
+	return res
+}
+
+// evaluateOnCosetBig returns the evaluations of the polynomial of coefficients
+// p on FrMultiplicativeGen*< g >, in natural order.
+func evaluateOnCosetBig(p []fr.Element, domainBig *fft.Domain) []fr.Element {
+	res := make([]fr.Element, domainBig.Cardinality)
+	copy(res, p)
+	domainBig.FFT(res, fft.DIF, fft.OnCoset())
+	fft.BitReverse(res)
+	return res
+}
+
+// logUpColumnDigests returns the digests bound to the challenge lambda.
+func logUpColumnDigests(proof *ProofLogUp) []*bls12377.G1Affine {
+	res := make([]*bls12377.G1Affine, 0, len(proof.fs)*len(proof.ts)+len(proof.ts)+1)
+	for k := range proof.fs {
+		for c := range proof.fs[k] {
+			res = append(res, &proof.fs[k][c])
+		}
+	}
+	for c := range proof.ts {
+		res = append(res, &proof.ts[c])
+	}
+	return append(res, &proof.m)
+}
+
+// logUpDigests returns the digests opened in proof.BatchedProof.
+func logUpDigests(proof *ProofLogUp) []kzg.Digest {
+	res := make([]kzg.Digest, 0, len(proof.fs)*(len(proof.ts)+1)+len(proof.ts)+4)
+	for k := range proof.fs {
+		res = append(res, proof.fs[k]...)
+	}
+	res = append(res, proof.ts...)
+	res = append(res, proof.m)
+	res = append(res, proof.hs...)
+	return append(res, proof.ht, proof.z, proof.q)
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package rescue
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomElements(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestMDS(t *testing.T) {
+	m := GetParams().MDS
+
+	// every square submatrix of an MDS matrix is invertible
+	for i := 0; i < Width; i++ {
+		for j := 0; j < Width; j++ {
+			require.False(t, m[i][j].IsZero())
+			for k := i + 1; k < Width; k++ {
+				for l := j + 1; l < Width; l++ {
+					var a, b fr.Element
+					a.Mul(&m[i][j], &m[k][l])
+					b.Mul(&m[i][l], &m[k][j])
+					require.False(t, a.Equal(&b))
+				}
+			}
+		}
+	}
+	var det, t0, t1 fr.Element
+	for j := 0; j < Width; j++ {
+		t0.Mul(&m[1][(j+1)%Width], &m[2][(j+2)%Width])
+		t1.Mul(&m[1][(j+2)%Width], &m[2][(j+1)%Width])
+		t0.Sub(&t0, &t1).Mul(&t0, &m[0][j])
+		det.Add(&det, &t0)
+	}
+	require.False(t, det.IsZero())
+}
+
+func TestHash(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	inputs := randomElements(5)
+
+	h := NewRescuePrime()
+	for i := range inputs {
+		b := inputs[i].Bytes()
+		_, err := h.Write(b[:])
+		assert.NoError(err)
+	}
+	expected := params.Hash(inputs)
+	bytes := expected[0].Bytes()
+	assert.Equal(bytes[:], h.Sum(nil))
+	assert.Equal(bytes[:], h.Sum(nil), "Sum doesn't change the state")
+
+	h.Reset()
+	h.(interface{ WriteElements(...fr.Element) }).WriteElements(inputs...)
+	assert.Equal(bytes[:], h.Sum(nil))
+
+	// the padding tells apart trailing zeros
+	padded := params.Hash(append(inputs, fr.Element{}))
+	assert.NotEqual(expected, padded)
+
+	_, err := h.Write(make([]byte, BlockSize-1))
+	assert.Error(err)
+}
@@ -0,0 +1,413 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"runtime"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/consensys/gnark-crypto/utils"
+)
+
+// Term cⱼ∏_{k∈Factors}Pₖ of a virtual polynomial
+type Term struct {
+	Coeff   fr.Element
+	Factors []int // indexes of the multilinear tables
+}
+
+// VirtualPolynomial P = ∑ⱼcⱼ∏_{k∈Sⱼ}Pₖ given by multilinear tables Pₖ of the
+// same size, i.e. a polynomial of degree maxⱼ|Sⱼ| in each variable.
+type VirtualPolynomial struct {
+	Tables []polynomial.MultiLin
+	Terms  []Term
+}
+
+// degree of the virtual polynomial in each variable
+func degree(terms []Term) int {
+	res := 1
+	for i := range terms {
+		res = utils.Max(res, len(terms[i].Factors))
+	}
+	return res
+}
+
+// evaluate returns ∑ⱼcⱼ∏_{k∈Sⱼ}values[k]
+func evaluate(terms []Term, values []fr.Element) fr.Element {
+	var res, prod fr.Element
+	for i := range terms {
+		prod = terms[i].Coeff
+		for _, k := range terms[i].Factors {
+			prod.Mul(&prod, &values[k])
+		}
+		res.Add(&res, &prod)
+	}
+	return res
+}
+
+// ProductSumClaims is a Claims implementation for ∑_{i<2ⁿ}P(i) = s, where P is
+// a VirtualPolynomial, or for a zero-check ∑_{i<2ⁿ}eq(τ, i)P(i) = 0.
+//
+// The final evaluation proof is the list of the evaluations of the tables at the
+// sumcheck challenges, which must then be checked against commitments to the tables.
+type ProductSumClaims struct {
+	p    VirtualPolynomial
+	pool *utils.WorkerPool
+
+	// zero-check only. The round polynomial is gⱼ(X) = αⱼ·eq(τⱼ, X)·hⱼ(X) where
+	// αⱼ = ∏_{k<j}eq(τₖ, rₖ) and hⱼ(X) = ∑ᵢeq(τ_{>j}, i)P(r₁, ..., rⱼ₋₁, X, i),
+	// so that hⱼ has one degree less than gⱼ. eqTable holds eq(τ_{>j}, ·).
+	tau     []fr.Element
+	eqTable polynomial.MultiLin
+	alpha   fr.Element
+	hClaim  fr.Element // ∑_{i}eq(τ_{≥j}, i)P(r₁, ..., rⱼ₋₁, i), i.e. the current claim divided by αⱼ
+	round   int
+	hValues []fr.Element
+}
+
+// NewProductSumClaims returns the claims for ∑_{i<2ⁿ}P(i). The tables are folded
+// in place. If pool is nil, the computation is not parallelized.
+func NewProductSumClaims(p VirtualPolynomial, pool *utils.WorkerPool) (*ProductSumClaims, error) {
+	if err := checkVirtualPolynomial(p); err != nil {
+		return nil, err
+	}
+	return &ProductSumClaims{p: p, pool: pool}, nil
+}
+
+// NewZeroCheckClaims returns the claims for ∑_{i<2ⁿ}eq(τ, i)P(i) = 0, which for a
+// random τ shows that P vanishes on the hypercube. The tables are folded in place.
+// If pool is nil, the computation is not parallelized.
+func NewZeroCheckClaims(p VirtualPolynomial, tau []fr.Element, pool *utils.WorkerPool) (*ProductSumClaims, error) {
+	if err := checkVirtualPolynomial(p); err != nil {
+		return nil, err
+	}
+	if 1<<len(tau) != len(p.Tables[0]) {
+		return nil, errors.New("τ must have as many coordinates as the tables have variables")
+	}
+	res := &ProductSumClaims{p: p, pool: pool, tau: tau}
+	res.eqTable = make(polynomial.MultiLin, len(p.Tables[0])/2)
+	res.eqTable[0].SetOne()
+	res.eqTable.Eq(tau[1:])
+	res.alpha.SetOne()
+	return res, nil
+}
+
+func checkVirtualPolynomial(p VirtualPolynomial) error {
+	if len(p.Tables) == 0 {
+		return errors.New("no tables")
+	}
+	n := len(p.Tables[0])
+	if n < 2 || bits.OnesCount(uint(n)) != 1 {
+		return errors.New("the size of the tables must be a power of two, at least 2")
+	}
+	for i := range p.Tables {
+		if len(p.Tables[i]) != n {
+			return errors.New("all tables must have the same size")
+		}
+	}
+	for i := range p.Terms {
+		for _, k := range p.Terms[i].Factors {
+			if k < 0 || k >= len(p.Tables) {
+				return fmt.Errorf("term %d: factor index %d out of range", i, k)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *ProductSumClaims) VarsNum() int {
+	return bits.TrailingZeros(uint(len(c.p.Tables[0])))
+}
+
+func (c *ProductSumClaims) ClaimsNum() int {
+	return 1
+}
+
+// Combine returns the first round polynomial. There is a single claim, so the
+// combination coefficient is ignored.
+func (c *ProductSumClaims) Combine(fr.Element) polynomial.Polynomial {
+	return c.computeRound()
+}
+
+// Next folds the tables on the first remaining variable and returns the next round polynomial
+func (c *ProductSumClaims) Next(r fr.Element) polynomial.Polynomial {
+	c.fold(r)
+	return c.computeRound()
+}
+
+// ProveFinalEval returns the evaluations of the tables at r
+func (c *ProductSumClaims) ProveFinalEval(r []fr.Element) interface{} {
+	c.fold(r[len(r)-1])
+	res := make([]fr.Element, len(c.p.Tables))
+	for i := range res {
+		res[i] = c.p.Tables[i][0]
+	}
+	return res
+}
+
+func (c *ProductSumClaims) fold(r fr.Element) {
+	if c.tau != nil {
+		// hⱼ₊₁ claim and αⱼ₊₁ = αⱼ·eq(τⱼ, rⱼ)
+		c.hClaim = evalOnRange(c.hValues, r)
+		e := eqAt(c.tau[c.round], r)
+		c.alpha.Mul(&c.alpha, &e)
+		c.round++
+	}
+
+	mid := len(c.p.Tables[0]) / 2
+	c.execute(mid, func(start, end int) {
+		var t fr.Element
+		for _, table := range c.p.Tables {
+			for i := start; i < end; i++ {
+				t.Sub(&table[i+mid], &table[i]).Mul(&t, &r)
+				table[i].Add(&table[i], &t)
+			}
+		}
+	})
+	for i := range c.p.Tables {
+		c.p.Tables[i] = c.p.Tables[i][:mid]
+	}
+
+	// eq(τ_{>j+1}, i) = ∑_{b∈{0,1}}eq(τ_{>j}, (b, i)) since eq(τⱼ₊₁, 0) + eq(τⱼ₊₁, 1) = 1
+	if c.tau != nil && len(c.eqTable) > 1 {
+		mid = len(c.eqTable) / 2
+		c.execute(mid, func(start, end int) {
+			for i := start; i < end; i++ {
+				c.eqTable[i].Add(&c.eqTable[i], &c.eqTable[i+mid])
+			}
+		})
+		c.eqTable = c.eqTable[:mid]
+	}
+}
+
+// computeRound returns the evaluations of the round polynomial at 1, ..., deg
+func (c *ProductSumClaims) computeRound() polynomial.Polynomial {
+	d := degree(c.p.Terms)
+
+	if c.tau == nil {
+		// g(0) is inferred by the verifier
+		return c.sums(d, 0, nil)[1:]
+	}
+
+	// hⱼ(1) is inferred from hⱼ(0)·eq(τⱼ, 0) + hⱼ(1)·eq(τⱼ, 1) = the current claim
+	tau := c.tau[c.round]
+	skip := 1
+	if tau.IsZero() {
+		skip = -1
+	}
+	c.hValues = c.sums(d, skip, c.eqTable)
+	if skip == 1 {
+		var oneMinusTau, t fr.Element
+		oneMinusTau.SetOne()
+		oneMinusTau.Sub(&oneMinusTau, &tau)
+		t.Mul(&c.hValues[0], &oneMinusTau)
+		c.hValues[1].Sub(&c.hClaim, &t).Div(&c.hValues[1], &tau)
+	}
+
+	// gⱼ(t) = αⱼ·eq(τⱼ, t)·hⱼ(t) for t = 1, ..., d+1
+	res := make(polynomial.Polynomial, d+1)
+	var x fr.Element
+	for t := 1; t <= d+1; t++ {
+		x.SetUint64(uint64(t))
+		var h fr.Element
+		if t <= d {
+			h = c.hValues[t]
+		} else {
+			h = evalOnRange(c.hValues, x)
+		}
+		e := eqAt(tau, x)
+		res[t-1].Mul(&h, &e).Mul(&res[t-1], &c.alpha)
+	}
+	return res
+}
+
+// sums returns ∑_{i<mid}w[i]·P(t, i) for t = 0, ..., d, except for t = skip, where
+// w is taken to be 1 if nil.
+func (c *ProductSumClaims) sums(d, skip int, w polynomial.MultiLin) []fr.Element {
+	mid := len(c.p.Tables[0]) / 2
+	res := make([]fr.Element, d+1)
+	var lock sync.Mutex
+
+	c.execute(mid, func(start, end int) {
+		partial := make([]fr.Element, d+1)
+		values := make([]fr.Element, len(c.p.Tables)) // Pₖ(t, i)
+		steps := make([]fr.Element, len(c.p.Tables))  // Pₖ(1, i) - Pₖ(0, i)
+		var v fr.Element
+		for i := start; i < end; i++ {
+			for k, table := range c.p.Tables {
+				values[k] = table[i]
+				steps[k].Sub(&table[i+mid], &table[i])
+			}
+			for t := 0; t <= d; t++ {
+				if t != 0 {
+					for k := range values {
+						values[k].Add(&values[k], &steps[k])
+					}
+				}
+				if t == skip {
+					continue
+				}
+				v = evaluate(c.p.Terms, values)
+				if w != nil {
+					v.Mul(&v, &w[i])
+				}
+				partial[t].Add(&partial[t], &v)
+			}
+		}
+		lock.Lock()
+		for t := range res {
+			res[t].Add(&res[t], &partial[t])
+		}
+		lock.Unlock()
+	})
+
+	return res
+}
+
+// execute runs work on [0, n), in parallel if a pool is available
+func (c *ProductSumClaims) execute(n int, work func(start, end int)) {
+	const minBlock = 1 << 8
+	if c.pool == nil || n <= minBlock {
+		work(0, n)
+		return
+	}
+	block := utils.Max(minBlock, n/(4*runtime.NumCPU()))
+	c.pool.Submit(n, work, block).Wait()
+}
+
+// ProductSumLazyClaims is the LazyClaims counterpart of ProductSumClaims.
+type ProductSumLazyClaims struct {
+	terms      []Term
+	nbTables   int
+	nbVars     int
+	claimedSum fr.Element
+	tau        []fr.Element
+
+	// Tables, if set, are evaluated by the verifier. Otherwise, the final
+	// evaluations provided by the prover must be checked against commitments.
+	Tables []polynomial.MultiLin
+
+	// Challenges and FinalEvaluations are set by a successful verification:
+	// the tables evaluate to FinalEvaluations at Challenges.
+	Challenges       []fr.Element
+	FinalEvaluations []fr.Element
+}
+
+// NewProductSumLazyClaims returns the verifier claims for ∑_{i<2ⁿ}P(i) = claimedSum,
+// P being a virtual polynomial in nbVars variables with the given terms, over nbTables tables.
+func NewProductSumLazyClaims(terms []Term, nbTables, nbVars int, claimedSum fr.Element) *ProductSumLazyClaims {
+	return &ProductSumLazyClaims{terms: terms, nbTables: nbTables, nbVars: nbVars, claimedSum: claimedSum}
+}
+
+// NewZeroCheckLazyClaims returns the verifier claims for ∑_{i<2ⁿ}eq(τ, i)P(i) = 0.
+func NewZeroCheckLazyClaims(terms []Term, nbTables int, tau []fr.Element) *ProductSumLazyClaims {
+	return &ProductSumLazyClaims{terms: terms, nbTables: nbTables, nbVars: len(tau), tau: tau}
+}
+
+func (c *ProductSumLazyClaims) ClaimsNum() int {
+	return 1
+}
+
+func (c *ProductSumLazyClaims) VarsNum() int {
+	return c.nbVars
+}
+
+func (c *ProductSumLazyClaims) CombinedSum(fr.Element) fr.Element {
+	return c.claimedSum
+}
+
+func (c *ProductSumLazyClaims) Degree(int) int {
+	if c.tau != nil {
+		return degree(c.terms) + 1
+	}
+	return degree(c.terms)
+}
+
+func (c *ProductSumLazyClaims) VerifyFinalEval(r []fr.Element, _ fr.Element, purportedValue fr.Element, proof interface{}) error {
+	evaluations, ok := proof.([]fr.Element)
+	if !ok || len(evaluations) != c.nbTables {
+		return errors.New("malformed final evaluation proof")
+	}
+	if c.Tables != nil {
+		if len(c.Tables) != c.nbTables {
+			return errors.New("wrong number of tables")
+		}
+		for i := range c.Tables {
+			if e := c.Tables[i].Evaluate(r, nil); !e.Equal(&evaluations[i]) {
+				return fmt.Errorf("table %d: incorrect evaluation", i)
+			}
+		}
+	}
+
+	expected := evaluate(c.terms, evaluations)
+	if c.tau != nil {
+		e := polynomial.EvalEq(c.tau, r)
+		expected.Mul(&expected, &e)
+	}
+	if !expected.Equal(&purportedValue) {
+		return errors.New("incorrect final evaluation")
+	}
+
+	c.Challenges = r
+	c.FinalEvaluations = evaluations
+	return nil
+}
+
+// eqAt returns eq(τ, x) = τx + (1-τ)(1-x)
+func eqAt(tau, x fr.Element) fr.Element {
+	var res, t, one fr.Element
+	one.SetOne()
+	res.Mul(&tau, &x).Double(&res)
+	t.Add(&tau, &x)
+	res.Sub(&res, &t).Add(&res, &one)
+	return res
+}
+
+// evalOnRange returns p(x) for the polynomial p of degree less than len(values)
+// such that p(i) = values[i], with Lagrange interpolation.
+func evalOnRange(values []fr.Element, x fr.Element) fr.Element {
+	n := len(values)
+
+	// Lagrange basis at x: ∏_{j≠i}(x-j)/(i-j)
+	xMinus := make([]fr.Element, n)
+	for j := range xMinus {
+		var jj fr.Element
+		jj.SetUint64(uint64(j))
+		xMinus[j].Sub(&x, &jj)
+	}
+
+	var res, num, den, t fr.Element
+	for i := range values {
+		num.SetOne()
+		den.SetOne()
+		for j := 0; j < n; j++ {
+			if j == i {
+				continue
+			}
+			num.Mul(&num, &xMinus[j])
+			t.SetInt64(int64(i - j))
+			den.Mul(&den, &t)
+		}
+		num.Div(&num, &den).Mul(&num, &values[i])
+		res.Add(&res, &num)
+	}
+	return res
+}
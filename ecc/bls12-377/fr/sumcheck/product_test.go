@@ -0,0 +1,187 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func randomTables(nbTables, nbVars int) []polynomial.MultiLin {
+	res := make([]polynomial.MultiLin, nbTables)
+	for i := range res {
+		res[i] = make(polynomial.MultiLin, 1<<nbVars)
+		for j := range res[i] {
+			res[i][j].SetRandom()
+		}
+	}
+	return res
+}
+
+func cloneTables(tables []polynomial.MultiLin) []polynomial.MultiLin {
+	res := make([]polynomial.MultiLin, len(tables))
+	for i := range tables {
+		res[i] = tables[i].Clone()
+	}
+	return res
+}
+
+func elementOf(i int64) (res fr.Element) {
+	res.SetInt64(i)
+	return
+}
+
+// 3·A·B·C + B - 2·A²
+var testTerms = []Term{
+	{Coeff: elementOf(3), Factors: []int{0, 1, 2}},
+	{Coeff: elementOf(1), Factors: []int{1}},
+	{Coeff: elementOf(-2), Factors: []int{0, 0}},
+}
+
+func TestProductSum(t *testing.T) {
+	assert := require.New(t)
+	pool := utils.NewWorkerPool()
+	defer pool.Stop()
+
+	for _, nbVars := range []int{1, 2, 5, 11} {
+		tables := randomTables(3, nbVars)
+
+		// ∑ᵢP(i)
+		var sum fr.Element
+		values := make([]fr.Element, len(tables))
+		for i := range tables[0] {
+			for k := range tables {
+				values[k] = tables[k][i]
+			}
+			v := evaluate(testTerms, values)
+			sum.Add(&sum, &v)
+		}
+
+		claims, err := NewProductSumClaims(VirtualPolynomial{Tables: cloneTables(tables), Terms: testTerms}, pool)
+		assert.NoError(err)
+		proof, err := Prove(claims, fiatshamir.WithHash(sha256.New()))
+		assert.NoError(err)
+
+		// the parallel and sequential provers agree
+		claims, err = NewProductSumClaims(VirtualPolynomial{Tables: cloneTables(tables), Terms: testTerms}, nil)
+		assert.NoError(err)
+		sequential, err := Prove(claims, fiatshamir.WithHash(sha256.New()))
+		assert.NoError(err)
+		assert.Equal(proof, sequential)
+
+		// the verifier evaluates the tables itself
+		lazy := NewProductSumLazyClaims(testTerms, len(tables), nbVars, sum)
+		lazy.Tables = tables
+		assert.NoError(Verify(lazy, proof, fiatshamir.WithHash(sha256.New())))
+
+		// the verifier relies on the evaluations provided by the prover
+		lazy = NewProductSumLazyClaims(testTerms, len(tables), nbVars, sum)
+		assert.NoError(Verify(lazy, proof, fiatshamir.WithHash(sha256.New())))
+		for k := range tables {
+			expected := tables[k].Evaluate(lazy.Challenges, nil)
+			assert.True(expected.Equal(&lazy.FinalEvaluations[k]))
+		}
+
+		// wrong sum
+		sum.Add(&sum, &values[0])
+		lazy = NewProductSumLazyClaims(testTerms, len(tables), nbVars, sum)
+		assert.Error(Verify(lazy, proof, fiatshamir.WithHash(sha256.New())))
+	}
+}
+
+func TestZeroCheck(t *testing.T) {
+	assert := require.New(t)
+	pool := utils.NewWorkerPool()
+	defer pool.Stop()
+
+	// A·B - C, with C = A·B on the hypercube
+	terms := []Term{
+		{Coeff: elementOf(1), Factors: []int{0, 1}},
+		{Coeff: elementOf(-1), Factors: []int{2}},
+	}
+
+	for _, nbVars := range []int{1, 3, 10} {
+		tables := randomTables(3, nbVars)
+		for i := range tables[2] {
+			tables[2][i].Mul(&tables[0][i], &tables[1][i])
+		}
+		tau := make([]fr.Element, nbVars)
+		for i := range tau {
+			tau[i].SetRandom()
+		}
+
+		claims, err := NewZeroCheckClaims(VirtualPolynomial{Tables: cloneTables(tables), Terms: terms}, tau, pool)
+		assert.NoError(err)
+		proof, err := Prove(claims, fiatshamir.WithHash(sha256.New()))
+		assert.NoError(err)
+		assert.Equal(degree(terms)+1, len(proof.PartialSumPolys[0]))
+
+		lazy := NewZeroCheckLazyClaims(terms, len(tables), tau)
+		lazy.Tables = tables
+		assert.NoError(Verify(lazy, proof, fiatshamir.WithHash(sha256.New())))
+
+		// P doesn't vanish on the hypercube
+		tables[2][len(tables[2])-1].SetOne()
+		claims, err = NewZeroCheckClaims(VirtualPolynomial{Tables: cloneTables(tables), Terms: terms}, tau, pool)
+		assert.NoError(err)
+		proof, err = Prove(claims, fiatshamir.WithHash(sha256.New()))
+		assert.NoError(err)
+		lazy = NewZeroCheckLazyClaims(terms, len(tables), tau)
+		lazy.Tables = tables
+		assert.Error(Verify(lazy, proof, fiatshamir.WithHash(sha256.New())))
+	}
+
+	_, err := NewZeroCheckClaims(VirtualPolynomial{Tables: randomTables(1, 3), Terms: terms[1:]}, make([]fr.Element, 2), nil)
+	assert.Error(err)
+	_, err = NewProductSumClaims(VirtualPolynomial{Tables: randomTables(1, 3), Terms: terms}, nil)
+	assert.Error(err)
+}
+
+func TestEvalOnRange(t *testing.T) {
+	// p(X) = X³ - 2X + 5
+	p := polynomial.Polynomial{elementOf(5), elementOf(-2), elementOf(0), elementOf(1)}
+	values := make([]fr.Element, 4)
+	for i := range values {
+		x := elementOf(int64(i))
+		values[i] = p.Eval(&x)
+	}
+	var x fr.Element
+	x.SetRandom()
+	expected := p.Eval(&x)
+	actual := evalOnRange(values, x)
+	require.True(t, expected.Equal(&actual))
+}
+
+func BenchmarkProductSum(b *testing.B) {
+	const nbVars = 16
+	tables := randomTables(3, nbVars)
+	pool := utils.NewWorkerPool()
+	defer pool.Stop()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		claims, _ := NewProductSumClaims(VirtualPolynomial{Tables: cloneTables(tables), Terms: testTerms}, pool)
+		b.StartTimer()
+		Prove(claims, fiatshamir.WithHash(sha256.New()))
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"crypto/rand"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+func TestEncoding(t *testing.T) {
+
+	for i := 0; i < 8; i++ {
+		privKey, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		private := []struct {
+			name      string
+			marshal   func() []byte
+			unmarshal func(*PrivateKey, []byte) error
+		}{
+			{"PKCS8", privKey.MarshalPKCS8, (*PrivateKey).UnmarshalPKCS8},
+			{"PEM", privKey.MarshalPEM, (*PrivateKey).UnmarshalPEM},
+			{"JWK", privKey.MarshalJWK, (*PrivateKey).UnmarshalJWK},
+		}
+		for _, e := range private {
+			var end PrivateKey
+			if err := e.unmarshal(&end, e.marshal()); err != nil {
+				t.Fatal(e.name, err)
+			}
+			if end != *privKey {
+				t.Fatal(e.name, "private key should stay the same")
+			}
+		}
+
+		publicKey := &privKey.PublicKey
+		public := []struct {
+			name      string
+			marshal   func() []byte
+			unmarshal func(*PublicKey, []byte) error
+		}{
+			{"PKIX", publicKey.MarshalPKIX, (*PublicKey).UnmarshalPKIX},
+			{"PEM", publicKey.MarshalPEM, (*PublicKey).UnmarshalPEM},
+			{"JWK", publicKey.MarshalJWK, (*PublicKey).UnmarshalJWK},
+		}
+		for _, e := range public {
+			var end PublicKey
+			if err := e.unmarshal(&end, e.marshal()); err != nil {
+				t.Fatal(e.name, err)
+			}
+			if !end.Equal(publicKey) {
+				t.Fatal(e.name, "public key should stay the same")
+			}
+		}
+	}
+}
+
+func TestEncodingFailures(t *testing.T) {
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var privEnd PrivateKey
+	var pubEnd PublicKey
+
+	// pkcs8 builds a PrivateKeyInfo from its fields
+	pkcs8 := func(version int64, oid, secrets []byte) []byte {
+		var b cryptobyte.Builder
+		b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1Int64(version)
+			b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+				b.AddASN1(asn1.OBJECT_IDENTIFIER, func(b *cryptobyte.Builder) {
+					b.AddBytes(oid)
+				})
+			})
+			b.AddASN1(asn1.OCTET_STRING, func(b *cryptobyte.Builder) {
+				b.AddASN1OctetString(secrets)
+			})
+		})
+		return b.BytesOrPanic()
+	}
+	secrets := privKey.secrets()
+	wrongOID := append([]byte{}, oidEdDSA...)
+	wrongOID[len(wrongOID)-1] ^= 1
+	orderSecrets := make([]byte, sizePrivateSecrets)
+	curve := twistededwards.GetEdwardsCurve()
+	curve.Order.FillBytes(orderSecrets[:sizeFr])
+
+	for _, c := range []struct {
+		name string
+		der  []byte
+	}{
+		{"trailing data", append(privKey.MarshalPKCS8(), 0)},
+		{"truncated", privKey.MarshalPKCS8()[:20]},
+		{"version", pkcs8(1, oidEdDSA, secrets)},
+		{"wrong algorithm", pkcs8(versionPKCS8, wrongOID, secrets)},
+		{"short secrets", pkcs8(versionPKCS8, oidEdDSA, secrets[1:])},
+		{"zero scalar", pkcs8(versionPKCS8, oidEdDSA, make([]byte, sizePrivateSecrets))},
+		{"scalar equal to the order", pkcs8(versionPKCS8, oidEdDSA, orderSecrets)},
+	} {
+		if err = privEnd.UnmarshalPKCS8(c.der); err == nil {
+			t.Fatal("PKCS8", c.name, "should be rejected")
+		}
+	}
+
+	pkix := privKey.PublicKey.MarshalPKIX()
+	var zero PublicKey
+	zero.A.Y.SetOne()
+	// (0, -1) is on the curve, of order 2
+	var lowOrder PublicKey
+	lowOrder.A.Y.SetOne().Neg(&lowOrder.A.Y)
+	for _, c := range []struct {
+		name string
+		der  []byte
+	}{
+		{"trailing data", append(pkix, 0)},
+		{"truncated", pkix[:len(pkix)-1]},
+		{"neutral element", zero.MarshalPKIX()},
+		{"small order point", lowOrder.MarshalPKIX()},
+	} {
+		if err = pubEnd.UnmarshalPKIX(c.der); err == nil {
+			t.Fatal("PKIX", c.name, "should be rejected")
+		}
+	}
+
+	privPEM := privKey.MarshalPEM()
+	for _, c := range []struct {
+		name string
+		pem  []byte
+	}{
+		{"public key", privKey.PublicKey.MarshalPEM()},
+		{"two blocks", append(privKey.MarshalPEM(), privPEM...)},
+		{"headers", pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Headers: map[string]string{"Proc-Type": "4,ENCRYPTED"}, Bytes: privKey.MarshalPKCS8()})},
+		{"not PEM", privKey.MarshalPKCS8()},
+	} {
+		if err = privEnd.UnmarshalPEM(c.pem); err == nil {
+			t.Fatal("PEM", c.name, "should be rejected")
+		}
+	}
+	if err = pubEnd.UnmarshalPEM(privPEM); err == nil {
+		t.Fatal("PEM private key should be rejected as public key")
+	}
+
+	privJWK := string(privKey.MarshalJWK())
+	pubJWK := string(privKey.PublicKey.MarshalJWK())
+	for _, c := range []struct {
+		name string
+		jwk  string
+	}{
+		{"kty", strings.Replace(privJWK, `"OKP"`, `"EC"`, 1)},
+		{"crv", strings.Replace(privJWK, `"`+jwkCurve+`"`, `"Ed25519"`, 1)},
+		{"padding", strings.Replace(privJWK, `","d"`, `=","d"`, 1)},
+		{"missing d", pubJWK},
+		{"public key mismatch", strings.Replace(privJWK, pubJWK[:len(pubJWK)-1], string(other.PublicKey.MarshalJWK()[:len(pubJWK)-1]), 1)},
+		{"not JSON", privJWK[1:]},
+		{"uppercase member", strings.Replace(privJWK, `"kty"`, `"KTY"`, 1)},
+		{"mixed case member", strings.Replace(privJWK, `"d"`, `"D"`, 1)},
+		{"unknown member", `{"kid":"1",` + privJWK[1:]},
+		{"trailing data", privJWK + "{}"},
+		{"duplicate member", `{"d":"AA",` + privJWK[1:]},
+		{"null d", `{"d":null,` + pubJWK[1:]},
+	} {
+		if err = privEnd.UnmarshalJWK([]byte(c.jwk)); err == nil {
+			t.Fatal("JWK", c.name, "should be rejected")
+		}
+	}
+	if err = pubEnd.UnmarshalJWK([]byte(privJWK)); err == nil {
+		t.Fatal("JWK private key should be rejected as public key")
+	}
+	if err = pubEnd.UnmarshalJWK([]byte(`{"d":"",` + pubJWK[1:])); err == nil {
+		t.Fatal("JWK public key with an empty d should be rejected")
+	}
+	if err = pubEnd.UnmarshalJWK([]byte(`{"kty":"OKP",` + pubJWK[1:])); err == nil {
+		t.Fatal("JWK public key with a duplicate member should be rejected")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	crand "crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards"
+	"github.com/consensys/gnark-crypto/internal/multisig"
+)
+
+// dkgContext is the context string of the key generations of the tests
+var dkgContext = []byte("FROST test session")
+
+// frostDKG runs the distributed key generation between n participants,
+// serializing all the messages
+func frostDKG(t testing.TB, threshold, n uint32) []*FROSTKeyShare {
+	dkgs := make([]*FROSTDKG, n)
+	round1 := make([]FROSTDKGRound1, n)
+	for i := range dkgs {
+		var p *FROSTDKGRound1
+		var err error
+		if dkgs[i], p, err = NewFROSTDKG(uint32(i+1), threshold, n, dkgContext, crand.Reader); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = round1[i].SetBytes(p.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	received := make([][]FROSTDKGRound2, n)
+	for i := range dkgs {
+		shares, err := dkgs[i].Round2(round1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, share := range shares {
+			var p FROSTDKGRound2
+			if _, err = p.SetBytes(share.Bytes()); err != nil {
+				t.Fatal(err)
+			}
+			received[p.To-1] = append(received[p.To-1], p)
+		}
+	}
+
+	keyShares := make([]*FROSTKeyShare, n)
+	for i := range dkgs {
+		var err error
+		if keyShares[i], err = dkgs[i].Finalize(received[i]); err != nil {
+			t.Fatal(err)
+		}
+		if !keyShares[i].GroupKey.A.Equal(&keyShares[0].GroupKey.A) {
+			t.Fatal("participants disagree on the group key")
+		}
+	}
+	return keyShares
+}
+
+// frostSign signs msg with the given key shares
+func frostSign(t testing.TB, keyShares []*FROSTKeyShare, msg []byte) ([]byte, error) {
+	hFunc := sha256.New()
+
+	secretNonces := make([]*FROSTSecretNonce, len(keyShares))
+	commitments := make([]FROSTNonceCommitment, len(keyShares))
+	for i, ks := range keyShares {
+		var c *FROSTNonceCommitment
+		var err error
+		if secretNonces[i], c, err = ks.NewNonce(crand.Reader); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = commitments[i].SetBytes(c.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	session, err := NewFROSTSession(&keyShares[0].GroupKey, commitments, msg, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonceCopy := *secretNonces[0]
+	shares := make([]FROSTSignatureShare, len(keyShares))
+	for i, ks := range keyShares {
+		share, err := session.Sign(ks, secretNonces[i])
+		if err != nil {
+			return nil, err
+		}
+		if _, err = shares[i].SetBytes(share.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		if !session.VerifyShare(&shares[i], &ks.VerificationShares[ks.ID-1]) {
+			t.Fatal("valid signature share rejected")
+		}
+	}
+	if len(shares) > 1 && session.VerifyShare(&shares[0], &keyShares[1].VerificationShares[keyShares[1].ID-1]) {
+		t.Fatal("invalid signature share accepted")
+	}
+
+	if _, err = session.Sign(keyShares[0], secretNonces[0]); err != multisig.ErrNonceUsed {
+		t.Fatal("expected the nonce reuse to be detected")
+	}
+	if _, err = session.Sign(keyShares[0], &nonceCopy); err != multisig.ErrNonceUsed {
+		t.Fatal("expected the reuse of a copy of the nonce to be detected")
+	}
+	if _, err = session.Sign(keyShares[0], &FROSTSecretNonce{}); err != multisig.ErrNonceUsed {
+		t.Fatal("expected the zero nonce to be rejected")
+	}
+	return session.Aggregate(shares)
+}
+
+func TestFROST(t *testing.T) {
+	const threshold, n = 3, 5
+	keyShares := frostDKG(t, threshold, n)
+	hFunc := sha256.New()
+	msg := []byte("testing FROST")
+
+	for _, signers := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 2, 3, 4}} {
+		subset := make([]*FROSTKeyShare, len(signers))
+		for i, j := range signers {
+			subset[i] = keyShares[j]
+		}
+		sig, err := frostSign(t, subset, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid, err := keyShares[0].GroupKey.Verify(sig, msg, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !valid {
+			t.Fatal("aggregated signature rejected", signers)
+		}
+		if valid, _ = keyShares[0].GroupKey.Verify(sig, []byte("wrong message"), hFunc); valid {
+			t.Fatal("aggregated signature of another message accepted")
+		}
+	}
+
+	if _, err := frostSign(t, keyShares[:threshold-1], msg); err != multisig.ErrNotEnoughSigners {
+		t.Fatal("expected an error below the threshold")
+	}
+}
+
+func TestFROSTDKGErrors(t *testing.T) {
+	if _, _, err := NewFROSTDKG(1, 3, 2, dkgContext, crand.Reader); err != multisig.ErrInvalidThreshold {
+		t.Fatal("expected an invalid threshold error")
+	}
+	if _, _, err := NewFROSTDKG(3, 2, 2, dkgContext, crand.Reader); err != multisig.ErrInvalidID {
+		t.Fatal("expected an invalid identifier error")
+	}
+
+	const threshold, n = 2, 3
+	dkgs := make([]*FROSTDKG, n)
+	round1 := make([]FROSTDKGRound1, n)
+	for i := range dkgs {
+		var p *FROSTDKGRound1
+		var err error
+		if dkgs[i], p, err = NewFROSTDKG(uint32(i+1), threshold, n, dkgContext, crand.Reader); err != nil {
+			t.Fatal(err)
+		}
+		round1[i] = *p
+	}
+	if _, err := dkgs[0].Finalize(nil); err != multisig.ErrDKGState {
+		t.Fatal("expected Finalize before Round2 to fail")
+	}
+
+	// wrong proof of knowledge
+	tampered := append([]FROSTDKGRound1{}, round1...)
+	tampered[1].Mu = tampered[2].Mu
+	if _, err := dkgs[0].Round2(tampered); err != multisig.ErrInvalidProof {
+		t.Fatal("expected an invalid proof error")
+	}
+
+	// proof of knowledge of another session
+	_, replayed, err := NewFROSTDKG(2, threshold, n, []byte("another session"), crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered = append([]FROSTDKGRound1{}, round1...)
+	tampered[1] = *replayed
+	if _, err = dkgs[0].Round2(tampered); err != multisig.ErrInvalidProof {
+		t.Fatal("expected a proof of another session to be rejected")
+	}
+
+	shares, err := dkgs[0].Round2(round1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = dkgs[1].Round2(round1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = dkgs[1].Round2(round1); err != multisig.ErrDKGState {
+		t.Fatal("expected Round2 to be called once")
+	}
+
+	// wrong share from participant 1 to participant 2
+	received := []FROSTDKGRound2{shares[0]}
+	received[0].Share[sizeFr-1] ^= 1
+	last := FROSTDKGRound2{From: 3, To: 2}
+	curveParams := twistededwards.GetEdwardsCurve()
+	multisig.Evaluate(dkgs[2].coefficients, 2, &curveParams.Order).FillBytes(last.Share[:])
+	received = append(received, last)
+	if _, err = dkgs[1].Finalize(received); err != multisig.ErrInvalidShare {
+		t.Fatal("expected an invalid share error")
+	}
+}
+
+func BenchmarkFROSTSign(b *testing.B) {
+	keyShares := frostDKG(b, 2, 3)[:2]
+	msg := []byte("benchmarking FROST")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		frostSign(b, keyShares, msg)
+	}
+}
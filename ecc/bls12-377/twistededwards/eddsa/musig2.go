@@ -0,0 +1,363 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"crypto/subtle"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards"
+	"github.com/consensys/gnark-crypto/internal/multisig"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Domain separation tags of the hashes to scalars
+const (
+	tagKeyAggList  = "MuSig2/keyagg list"
+	tagKeyAggCoeff = "MuSig2/keyagg coefficient"
+	tagMuSig2Nonce = "MuSig2/nonce"
+	tagNonceCoeff  = "MuSig2/noncecoef"
+)
+
+// MuSig2AggregateKey is the result of the key aggregation of MuSig2
+// (https://eprint.iacr.org/2020/1261.pdf). The aggregate public key
+// X̃ = ∑ aᵢ⋅Xᵢ, where aᵢ = H(L, Xᵢ) and L is the list of the public keys, is an
+// eddsa public key: the aggregated signatures are verified with PublicKey.Verify.
+type MuSig2AggregateKey struct {
+	PublicKey    PublicKey
+	keys         []PublicKey
+	coefficients []big.Int
+}
+
+// MuSig2SecretNonce is the secret part of the nonce of a MuSig2 signer. It is
+// bound to the key of the signer and zeroed by MuSig2Session.Sign, so that it
+// cannot be used for two signatures. Copies of a MuSig2SecretNonce share the
+// same secret, so that signing with one of them invalidates all of them.
+type MuSig2SecretNonce struct {
+	secret *musig2SecretNonce
+}
+
+// musig2SecretNonce holds the secret scalars of a MuSig2SecretNonce
+type musig2SecretNonce struct {
+	k1, k2 [sizeFr]byte
+	pub    [sizePublicKey]byte
+}
+
+// MuSig2PublicNonce is the first round message of a MuSig2 signer,
+// R₁ = k₁⋅Base and R₂ = k₂⋅Base. MuSig2AggregateNonces sums them.
+type MuSig2PublicNonce struct {
+	R1, R2 twistededwards.PointAffine
+}
+
+// MuSig2PartialSignature is the second round message of a MuSig2 signer,
+// sᵢ = k₁ + b⋅k₂ + c⋅aᵢ⋅xᵢ mod l
+type MuSig2PartialSignature struct {
+	S [sizeFr]byte
+}
+
+// MuSig2Session holds the values shared by the signers of a message once the
+// public nonces are aggregated.
+type MuSig2Session struct {
+	key     *MuSig2AggregateKey
+	nonce   MuSig2PublicNonce
+	message []byte
+	b       big.Int // nonce coefficient H(R₁, R₂, X̃, m)
+	c       big.Int // challenge H(R, X̃, m)
+	R       twistededwards.PointAffine
+}
+
+// MuSig2AggregateKeys returns the aggregate key of pubs, which must be given in
+// the same order to all signers.
+func MuSig2AggregateKeys(pubs []PublicKey) (*MuSig2AggregateKey, error) {
+	if len(pubs) == 0 {
+		return nil, multisig.ErrNoSigner
+	}
+
+	list := make([]byte, 0, len(pubs)*sizePublicKey)
+	for i := range pubs {
+		if !pubs[i].A.IsOnCurve() {
+			return nil, errNotOnCurve
+		}
+		list = append(list, pubs[i].Bytes()...)
+	}
+	l := hashToScalar(tagKeyAggList, list).Bytes()
+
+	res := &MuSig2AggregateKey{
+		keys:         make([]PublicKey, len(pubs)),
+		coefficients: make([]big.Int, len(pubs)),
+	}
+	copy(res.keys, pubs)
+
+	points := make([]twistededwards.PointAffine, len(pubs))
+	for i := range pubs {
+		points[i] = pubs[i].A
+		res.coefficients[i].Set(hashToScalar(tagKeyAggCoeff, l, pubs[i].Bytes()))
+	}
+	if _, err := res.PublicKey.A.MultiExp(points, res.coefficients, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// coefficient returns the sum of the coefficients of pub in the aggregate key
+func (key *MuSig2AggregateKey) coefficient(pub *PublicKey) (*big.Int, error) {
+	var res big.Int
+	found := false
+	for i := range key.keys {
+		if key.keys[i].A.Equal(&pub.A) {
+			res.Add(&res, &key.coefficients[i])
+			found = true
+		}
+	}
+	if !found {
+		return nil, multisig.ErrUnknownSigner
+	}
+	return &res, nil
+}
+
+// MuSig2Nonce generates the nonce of privKey for a MuSig2 signature. The secret
+// nonce derives from privKey and 32 bytes read from rand, and must be used
+// for a single signature.
+func (privKey *PrivateKey) MuSig2Nonce(rand io.Reader) (*MuSig2SecretNonce, *MuSig2PublicNonce, error) {
+	var seed [32]byte
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+
+	curveParams := twistededwards.GetEdwardsCurve()
+	secret := new(musig2SecretNonce)
+	public := new(MuSig2PublicNonce)
+	copy(secret.pub[:], privKey.PublicKey.Bytes())
+
+	k1 := hashToScalar(tagMuSig2Nonce, seed[:], privKey.randSrc[:], secret.pub[:], []byte{1})
+	k2 := hashToScalar(tagMuSig2Nonce, seed[:], privKey.randSrc[:], secret.pub[:], []byte{2})
+	k1.FillBytes(secret.k1[:])
+	k2.FillBytes(secret.k2[:])
+	public.R1.ScalarMultiplication(&curveParams.Base, k1)
+	public.R2.ScalarMultiplication(&curveParams.Base, k2)
+
+	return &MuSig2SecretNonce{secret}, public, nil
+}
+
+// MuSig2AggregateNonces returns the sum of the public nonces of the signers
+func MuSig2AggregateNonces(nonces []MuSig2PublicNonce) (*MuSig2PublicNonce, error) {
+	if len(nonces) == 0 {
+		return nil, multisig.ErrNoSigner
+	}
+	res := new(MuSig2PublicNonce)
+	res.R1.Set(&nonces[0].R1)
+	res.R2.Set(&nonces[0].R2)
+	for i := 1; i < len(nonces); i++ {
+		res.R1.Add(&res.R1, &nonces[i].R1)
+		res.R2.Add(&res.R2, &nonces[i].R2)
+	}
+	return res, nil
+}
+
+// NewMuSig2Session returns the signing session of message under key, once the
+// public nonces of all the signers are aggregated in nonce. hFunc is the hash
+// used by PublicKey.Verify.
+//
+// b = H(R₁, R₂, X̃, m)
+// R = R₁ + b⋅R₂
+// c = H(R, X̃, m)
+func NewMuSig2Session(key *MuSig2AggregateKey, nonce *MuSig2PublicNonce, message []byte, hFunc hash.Hash) (*MuSig2Session, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	if !nonce.R1.IsOnCurve() || !nonce.R2.IsOnCurve() {
+		return nil, errNotOnCurve
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	s := &MuSig2Session{
+		key:     key,
+		nonce:   *nonce,
+		message: append([]byte{}, message...),
+	}
+	s.b.Set(hashToScalar(tagNonceCoeff, nonce.Bytes(), key.PublicKey.Bytes(), message))
+
+	var R2 twistededwards.PointAffine
+	R2.ScalarMultiplication(&nonce.R2, &s.b)
+	s.R.Add(&nonce.R1, &R2)
+
+	c, err := hram(&s.R, &key.PublicKey.A, message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	s.c.Mod(c, &curveParams.Order)
+
+	return s, nil
+}
+
+// Sign returns the partial signature of privKey with the secret nonce, which
+// is zeroed, even if an error is returned.
+//
+// sᵢ = k₁ + b⋅k₂ + c⋅aᵢ⋅xᵢ mod l
+func (s *MuSig2Session) Sign(privKey *PrivateKey, nonce *MuSig2SecretNonce) (*MuSig2PartialSignature, error) {
+	if nonce.secret == nil {
+		return nil, multisig.ErrNonceUsed
+	}
+	var k1, k2 big.Int
+	k1.SetBytes(nonce.secret.k1[:])
+	k2.SetBytes(nonce.secret.k2[:])
+	pub := nonce.secret.pub
+	*nonce.secret = musig2SecretNonce{}
+	if k1.Sign() == 0 || k2.Sign() == 0 {
+		return nil, multisig.ErrNonceUsed
+	}
+	if subtle.ConstantTimeCompare(pub[:], privKey.PublicKey.Bytes()) != 1 {
+		return nil, multisig.ErrNonceMismatch
+	}
+	a, err := s.key.coefficient(&privKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	curveParams := twistededwards.GetEdwardsCurve()
+	var x big.Int
+	x.SetBytes(privKey.scalar[:])
+	res := multisig.Response(&k1, &k2, &s.b, &s.c, a, &x, false, &curveParams.Order)
+
+	partial := new(MuSig2PartialSignature)
+	res.FillBytes(partial.S[:])
+	return partial, nil
+}
+
+// VerifyPartial checks the partial signature of the signer with public key pub
+// and public nonce nonce
+//
+// sᵢ⋅Base ?= R₁ᵢ + b⋅R₂ᵢ + c⋅aᵢ⋅Xᵢ
+func (s *MuSig2Session) VerifyPartial(partial *MuSig2PartialSignature, nonce *MuSig2PublicNonce, pub *PublicKey) bool {
+	a, err := s.key.coefficient(pub)
+	if err != nil {
+		return false
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	var lhs, rhs, tmp twistededwards.PointAffine
+	var si, e big.Int
+	si.SetBytes(partial.S[:])
+	lhs.ScalarMultiplication(&curveParams.Base, &si)
+
+	e.Mul(&s.c, a)
+	rhs.ScalarMultiplication(&pub.A, &e)
+	tmp.ScalarMultiplication(&nonce.R2, &s.b)
+	rhs.Add(&rhs, &tmp).
+		Add(&rhs, &nonce.R1)
+
+	return lhs.Equal(&rhs)
+}
+
+// Aggregate returns the eddsa signature (R, ∑ sᵢ mod l) made of the partial
+// signatures of all the signers.
+func (s *MuSig2Session) Aggregate(partials []MuSig2PartialSignature) ([]byte, error) {
+	if len(partials) != len(s.key.keys) {
+		return nil, multisig.ErrNbPartials
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	ss := make([][]byte, len(partials))
+	for i := range partials {
+		ss[i] = partials[i].S[:]
+	}
+	sum := multisig.Sum(ss, &curveParams.Order)
+
+	var sig Signature
+	sig.R.Set(&s.R)
+	sum.FillBytes(sig.S[:])
+	return sig.Bytes(), nil
+}
+
+// Bytes returns the binary representation of the public nonce, as R₁||R₂
+// where R₁ and R₂ are compressed points
+func (nonce *MuSig2PublicNonce) Bytes() []byte {
+	var res [2 * sizeFr]byte
+	r1 := nonce.R1.Bytes()
+	r2 := nonce.R2.Bytes()
+	copy(res[:sizeFr], r1[:])
+	copy(res[sizeFr:], r2[:])
+	return res[:]
+}
+
+// SetBytes sets the public nonce from buf, interpreted as R₁||R₂.
+// It returns the number of bytes read from buf.
+func (nonce *MuSig2PublicNonce) SetBytes(buf []byte) (int, error) {
+	if len(buf) < 2*sizeFr {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := nonce.R1.SetBytes(buf[:sizeFr]); err != nil {
+		return 0, err
+	}
+	if _, err := nonce.R2.SetBytes(buf[sizeFr : 2*sizeFr]); err != nil {
+		return 0, err
+	}
+	if !nonce.R1.IsOnCurve() || !nonce.R2.IsOnCurve() {
+		return 0, errNotOnCurve
+	}
+	return 2 * sizeFr, nil
+}
+
+// Bytes returns the binary representation of the partial signature, as a big
+// endian integer of size sizeFr
+func (partial *MuSig2PartialSignature) Bytes() []byte {
+	var res [sizeFr]byte
+	copy(res[:], partial.S[:])
+	return res[:]
+}
+
+// SetBytes sets the partial signature from buf, which must hold a big endian
+// integer smaller than the order of the curve.
+// It returns the number of bytes read from buf.
+func (partial *MuSig2PartialSignature) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeFr {
+		return 0, io.ErrShortBuffer
+	}
+	if err := checkScalar(buf[:sizeFr]); err != nil {
+		return 0, err
+	}
+	copy(partial.S[:], buf[:sizeFr])
+	return sizeFr, nil
+}
+
+// hashToScalar returns blake2b-512(tag ∥ data[0] ∥ data[1] ∥ ...) mod l
+func hashToScalar(tag string, data ...[]byte) *big.Int {
+	h, _ := blake2b.New512(nil)
+	h.Write([]byte(tag))
+	for i := range data {
+		h.Write(data[i])
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+	var res big.Int
+	res.SetBytes(h.Sum(nil))
+	return res.Mod(&res, &curveParams.Order)
+}
+
+// checkScalar returns an error if the big endian integer in buf is not smaller
+// than the order of the curve
+func checkScalar(buf []byte) error {
+	curveParams := twistededwards.GetEdwardsCurve()
+	var s big.Int
+	if s.SetBytes(buf).Cmp(&curveParams.Order) != -1 {
+		return errSBiggerThanRMod
+	}
+	return nil
+}
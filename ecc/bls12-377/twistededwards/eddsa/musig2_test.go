@@ -0,0 +1,144 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	crand "crypto/rand"
+	"crypto/sha256"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/internal/multisig"
+)
+
+func TestMuSig2(t *testing.T) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := sha256.New()
+	msg := []byte("testing MuSig2")
+
+	const n = 4
+	privKeys := make([]*PrivateKey, n)
+	pubs := make([]PublicKey, n)
+	for i := range privKeys {
+		var err error
+		if privKeys[i], err = GenerateKey(r); err != nil {
+			t.Fatal(err)
+		}
+		pubs[i] = privKeys[i].PublicKey
+	}
+	key, err := MuSig2AggregateKeys(pubs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// first round: the public nonces go through their binary representation
+	secretNonces := make([]*MuSig2SecretNonce, n)
+	publicNonces := make([]MuSig2PublicNonce, n)
+	for i := range privKeys {
+		var public *MuSig2PublicNonce
+		if secretNonces[i], public, err = privKeys[i].MuSig2Nonce(crand.Reader); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = publicNonces[i].SetBytes(public.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nonce, err := MuSig2AggregateNonces(publicNonces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, err := NewMuSig2Session(key, nonce, msg, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// second round
+	nonceCopy := *secretNonces[0]
+	partials := make([]MuSig2PartialSignature, n)
+	for i := range privKeys {
+		partial, err := session.Sign(privKeys[i], secretNonces[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = partials[i].SetBytes(partial.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		if !session.VerifyPartial(&partials[i], &publicNonces[i], &pubs[i]) {
+			t.Fatal("valid partial signature rejected")
+		}
+	}
+	if session.VerifyPartial(&partials[0], &publicNonces[1], &pubs[1]) {
+		t.Fatal("invalid partial signature accepted")
+	}
+
+	sig, err := session.Aggregate(partials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid, err := key.PublicKey.Verify(sig, msg, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid {
+		t.Fatal("aggregated signature rejected")
+	}
+	if valid, _ = key.PublicKey.Verify(sig, []byte("wrong message"), hFunc); valid {
+		t.Fatal("aggregated signature of another message accepted")
+	}
+
+	// the secret nonces can't be reused
+	if _, err = session.Sign(privKeys[0], secretNonces[0]); err != multisig.ErrNonceUsed {
+		t.Fatal("expected the nonce reuse to be detected")
+	}
+	if _, err = session.Sign(privKeys[0], &nonceCopy); err != multisig.ErrNonceUsed {
+		t.Fatal("expected the reuse of a copy of the nonce to be detected")
+	}
+	if _, err = session.Sign(privKeys[0], &MuSig2SecretNonce{}); err != multisig.ErrNonceUsed {
+		t.Fatal("expected the zero nonce to be rejected")
+	}
+	secret, _, err := privKeys[0].MuSig2Nonce(crand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = session.Sign(privKeys[1], secret); err != multisig.ErrNonceMismatch {
+		t.Fatal("expected the nonce of another key to be rejected")
+	}
+	if _, err = session.Aggregate(partials[1:]); err == nil {
+		t.Fatal("expected an error on missing partial signatures")
+	}
+}
+
+func BenchmarkMuSig2Sign(b *testing.B) {
+
+	src := rand.NewSource(0)
+	r := rand.New(src) //#nosec G404 weak rng is fine here
+
+	hFunc := sha256.New()
+	msg := []byte("benchmarking MuSig2")
+	privKey, _ := GenerateKey(r)
+	key, _ := MuSig2AggregateKeys([]PublicKey{privKey.PublicKey})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		secret, public, _ := privKey.MuSig2Nonce(crand.Reader)
+		session, _ := NewMuSig2Session(key, public, msg, hFunc)
+		session.Sign(privKey, secret)
+	}
+}
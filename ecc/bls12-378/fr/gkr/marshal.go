@@ -0,0 +1,391 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package gkr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/sumcheck"
+)
+
+// Circuits are encoded wire by wire, in order. Each wire is given by the name
+// its gate is registered under (see RegisterGate) and the indexes of its inputs.
+// Input wires have no gate. Assignments and proofs are encoded as lists of
+// fr.Vector, following the order of the wires. In binary, proofs use the
+// encoding of sumcheck.Proof.
+
+// wireInfo is the serializable form of a Wire
+type wireInfo struct {
+	Gate   *string `json:"gate"`
+	Inputs []int   `json:"inputs"`
+}
+
+func (c Circuit) toInfo() ([]wireInfo, error) {
+	indexes := indexMap(c)
+	res := make([]wireInfo, len(c))
+	for i := range c {
+		res[i].Inputs = make([]int, len(c[i].Inputs))
+		for j, in := range c[i].Inputs {
+			index, ok := indexes[in]
+			if !ok {
+				return nil, fmt.Errorf("wire %d: input %d is not in the circuit", i, j)
+			}
+			res[i].Inputs[j] = index
+		}
+		if c[i].IsInput() {
+			continue
+		}
+		name, ok := GateName(c[i].Gate)
+		if !ok {
+			return nil, fmt.Errorf("wire %d: gate not registered", i)
+		}
+		res[i].Gate = &name
+	}
+	return res, nil
+}
+
+func (c *Circuit) fromInfo(info []wireInfo) error {
+	*c = make(Circuit, len(info))
+	for i := range info {
+		// only the wires with inputs have a gate, so that encoding is the inverse of decoding
+		if len(info[i].Inputs) == 0 {
+			if info[i].Gate != nil {
+				return fmt.Errorf("wire %d: gate without inputs", i)
+			}
+		} else {
+			if info[i].Gate == nil {
+				return fmt.Errorf("wire %d: missing gate", i)
+			}
+			if (*c)[i].Gate = GetGate(*info[i].Gate); (*c)[i].Gate == nil {
+				return fmt.Errorf("wire %d: unknown gate \"%s\"", i, *info[i].Gate)
+			}
+		}
+		(*c)[i].Inputs = make([]*Wire, len(info[i].Inputs))
+		for j, index := range info[i].Inputs {
+			if index < 0 || index >= len(info) {
+				return fmt.Errorf("wire %d: input index %d out of range", i, index)
+			}
+			(*c)[i].Inputs[j] = &(*c)[index]
+		}
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler
+func (c Circuit) MarshalJSON() ([]byte, error) {
+	info, err := c.toInfo()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(info)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *Circuit) UnmarshalJSON(data []byte) error {
+	var info []wireInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return err
+	}
+	return c.fromInfo(info)
+}
+
+// WriteTo implements io.WriterTo. For each wire, the length of its gate name,
+// the name, the number of inputs and their indexes are encoded as big endian uint32.
+func (c Circuit) WriteTo(w io.Writer) (int64, error) {
+	info, err := c.toInfo()
+	if err != nil {
+		return 0, err
+	}
+	var buf bytes.Buffer
+	writeUint32(&buf, len(info))
+	for i := range info {
+		var name string
+		if info[i].Gate != nil {
+			name = *info[i].Gate
+		}
+		writeUint32(&buf, len(name))
+		buf.WriteString(name)
+		writeUint32(&buf, len(info[i].Inputs))
+		for _, index := range info[i].Inputs {
+			writeUint32(&buf, index)
+		}
+	}
+	return buf.WriteTo(w)
+}
+
+// maxGateNameLen bounds the length of the gate names read by Circuit.ReadFrom
+const maxGateNameLen = 1 << 10
+
+// ReadFrom implements io.ReaderFrom. The wires and their inputs are appended as
+// they are read, so that forged lengths can't cause large allocations.
+func (c *Circuit) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	nbWires, err := readUint32(r, &n)
+	if err != nil {
+		return n, err
+	}
+	var info []wireInfo
+	for i := 0; i < nbWires; i++ {
+		var wire wireInfo
+		nameLen, err := readUint32(r, &n)
+		if err != nil {
+			return n, err
+		}
+		if nameLen > maxGateNameLen {
+			return n, fmt.Errorf("wire %d: gate name too long", i)
+		}
+		if nameLen != 0 {
+			name := make([]byte, nameLen)
+			read, err := io.ReadFull(r, name)
+			n += int64(read)
+			if err != nil {
+				return n, err
+			}
+			s := string(name)
+			wire.Gate = &s
+		}
+		nbInputs, err := readUint32(r, &n)
+		if err != nil {
+			return n, err
+		}
+		if nbInputs != 0 && wire.Gate == nil {
+			return n, fmt.Errorf("wire %d: missing gate", i)
+		}
+		for j := 0; j < nbInputs; j++ {
+			index, err := readUint32(r, &n)
+			if err != nil {
+				return n, err
+			}
+			wire.Inputs = append(wire.Inputs, index)
+		}
+		info = append(info, wire)
+	}
+	return n, c.fromInfo(info)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (c Circuit) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (c *Circuit) UnmarshalBinary(data []byte) error {
+	_, err := c.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteAssignment writes the assignment of each wire of the circuit, in order.
+// Wires that aren't assigned are encoded as empty vectors.
+func (c Circuit) WriteAssignment(w io.Writer, a WireAssignment) (int64, error) {
+	var n int64
+	for i := range c {
+		v := fr.Vector(a[&c[i]])
+		m, err := v.WriteTo(w)
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// ReadAssignment reads an assignment written by WriteAssignment
+func (c Circuit) ReadAssignment(r io.Reader) (WireAssignment, int64, error) {
+	var n int64
+	res := make(WireAssignment, len(c))
+	for i := range c {
+		var v fr.Vector
+		m, err := v.ReadFrom(r)
+		n += m
+		if err != nil {
+			return nil, n, err
+		}
+		if len(v) != 0 {
+			res[&c[i]] = polynomial.MultiLin(v)
+		}
+	}
+	return res, n, nil
+}
+
+// MarshalAssignmentJSON encodes the assignment as a list of values for each wire
+// of the circuit, in order. Wires that aren't assigned are encoded as null.
+func (c Circuit) MarshalAssignmentJSON(a WireAssignment) ([]byte, error) {
+	values := make([][]fr.Element, len(c))
+	for i := range c {
+		values[i] = a[&c[i]]
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalAssignmentJSON decodes an assignment encoded by MarshalAssignmentJSON
+func (c Circuit) UnmarshalAssignmentJSON(data []byte) (WireAssignment, error) {
+	var values [][]fr.Element
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	if len(values) != len(c) {
+		return nil, fmt.Errorf("expected %d wire assignments, got %d", len(c), len(values))
+	}
+	res := make(WireAssignment, len(c))
+	for i := range values {
+		if values[i] != nil {
+			res[&c[i]] = values[i]
+		}
+	}
+	return res, nil
+}
+
+// sumcheckProofInfo is the serializable form of a sumcheck.Proof in a GKR proof
+type sumcheckProofInfo struct {
+	PartialSumPolys [][]fr.Element `json:"partialSumPolys"`
+	FinalEvalProof  []fr.Element   `json:"finalEvalProof"`
+}
+
+func (p Proof) toInfo() ([]sumcheckProofInfo, error) {
+	res := make([]sumcheckProofInfo, len(p))
+	for i := range p {
+		res[i].PartialSumPolys = make([][]fr.Element, len(p[i].PartialSumPolys))
+		for j := range p[i].PartialSumPolys {
+			res[i].PartialSumPolys[j] = p[i].PartialSumPolys[j]
+		}
+		if p[i].FinalEvalProof != nil {
+			finalEvalProof, ok := p[i].FinalEvalProof.([]fr.Element)
+			if !ok {
+				return nil, fmt.Errorf("wire %d: unexpected final evaluation proof type %T", i, p[i].FinalEvalProof)
+			}
+			res[i].FinalEvalProof = finalEvalProof
+		}
+	}
+	return res, nil
+}
+
+func (p *Proof) fromInfo(info []sumcheckProofInfo) {
+	*p = make(Proof, len(info))
+	for i := range info {
+		(*p)[i].PartialSumPolys = make([]polynomial.Polynomial, len(info[i].PartialSumPolys))
+		for j := range info[i].PartialSumPolys {
+			(*p)[i].PartialSumPolys[j] = info[i].PartialSumPolys[j]
+		}
+		// the verifier expects a final evaluation proof for every wire
+		finalEvalProof := info[i].FinalEvalProof
+		if finalEvalProof == nil {
+			finalEvalProof = []fr.Element{}
+		}
+		(*p)[i].FinalEvalProof = finalEvalProof
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (p Proof) MarshalJSON() ([]byte, error) {
+	info, err := p.toInfo()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(info)
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *Proof) UnmarshalJSON(data []byte) error {
+	var info []sumcheckProofInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return err
+	}
+	p.fromInfo(info)
+	return nil
+}
+
+// WriteTo implements io.WriterTo. The number of wires is encoded as a big
+// endian uint32, followed by the sumcheck proof of each wire.
+func (p Proof) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	writeUint32(&buf, len(p))
+	for i := range p {
+		if _, err := p[i].WriteTo(&buf); err != nil {
+			return 0, fmt.Errorf("wire %d: %w", i, err)
+		}
+	}
+	return buf.WriteTo(w)
+}
+
+// ReadFrom implements io.ReaderFrom. The sumcheck proofs are appended as they
+// are read, so that a forged number of wires can't cause a large allocation.
+func (p *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var n int64
+	nbWires, err := readUint32(r, &n)
+	if err != nil {
+		return n, err
+	}
+	*p = Proof{}
+	for i := 0; i < nbWires; i++ {
+		var wire sumcheck.Proof
+		m, err := wire.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		// the verifier expects a final evaluation proof for every wire
+		if wire.FinalEvalProof == nil {
+			wire.FinalEvalProof = []fr.Element{}
+		}
+		if _, ok := wire.FinalEvalProof.([]fr.Element); !ok {
+			return n, fmt.Errorf("wire %d: unexpected final evaluation proof type %T", i, wire.FinalEvalProof)
+		}
+		*p = append(*p, wire)
+	}
+	return n, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (p Proof) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	_, err := p.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+func writeUint32(buf *bytes.Buffer, v int) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(v))
+	buf.Write(b[:])
+}
+
+func readUint32(r io.Reader, n *int64) (int, error) {
+	var b [4]byte
+	read, err := io.ReadFull(r, b[:])
+	*n += int64(read)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(b[:])), nil
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package griffin
+
+import (
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// Width of the state
+	Width = 3
+	// Rate of the sponge
+	Rate          = Width - 1
+	securityLevel = 128
+
+	BlockSize = fr.Bytes // BlockSize size that Griffin consumes
+)
+
+var (
+	defaultParams *Params
+	once          sync.Once
+)
+
+// Params of the Griffin permutation
+type Params struct {
+	D           int
+	d, dInv     big.Int
+	NbRounds    int
+	Alpha, Beta fr.Element // α₂, β₂ of the Horst layer, with α₂²-4β₂ a non-square
+	// RoundConstants holds Width constants per round but the last one
+	RoundConstants []fr.Element
+}
+
+// GetParams returns the Griffin parameters for fr
+func GetParams() *Params {
+	once.Do(func() {
+		defaultParams = newParams()
+	})
+	return defaultParams
+}
+
+func newParams() *Params {
+	p := new(Params)
+
+	// smallest d ≥ 3 coprime with r-1, and its inverse mod r-1
+	var rMinusOne, gcd big.Int
+	rMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	p.D = 3
+	for gcd.GCD(nil, nil, &rMinusOne, big.NewInt(int64(p.D))).Cmp(big.NewInt(1)) != 0 {
+		p.D++
+	}
+	p.d.SetInt64(int64(p.D))
+	p.dInv.ModInverse(&p.d, &rMinusOne)
+
+	p.NbRounds = nbRounds(p.D)
+
+	// SHAKE128("Griffin" ‖ r as little endian 64-bit words)
+	shake := sha3.NewShake128()
+	_, _ = shake.Write([]byte("Griffin"))
+	var modulus [fr.Limbs * 8]byte
+	fr.Modulus().FillBytes(modulus[:])
+	for i := 0; i < len(modulus)/2; i++ {
+		modulus[i], modulus[len(modulus)-1-i] = modulus[len(modulus)-1-i], modulus[i]
+	}
+	_, _ = shake.Write(modulus[:])
+
+	p.RoundConstants = make([]fr.Element, Width*(p.NbRounds-1))
+	for i := range p.RoundConstants {
+		p.RoundConstants[i] = sampleElement(shake)
+	}
+	// non-zero and distinct α₂, β₂
+	for {
+		p.Alpha = sampleNonZeroElement(shake)
+		p.Beta = sampleNonZeroElement(shake)
+		for p.Beta.Equal(&p.Alpha) {
+			p.Beta = sampleNonZeroElement(shake)
+		}
+		var delta, t fr.Element
+		delta.Square(&p.Alpha)
+		t.Double(&p.Beta).Double(&t)
+		if delta.Sub(&delta, &t).Legendre() == -1 {
+			break
+		}
+	}
+	return p
+}
+
+// nbRounds returns ⌈1.2·max(6, 1+R)⌉, R being the smallest number of rounds
+// resisting Gröbner basis attacks: C(R(d+Width)+1, 1+Width·R)² ≥ 2^securityLevel
+func nbRounds(d int) int {
+	var target big.Int
+	target.Lsh(big.NewInt(1), securityLevel)
+	r := 1
+	for ; ; r++ {
+		var b big.Int
+		b.Binomial(int64(r*(d+Width)+1), int64(1+Width*r))
+		b.Mul(&b, &b)
+		if b.Cmp(&target) >= 0 {
+			break
+		}
+	}
+	if r+1 < 6 {
+		r = 5
+	}
+	return (6*(r+1) + 4) / 5
+}
+
+// sampleElement reads little endian integers of fr.Bits bits until one is
+// smaller than the modulus.
+func sampleElement(r io.Reader) fr.Element {
+	var buf [(fr.Bits + 7) / 8]byte
+	var x big.Int
+	for {
+		_, _ = r.Read(buf[:])
+		buf[len(buf)-1] &= byte((1 << (fr.Bits - 8*(len(buf)-1))) - 1) // keep fr.Bits bits
+		for i := 0; i < len(buf)/2; i++ {
+			buf[i], buf[len(buf)-1-i] = buf[len(buf)-1-i], buf[i]
+		}
+		if x.SetBytes(buf[:]).Cmp(fr.Modulus()) < 0 {
+			var res fr.Element
+			res.SetBigInt(&x)
+			return res
+		}
+	}
+}
+
+// sampleNonZeroElement samples elements until one is not zero.
+func sampleNonZeroElement(r io.Reader) fr.Element {
+	for {
+		if res := sampleElement(r); !res.IsZero() {
+			return res
+		}
+	}
+}
+
+// Permutation applies the Griffin permutation to the state
+func (p *Params) Permutation(state *[Width]fr.Element) {
+	linear(state)
+	for r := 0; r < p.NbRounds; r++ {
+		p.nonLinear(state)
+		linear(state)
+		if r+1 < p.NbRounds {
+			for i := range state {
+				state[i].Add(&state[i], &p.RoundConstants[Width*r+i])
+			}
+		}
+	}
+}
+
+// nonLinear applies the Horst layer: y₀ = x₀^(1/d), y₁ = x₁^d and
+// y₂ = x₂·(L² + α₂L + β₂) where L = y₀ + y₁
+func (p *Params) nonLinear(state *[Width]fr.Element) {
+	state[0].Exp(state[0], &p.dInv)
+	x1 := state[1]
+	switch p.D {
+	case 3:
+		state[1].Square(&x1).Mul(&state[1], &x1)
+	case 5:
+		state[1].Square(&x1).Square(&state[1]).Mul(&state[1], &x1)
+	case 7:
+		var t fr.Element
+		t.Square(&x1)
+		state[1].Square(&t).Mul(&state[1], &t).Mul(&state[1], &x1)
+	default:
+		state[1].Exp(x1, &p.d)
+	}
+
+	var l, t fr.Element
+	l.Add(&state[0], &state[1])
+	t.Add(&l, &p.Alpha).Mul(&t, &l).Add(&t, &p.Beta)
+	state[2].Mul(&state[2], &t)
+}
+
+// linear multiplies the state by circ(2, 1, 1)
+func linear(state *[Width]fr.Element) {
+	var sum fr.Element
+	sum.Add(&state[0], &state[1]).Add(&sum, &state[2])
+	for i := range state {
+		state[i].Add(&state[i], &sum)
+	}
+}
+
+// Hash absorbs the inputs in the sponge, padded with a one and zeros up to a
+// multiple of Rate, and returns the Rate elements squeezed.
+func (p *Params) Hash(inputs []fr.Element) [Rate]fr.Element {
+	padded := make([]fr.Element, len(inputs)+1, len(inputs)+Rate)
+	copy(padded, inputs)
+	padded[len(inputs)].SetOne()
+	for len(padded)%Rate != 0 {
+		padded = append(padded, fr.Element{})
+	}
+
+	var state [Width]fr.Element
+	for ; len(padded) != 0; padded = padded[Rate:] {
+		for i := 0; i < Rate; i++ {
+			state[i].Add(&state[i], &padded[i])
+		}
+		p.Permutation(&state)
+	}
+
+	var res [Rate]fr.Element
+	copy(res[:], state[:Rate])
+	return res
+}
+
+// digest accumulates the field elements to hash
+type digest struct {
+	data   []fr.Element
+	params *Params
+}
+
+// NewGriffin returns a Griffin hash.Hash. Its digest is the first element
+// squeezed from the sponge.
+func NewGriffin() hash.Hash {
+	return &digest{params: GetParams()}
+}
+
+// Reset resets the Hash to its initial state.
+func (d *digest) Reset() {
+	d.data = d.data[:0]
+}
+
+// Sum appends the hash of the data written so far to b.
+// It does not change the underlying hash state.
+func (d *digest) Sum(b []byte) []byte {
+	h := d.params.Hash(d.data)
+	bytes := h[0].Bytes()
+	return append(b, bytes[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest) Size() int {
+	return BlockSize
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+//
+// Each []byte block of size BlockSize represents a big endian fr.Element. If len(p)
+// is not a multiple of BlockSize or any of the blocks represents an integer larger
+// than fr.Modulus, this function returns an error.
+func (d *digest) Write(p []byte) (int, error) {
+	if len(p)%BlockSize != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	elems := make([]fr.Element, len(p)/BlockSize)
+	for i := range elems {
+		var err error
+		if elems[i], err = fr.BigEndian.Element((*[BlockSize]byte)(p[i*BlockSize : (i+1)*BlockSize])); err != nil {
+			return 0, err
+		}
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+// WriteElements adds field elements to the running hash.
+func (d *digest) WriteElements(elems ...fr.Element) {
+	d.data = append(d.data, elems...)
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package griffin
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomElements(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestParams(t *testing.T) {
+	params := GetParams()
+	require.Equal(t, Width*(params.NbRounds-1), len(params.RoundConstants))
+
+	// α₂² - 4β₂ is a non-square
+	var delta, t4 fr.Element
+	delta.Square(&params.Alpha)
+	t4.Double(&params.Beta).Double(&t4)
+	require.Equal(t, -1, delta.Sub(&delta, &t4).Legendre())
+
+	// x ↦ x^d and x ↦ x^(1/d) are inverses
+	x := randomElements(1)[0]
+	var y fr.Element
+	y.Exp(x, &params.d).Exp(y, &params.dInv)
+	require.True(t, x.Equal(&y))
+}
+
+func TestHash(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	inputs := randomElements(5)
+
+	h := NewGriffin()
+	for i := range inputs {
+		b := inputs[i].Bytes()
+		_, err := h.Write(b[:])
+		assert.NoError(err)
+	}
+	expected := params.Hash(inputs)
+	bytes := expected[0].Bytes()
+	assert.Equal(bytes[:], h.Sum(nil))
+	assert.Equal(bytes[:], h.Sum(nil), "Sum doesn't change the state")
+
+	h.Reset()
+	h.(interface{ WriteElements(...fr.Element) }).WriteElements(inputs...)
+	assert.Equal(bytes[:], h.Sum(nil))
+
+	// the padding tells apart trailing zeros
+	padded := params.Hash(append(inputs, fr.Element{}))
+	assert.NotEqual(expected, padded)
+
+	_, err := h.Write(make([]byte, BlockSize-1))
+	assert.Error(err)
+}
@@ -17,6 +17,7 @@
 package permutation
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
@@ -70,6 +71,52 @@ func TestProof(t *testing.T) {
 
 }
 
+func TestProofSerialization(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	assert.NoError(t, err)
+
+	a := make([]fr.Element, 8)
+	b := make([]fr.Element, 8)
+	for i := 0; i < 8; i++ {
+		a[i].SetUint64(uint64(4*i + 1))
+	}
+	for i := 0; i < 8; i++ {
+		b[i].Set(&a[(5*i)%8])
+	}
+
+	proof, err := Prove(kzgSrs.Pk, a, b)
+	assert.NoError(t, err)
+
+	var compressed, raw bytes.Buffer
+	n, err := proof.WriteTo(&compressed)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(compressed.Len()), n)
+	n, err = proof.WriteRawTo(&raw)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(raw.Len()), n)
+	assert.Less(t, compressed.Len(), raw.Len())
+
+	for _, buf := range []*bytes.Buffer{&compressed, &raw} {
+		var decoded Proof
+		size := int64(buf.Len())
+		n, err := decoded.ReadFrom(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, size, n)
+		assert.Equal(t, proof, decoded)
+		assert.NoError(t, Verify(kzgSrs.Vk, decoded))
+	}
+
+	data, err := proof.MarshalBinary()
+	assert.NoError(t, err)
+	var decoded Proof
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, proof, decoded)
+
+	// truncated data
+	assert.Error(t, decoded.UnmarshalBinary(data[:len(data)-1]))
+}
+
 func BenchmarkProver(b *testing.B) {
 
 	srsSize := 1 << 15
@@ -0,0 +1,305 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package plookup
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/kzg"
+)
+
+// WriteTo writes binary encoding of the ProofLookupVector, with compressed points.
+func (proof *ProofLookupVector) WriteTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of the ProofLookupVector to w without point compression.
+func (proof *ProofLookupVector) WriteRawTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, bls12378.RawEncoding())
+}
+
+func (proof *ProofLookupVector) writeTo(w io.Writer, options ...func(*bls12378.Encoder)) (int64, error) {
+	enc := bls12378.NewEncoder(w, options...)
+
+	// the opening proofs are encoded field by field so that the options apply
+	toEncode := []interface{}{
+		proof.size,
+		&proof.g,
+		&proof.h1,
+		&proof.h2,
+		&proof.t,
+		&proof.z,
+		&proof.f,
+		&proof.h,
+		&proof.BatchedProof.H,
+		proof.BatchedProof.ClaimedValues,
+		&proof.BatchedProofShifted.H,
+		proof.BatchedProofShifted.ClaimedValues,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes a ProofLookupVector, written with compressed points or not, from r.
+func (proof *ProofLookupVector) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12378.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&proof.size,
+		&proof.g,
+		&proof.h1,
+		&proof.h2,
+		&proof.t,
+		&proof.z,
+		&proof.f,
+		&proof.h,
+		&proof.BatchedProof.H,
+		&proof.BatchedProof.ClaimedValues,
+		&proof.BatchedProofShifted.H,
+		&proof.BatchedProofShifted.ClaimedValues,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, with compressed points.
+func (proof *ProofLookupVector) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (proof *ProofLookupVector) UnmarshalBinary(data []byte) error {
+	_, err := proof.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteTo writes binary encoding of the ProofLookupTables, with compressed points.
+func (proof *ProofLookupTables) WriteTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the ProofLookupTables to w without point compression.
+func (proof *ProofLookupTables) WriteRawTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, true)
+}
+
+func (proof *ProofLookupTables) writeTo(w io.Writer, raw bool) (int64, error) {
+	var options []func(*bls12378.Encoder)
+	writeFolded, writePermutation := proof.foldedProof.WriteTo, proof.permutationProof.WriteTo
+	if raw {
+		options = append(options, bls12378.RawEncoding())
+		writeFolded, writePermutation = proof.foldedProof.WriteRawTo, proof.permutationProof.WriteRawTo
+	}
+
+	enc := bls12378.NewEncoder(w, options...)
+	if err := enc.Encode(proof.fs); err != nil {
+		return enc.BytesWritten(), err
+	}
+	if err := enc.Encode(proof.ts); err != nil {
+		return enc.BytesWritten(), err
+	}
+	n := enc.BytesWritten()
+
+	m, err := writeFolded(w)
+	n += m
+	if err != nil {
+		return n, err
+	}
+	m, err = writePermutation(w)
+	return n + m, err
+}
+
+// ReadFrom decodes a ProofLookupTables, written with compressed points or not, from r.
+func (proof *ProofLookupTables) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12378.NewDecoder(r)
+	if err := dec.Decode(&proof.fs); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&proof.ts); err != nil {
+		return dec.BytesRead(), err
+	}
+	n := dec.BytesRead()
+
+	m, err := proof.foldedProof.ReadFrom(r)
+	n += m
+	if err != nil {
+		return n, err
+	}
+	m, err = proof.permutationProof.ReadFrom(r)
+	return n + m, err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, with compressed points.
+func (proof *ProofLookupTables) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (proof *ProofLookupTables) UnmarshalBinary(data []byte) error {
+	_, err := proof.ReadFrom(bytes.NewReader(data))
+	return err
+}
+
+// WriteTo writes binary encoding of the ProofLogUp, with compressed points.
+func (proof *ProofLogUp) WriteTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w)
+}
+
+// WriteRawTo writes binary encoding of the ProofLogUp to w without point compression.
+func (proof *ProofLogUp) WriteRawTo(w io.Writer) (int64, error) {
+	return proof.writeTo(w, bls12378.RawEncoding())
+}
+
+func (proof *ProofLogUp) writeTo(w io.Writer, options ...func(*bls12378.Encoder)) (int64, error) {
+	enc := bls12378.NewEncoder(w, options...)
+
+	toEncode := []interface{}{
+		proof.size,
+		&proof.g,
+		uint32(len(proof.fs)),
+	}
+	for k := range proof.fs {
+		toEncode = append(toEncode, proof.fs[k])
+	}
+	toEncode = append(toEncode,
+		proof.ts,
+		&proof.m,
+		proof.hs,
+		&proof.ht,
+		&proof.z,
+		&proof.q,
+		&proof.BatchedProof.H,
+		proof.BatchedProof.ClaimedValues,
+		&proof.ShiftedProof.H,
+		&proof.ShiftedProof.ClaimedValue,
+	)
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes a ProofLogUp, written with compressed points or not, from r.
+// The encoded numbers of digests are not trusted: the slices grow as the
+// digests are actually read.
+func (proof *ProofLogUp) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12378.NewDecoder(r)
+
+	var nbTables uint32
+	for _, v := range []interface{}{&proof.size, &proof.g, &nbTables} {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	proof.fs = nil
+	for k := uint32(0); k < nbTables; k++ {
+		f, err := readDigests(dec)
+		if err != nil {
+			return dec.BytesRead(), err
+		}
+		proof.fs = append(proof.fs, f)
+	}
+
+	var err error
+	if proof.ts, err = readDigests(dec); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err = dec.Decode(&proof.m); err != nil {
+		return dec.BytesRead(), err
+	}
+	if proof.hs, err = readDigests(dec); err != nil {
+		return dec.BytesRead(), err
+	}
+
+	toDecode := []interface{}{
+		&proof.ht,
+		&proof.z,
+		&proof.q,
+		&proof.BatchedProof.H,
+		&proof.BatchedProof.ClaimedValues,
+		&proof.ShiftedProof.H,
+		&proof.ShiftedProof.ClaimedValue,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// readDigests decodes a slice of digests written by the Encoder, one digest at
+// a time.
+func readDigests(dec *bls12378.Decoder) ([]kzg.Digest, error) {
+	var n uint32
+	if err := dec.Decode(&n); err != nil {
+		return nil, err
+	}
+	res := []kzg.Digest{}
+	for i := uint32(0); i < n; i++ {
+		var d kzg.Digest
+		if err := dec.Decode(&d); err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	return res, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, with compressed points.
+func (proof *ProofLogUp) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := proof.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (proof *ProofLogUp) UnmarshalBinary(data []byte) error {
+	_, err := proof.ReadFrom(bytes.NewReader(data))
+	return err
+}
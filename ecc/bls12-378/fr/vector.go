@@ -152,23 +152,34 @@ func (vector *Vector) ReadFrom(r io.Reader) (int64, error) {
 	sliceLen := binary.BigEndian.Uint32(buf[:4])
 
 	n := int64(4)
-	(*vector) = make(Vector, sliceLen)
+	// the length is read from r and not trusted: the vector grows as the
+	// elements are actually read, from a bounded initial capacity.
+	capacity := sliceLen
+	if capacity > maxPreallocatedLen {
+		capacity = maxPreallocatedLen
+	}
+	(*vector) = make(Vector, 0, capacity)
 
-	for i := 0; i < int(sliceLen); i++ {
+	for i := uint32(0); i < sliceLen; i++ {
 		read, err := io.ReadFull(r, buf[:])
 		n += int64(read)
 		if err != nil {
 			return n, err
 		}
-		(*vector)[i], err = BigEndian.Element(&buf)
+		e, err := BigEndian.Element(&buf)
 		if err != nil {
 			return n, err
 		}
+		(*vector) = append(*vector, e)
 	}
 
 	return n, nil
 }
 
+// maxPreallocatedLen bounds the capacity ReadFrom allocates before reading
+// the elements.
+const maxPreallocatedLen = 1 << 16
+
 // String implements fmt.Stringer interface
 func (vector Vector) String() string {
 	var sbb strings.Builder
@@ -0,0 +1,150 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package tbls
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+var ErrNoCommitment = errors.New("at least one commitment is needed")
+
+var _, _, g1Gen, g2Gen = curve.Generators()
+
+// CommitmentsG1 are the Feldman commitments [a₀]G1, …, [aₜ₋₁]G1 to the
+// coefficients of a secret polynomial f, which make the shares of f verifiable.
+type CommitmentsG1 []curve.G1Affine
+
+// CommitG1 returns the Feldman commitments to the coefficients of f in G1.
+func (f Polynomial) CommitG1() CommitmentsG1 {
+	return curve.BatchScalarMultiplicationG1(&g1Gen, f)
+}
+
+// PublicKey returns [f(0)]G1, the public key of the shared secret.
+// c must not be empty.
+func (c CommitmentsG1) PublicKey() curve.G1Affine {
+	return c[0]
+}
+
+// PublicShare returns the public key share [f(id)]G1 = ∑ idᵏ⋅[aₖ]G1 of the
+// participant id.
+func (c CommitmentsG1) PublicShare(id uint32) (curve.G1Affine, error) {
+	var res curve.G1Affine
+	if len(c) == 0 {
+		return res, ErrNoCommitment
+	}
+	powers := make([]fr.Element, len(c))
+	powers[0].SetOne()
+	if len(c) > 1 {
+		powers[1].SetUint64(uint64(id))
+	}
+	for k := 2; k < len(powers); k++ {
+		powers[k].Mul(&powers[k-1], &powers[1])
+	}
+	_, err := res.MultiExp(c, powers, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// VerifyShare returns true if [share.Value]G1 = ∑ share.IDᵏ⋅[aₖ]G1.
+func (c CommitmentsG1) VerifyShare(share *Share) bool {
+	if share.ID == 0 {
+		return false
+	}
+	expected, err := c.PublicShare(share.ID)
+	if err != nil {
+		return false
+	}
+	var s big.Int
+	var res curve.G1Affine
+	share.Value.BigInt(&s)
+	res.ScalarMultiplication(&g1Gen, &s)
+	return res.Equal(&expected)
+}
+
+// add returns the commitments to the sum of the committed polynomials, which
+// must have the same degree.
+func (c CommitmentsG1) add(other CommitmentsG1) CommitmentsG1 {
+	res := make(CommitmentsG1, len(c))
+	for k := range res {
+		res[k].Add(&c[k], &other[k])
+	}
+	return res
+}
+
+// CommitmentsG2 are the Feldman commitments [a₀]G2, …, [aₜ₋₁]G2 to the
+// coefficients of a secret polynomial f, which make the shares of f verifiable.
+type CommitmentsG2 []curve.G2Affine
+
+// CommitG2 returns the Feldman commitments to the coefficients of f in G2.
+func (f Polynomial) CommitG2() CommitmentsG2 {
+	return curve.BatchScalarMultiplicationG2(&g2Gen, f)
+}
+
+// PublicKey returns [f(0)]G2, the public key of the shared secret.
+// c must not be empty.
+func (c CommitmentsG2) PublicKey() curve.G2Affine {
+	return c[0]
+}
+
+// PublicShare returns the public key share [f(id)]G2 = ∑ idᵏ⋅[aₖ]G2 of the
+// participant id.
+func (c CommitmentsG2) PublicShare(id uint32) (curve.G2Affine, error) {
+	var res curve.G2Affine
+	if len(c) == 0 {
+		return res, ErrNoCommitment
+	}
+	powers := make([]fr.Element, len(c))
+	powers[0].SetOne()
+	if len(c) > 1 {
+		powers[1].SetUint64(uint64(id))
+	}
+	for k := 2; k < len(powers); k++ {
+		powers[k].Mul(&powers[k-1], &powers[1])
+	}
+	_, err := res.MultiExp(c, powers, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// VerifyShare returns true if [share.Value]G2 = ∑ share.IDᵏ⋅[aₖ]G2.
+func (c CommitmentsG2) VerifyShare(share *Share) bool {
+	if share.ID == 0 {
+		return false
+	}
+	expected, err := c.PublicShare(share.ID)
+	if err != nil {
+		return false
+	}
+	var s big.Int
+	var res curve.G2Affine
+	share.Value.BigInt(&s)
+	res.ScalarMultiplication(&g2Gen, &s)
+	return res.Equal(&expected)
+}
+
+// add returns the commitments to the sum of the committed polynomials, which
+// must have the same degree.
+func (c CommitmentsG2) add(other CommitmentsG2) CommitmentsG2 {
+	res := make(CommitmentsG2, len(c))
+	for k := range res {
+		res[k].Add(&c[k], &other[k])
+	}
+	return res
+}
@@ -0,0 +1,371 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/twistededwards"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	errInvalidEncoding = errors.New("invalid key encoding")
+	errWrongCurve      = errors.New("key is not an EdDSA key on the twisted Edwards curve of bls12-378")
+	errKeyMismatch     = errors.New("public key doesn't match the private key")
+	errInvalidPoint    = errors.New("public key is not a point of the prime order subgroup")
+)
+
+// oidEdDSA is the object identifier of the EdDSA keys on the twisted Edwards
+// curve of bls12-378 (2.25.46608167216907221384985006267746188518.2.3), under the UUID arc of gnark-crypto
+// (ITU-T X.667), as Ed25519 has one in RFC 8410
+var oidEdDSA = []byte{0x69, 0xc6, 0x90, 0xb4, 0x83, 0xf6, 0xbe, 0xba, 0x88, 0xd7, 0xa4, 0xc5, 0xb1, 0xa0, 0xc8, 0x9d, 0xad, 0xf9, 0x66, 0x02, 0x03}
+
+const (
+	// jwkCurve is the "crv" parameter of the JSON Web Keys
+	jwkCurve = "bls12-378/twistededwards"
+
+	pemPrivateKey      = "PRIVATE KEY"
+	pemPublicKey       = "PUBLIC KEY"
+	sizePrivateSecrets = sizeFr + 32
+	versionPKCS8       = 0
+)
+
+// jwk is the JSON Web Key of an octet key pair, RFC 8037 Section 2
+type jwk struct {
+	Kty string  `json:"kty"`
+	Crv string  `json:"crv"`
+	X   string  `json:"x"`
+	D   *string `json:"d,omitempty"` // nil if absent
+}
+
+// MarshalPKIX returns the DER encoding of the public key as an X.509
+// SubjectPublicKeyInfo, holding its compressed representation as in RFC 8410.
+func (pk *PublicKey) MarshalPKIX() []byte {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		addAlgorithm(b)
+		b.AddASN1BitString(pk.Bytes())
+	})
+	return b.BytesOrPanic()
+}
+
+// UnmarshalPKIX sets the public key from its DER encoded X.509
+// SubjectPublicKeyInfo. The point must be canonically encoded, on the curve
+// and not the neutral element.
+func (pk *PublicKey) UnmarshalPKIX(der []byte) error {
+	var spki, alg cryptobyte.String
+	var point []byte
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&spki, asn1.SEQUENCE) || !input.Empty() ||
+		!spki.ReadASN1(&alg, asn1.SEQUENCE) ||
+		!spki.ReadASN1BitStringAsBytes(&point) || !spki.Empty() {
+		return errInvalidEncoding
+	}
+	if err := readAlgorithm(&alg); err != nil {
+		return err
+	}
+	return pk.setCanonicalBytes(point)
+}
+
+// MarshalPEM returns the PEM encoding of the public key, as a "PUBLIC KEY"
+// block of its SubjectPublicKeyInfo.
+func (pk *PublicKey) MarshalPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: pk.MarshalPKIX()})
+}
+
+// UnmarshalPEM sets the public key from a single "PUBLIC KEY" PEM block.
+func (pk *PublicKey) UnmarshalPEM(data []byte) error {
+	block, err := decodePEM(data)
+	if err != nil {
+		return err
+	}
+	if block.Type != pemPublicKey {
+		return errInvalidEncoding
+	}
+	return pk.UnmarshalPKIX(block.Bytes)
+}
+
+// MarshalJWK returns the JSON Web Key of the public key, of type "OKP" with
+// the curve "bls12-378/twistededwards" and x its compressed representation.
+func (pk *PublicKey) MarshalJWK() []byte {
+	res, _ := json.Marshal(jwk{
+		Kty: "OKP",
+		Crv: jwkCurve,
+		X:   base64.RawURLEncoding.EncodeToString(pk.Bytes()),
+	})
+	return res
+}
+
+// UnmarshalJWK sets the public key from its JSON Web Key. x must be unpadded
+// base64url encoded, and the key must not contain the private parameter "d"
+// nor any member other than kty, crv and x.
+func (pk *PublicKey) UnmarshalJWK(data []byte) error {
+	key, err := decodeJWK(data)
+	if err != nil {
+		return err
+	}
+	if key.D != nil {
+		return errInvalidEncoding
+	}
+	return pk.unmarshalJWK(key)
+}
+
+// MarshalPKCS8 returns the DER encoding of the private key as a PKCS #8
+// PrivateKeyInfo, whose private key is the octet string scalar||randSrc as in
+// RFC 8410.
+func (privKey *PrivateKey) MarshalPKCS8() []byte {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1Int64(versionPKCS8)
+		addAlgorithm(b)
+		b.AddASN1(asn1.OCTET_STRING, func(b *cryptobyte.Builder) {
+			b.AddASN1OctetString(privKey.secrets())
+		})
+	})
+	return b.BytesOrPanic()
+}
+
+// UnmarshalPKCS8 sets the private key from its DER encoded PKCS #8
+// PrivateKeyInfo, without attributes. The public key is computed from the
+// scalar, which must not be zero.
+func (privKey *PrivateKey) UnmarshalPKCS8(der []byte) error {
+	var info, alg, wrapped cryptobyte.String
+	var secrets []byte
+	var version int64
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&info, asn1.SEQUENCE) || !input.Empty() ||
+		!info.ReadASN1Integer(&version) ||
+		!info.ReadASN1(&alg, asn1.SEQUENCE) ||
+		!info.ReadASN1(&wrapped, asn1.OCTET_STRING) || !info.Empty() ||
+		!wrapped.ReadASN1Bytes(&secrets, asn1.OCTET_STRING) || !wrapped.Empty() {
+		return errInvalidEncoding
+	}
+	if version != versionPKCS8 {
+		return errInvalidEncoding
+	}
+	if err := readAlgorithm(&alg); err != nil {
+		return err
+	}
+	return privKey.setSecrets(secrets, nil)
+}
+
+// MarshalPEM returns the PEM encoding of the private key, as a "PRIVATE KEY"
+// block of its PKCS #8 PrivateKeyInfo.
+func (privKey *PrivateKey) MarshalPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: privKey.MarshalPKCS8()})
+}
+
+// UnmarshalPEM sets the private key from a single "PRIVATE KEY" PEM block.
+// Encrypted blocks are rejected.
+func (privKey *PrivateKey) UnmarshalPEM(data []byte) error {
+	block, err := decodePEM(data)
+	if err != nil {
+		return err
+	}
+	if block.Type != pemPrivateKey {
+		return errInvalidEncoding
+	}
+	return privKey.UnmarshalPKCS8(block.Bytes)
+}
+
+// MarshalJWK returns the JSON Web Key of the private key, of type "OKP" with
+// the curve "bls12-378/twistededwards" and d the octet string scalar||randSrc.
+func (privKey *PrivateKey) MarshalJWK() []byte {
+	d := base64.RawURLEncoding.EncodeToString(privKey.secrets())
+	res, _ := json.Marshal(jwk{
+		Kty: "OKP",
+		Crv: jwkCurve,
+		X:   base64.RawURLEncoding.EncodeToString(privKey.PublicKey.Bytes()),
+		D:   &d,
+	})
+	return res
+}
+
+// UnmarshalJWK sets the private key from its JSON Web Key. x and d must be
+// unpadded base64url encoded, x must be the public key of d, and the key must
+// not contain any member other than kty, crv, x and d.
+func (privKey *PrivateKey) UnmarshalJWK(data []byte) error {
+	key, err := decodeJWK(data)
+	if err != nil {
+		return err
+	}
+	var pk PublicKey
+	if err = pk.unmarshalJWK(key); err != nil {
+		return err
+	}
+	if key.D == nil {
+		return errInvalidEncoding
+	}
+	d, err := base64.RawURLEncoding.Strict().DecodeString(*key.D)
+	if err != nil {
+		return errInvalidEncoding
+	}
+	return privKey.setSecrets(d, &pk)
+}
+
+// secrets returns scalar||randSrc
+func (privKey *PrivateKey) secrets() []byte {
+	res := make([]byte, sizePrivateSecrets)
+	copy(res, privKey.scalar[:])
+	copy(res[sizeFr:], privKey.randSrc[:])
+	return res
+}
+
+// setSecrets sets the private key from scalar||randSrc. The public key is
+// computed from the scalar, and must be equal to pk if it is not nil.
+func (privKey *PrivateKey) setSecrets(secrets []byte, pk *PublicKey) error {
+	if len(secrets) != sizePrivateSecrets {
+		return errInvalidEncoding
+	}
+	c := twistededwards.GetEdwardsCurve()
+	scalar := new(big.Int).SetBytes(secrets[:sizeFr])
+	// a multiple of the order would give the identity as public key
+	if new(big.Int).Mod(scalar, &c.Order).Sign() == 0 {
+		return errZero
+	}
+	var A twistededwards.PointAffine
+	A.ScalarMultiplication(&c.Base, scalar)
+	if pk != nil && !pk.A.Equal(&A) {
+		return errKeyMismatch
+	}
+	privKey.PublicKey.A = A
+	copy(privKey.scalar[:], secrets[:sizeFr])
+	copy(privKey.randSrc[:], secrets[sizeFr:])
+	return nil
+}
+
+// setCanonicalBytes sets the public key from its compressed representation,
+// which must be the one returned by Bytes, of a point of the prime order
+// subgroup other than the identity.
+func (pk *PublicKey) setCanonicalBytes(buf []byte) error {
+	if len(buf) != sizePublicKey {
+		return errInvalidEncoding
+	}
+	var A PublicKey
+	if _, err := A.SetBytes(buf); err != nil {
+		return err
+	}
+	if !bytes.Equal(A.Bytes(), buf) {
+		return errInvalidEncoding
+	}
+	if A.A.IsZero() {
+		return errZero
+	}
+	// SetBytes only checks that the point is on the curve, which has a cofactor
+	c := twistededwards.GetEdwardsCurve()
+	var lA twistededwards.PointAffine
+	if lA.ScalarMultiplication(&A.A, &c.Order); !lA.IsZero() {
+		return errInvalidPoint
+	}
+	*pk = A
+	return nil
+}
+
+func (pk *PublicKey) unmarshalJWK(key *jwk) error {
+	if key.Kty != "OKP" || key.Crv != jwkCurve {
+		return errWrongCurve
+	}
+	x, err := base64.RawURLEncoding.Strict().DecodeString(key.X)
+	if err != nil {
+		return errInvalidEncoding
+	}
+	return pk.setCanonicalBytes(x)
+}
+
+// addAlgorithm adds the AlgorithmIdentifier of the keys, without parameters
+func addAlgorithm(b *cryptobyte.Builder) {
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(asn1.OBJECT_IDENTIFIER, func(b *cryptobyte.Builder) {
+			b.AddBytes(oidEdDSA)
+		})
+	})
+}
+
+// readAlgorithm reads the contents of an AlgorithmIdentifier, which must be
+// the one of the keys, without parameters
+func readAlgorithm(alg *cryptobyte.String) error {
+	var algorithm cryptobyte.String
+	if !alg.ReadASN1(&algorithm, asn1.OBJECT_IDENTIFIER) || !alg.Empty() {
+		return errInvalidEncoding
+	}
+	if !bytes.Equal(algorithm, oidEdDSA) {
+		return errWrongCurve
+	}
+	return nil
+}
+
+// decodePEM returns the only PEM block of data, which must not have headers
+func decodePEM(data []byte) (*pem.Block, error) {
+	block, rest := pem.Decode(data)
+	if block == nil || len(block.Headers) != 0 || len(bytes.TrimSpace(rest)) != 0 {
+		return nil, errInvalidEncoding
+	}
+	return block, nil
+}
+
+// decodeJWK decodes the JSON Web Key in data, which must only have the members
+// kty, crv, x and d, with these exact names and at most once each.
+func decodeJWK(data []byte) (*jwk, error) {
+	// encoding/json matches the names of the members case-insensitively and
+	// keeps the last of duplicate members: check the names first
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, errInvalidEncoding
+	}
+	seen := make(map[string]bool)
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, errInvalidEncoding
+		}
+		switch name := t.(string); name {
+		case "kty", "crv", "x", "d":
+			if seen[name] {
+				return nil, errInvalidEncoding
+			}
+			seen[name] = true
+		default:
+			return nil, errInvalidEncoding
+		}
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, errInvalidEncoding
+		}
+	}
+	if t, err := dec.Token(); err != nil || t != json.Delim('}') {
+		return nil, errInvalidEncoding
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errInvalidEncoding
+	}
+
+	var key jwk
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&key); err != nil {
+		return nil, errInvalidEncoding
+	}
+	return &key, nil
+}
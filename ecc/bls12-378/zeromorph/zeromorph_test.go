@@ -0,0 +1,194 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package zeromorph
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/stretchr/testify/require"
+)
+
+// testSRS re-used across tests of the Zeromorph scheme
+var testSRS *SRS
+
+const maxNbVars = 5
+
+func init() {
+	var err error
+	testSRS, err = NewSRS(maxNbVars, new(big.Int).SetInt64(42))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func randomMultiLin(t *testing.T, nbVars int) polynomial.MultiLin {
+	res := make(polynomial.MultiLin, 1<<nbVars)
+	for i := range res {
+		_, err := res[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return res
+}
+
+func randomPoint(t *testing.T, nbVars int) []fr.Element {
+	res := make([]fr.Element, nbVars)
+	for i := range res {
+		_, err := res[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return res
+}
+
+func testSettings() fiatshamir.Settings {
+	return fiatshamir.WithHash(sha256.New(), []byte("zeromorph test"))
+}
+
+func TestOpen(t *testing.T) {
+	assert := require.New(t)
+
+	for nbVars := 0; nbVars <= maxNbVars; nbVars++ {
+		f := randomMultiLin(t, nbVars)
+		point := randomPoint(t, nbVars)
+		digest, err := Commit(f, testSRS.Pk)
+		assert.NoError(err)
+
+		proof, err := Open(f, &digest, point, testSRS.Pk, testSettings())
+		assert.NoError(err)
+		expected := f.Evaluate(point, nil)
+		assert.True(expected.Equal(&proof.ClaimedValue))
+		assert.NoError(Verify(&digest, &proof, point, testSRS.Vk, testSettings()))
+
+		// wrong claimed value
+		wrong := proof
+		wrong.ClaimedValue.SetOne().Add(&wrong.ClaimedValue, &proof.ClaimedValue)
+		assert.Error(Verify(&digest, &wrong, point, testSRS.Vk, testSettings()))
+
+		// wrong point and wrong transcript, a constant polynomial has no quotients
+		if nbVars > 0 {
+			wrongPoint := append([]fr.Element(nil), point...)
+			wrongPoint[0].SetOne()
+			assert.Error(Verify(&digest, &proof, wrongPoint, testSRS.Vk, testSettings()))
+			assert.Error(Verify(&digest, &proof, point, testSRS.Vk, fiatshamir.WithHash(sha256.New())))
+		}
+	}
+
+	// the opening is also correct from an SRS larger than the polynomial
+	srs, err := kzg.NewSRS(1<<(maxNbVars+1), new(big.Int).SetInt64(42))
+	assert.NoError(err)
+	large := FromKZG(srs)
+	f := randomMultiLin(t, 2)
+	point := randomPoint(t, 2)
+	digest, err := Commit(f, large.Pk)
+	assert.NoError(err)
+	proof, err := Open(f, &digest, point, large.Pk, testSettings())
+	assert.NoError(err)
+	assert.NoError(Verify(&digest, &proof, point, large.Vk, testSettings()))
+
+	_, err = Commit(make(polynomial.MultiLin, 3), testSRS.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = Commit(make(polynomial.MultiLin, 1<<(maxNbVars+1)), testSRS.Pk)
+	assert.ErrorIs(err, ErrPolynomialTooLarge)
+	_, err = Open(f, &digest, point[:1], testSRS.Pk, testSettings())
+	assert.ErrorIs(err, ErrInvalidNbVars)
+	proof.Quotients = proof.Quotients[:1]
+	assert.ErrorIs(Verify(&digest, &proof, point, large.Vk, testSettings()), ErrInvalidProof)
+}
+
+func TestBatchOpen(t *testing.T) {
+	assert := require.New(t)
+
+	const nbVars = 4
+	polynomials := make([]polynomial.MultiLin, 5)
+	digests := make([]Digest, len(polynomials))
+	for i := range polynomials {
+		polynomials[i] = randomMultiLin(t, nbVars)
+		var err error
+		digests[i], err = Commit(polynomials[i], testSRS.Pk)
+		assert.NoError(err)
+	}
+	point := randomPoint(t, nbVars)
+
+	proof, err := BatchOpen(polynomials, digests, point, testSRS.Pk, testSettings())
+	assert.NoError(err)
+	for i := range polynomials {
+		expected := polynomials[i].Evaluate(point, nil)
+		assert.True(expected.Equal(&proof.ClaimedValues[i]))
+	}
+	assert.NoError(BatchVerify(digests, &proof, point, testSRS.Vk, testSettings()))
+
+	// wrong claimed value
+	proof.ClaimedValues[1].SetOne()
+	assert.Error(BatchVerify(digests, &proof, point, testSRS.Vk, testSettings()))
+
+	_, err = BatchOpen(polynomials, digests[1:], point, testSRS.Pk, testSettings())
+	assert.ErrorIs(err, ErrInvalidNbDigests)
+	_, err = BatchOpen(nil, nil, point, testSRS.Pk, testSettings())
+	assert.ErrorIs(err, ErrZeroNbDigests)
+}
+
+func TestBatchVerifyMultiPoints(t *testing.T) {
+	assert := require.New(t)
+
+	const nbProofs = 4
+	digests := make([]Digest, nbProofs)
+	proofs := make([]OpeningProof, nbProofs)
+	points := make([][]fr.Element, nbProofs)
+	settings := make([]fiatshamir.Settings, nbProofs)
+	for i := range digests {
+		nbVars := i + 1
+		f := randomMultiLin(t, nbVars)
+		points[i] = randomPoint(t, nbVars)
+		var err error
+		digests[i], err = Commit(f, testSRS.Pk)
+		assert.NoError(err)
+		proofs[i], err = Open(f, &digests[i], points[i], testSRS.Pk, testSettings())
+		assert.NoError(err)
+		settings[i] = testSettings()
+	}
+	assert.NoError(BatchVerifyMultiPoints(digests, proofs, points, testSRS.Vk, settings))
+
+	for i := range settings {
+		settings[i] = testSettings()
+	}
+	proofs[2].ClaimedValue.SetOne()
+	assert.Error(BatchVerifyMultiPoints(digests, proofs, points, testSRS.Vk, settings))
+
+	assert.ErrorIs(BatchVerifyMultiPoints(nil, nil, nil, testSRS.Vk, nil), ErrZeroNbDigests)
+	assert.ErrorIs(BatchVerifyMultiPoints(digests, proofs[1:], points, testSRS.Vk, settings), ErrInvalidNbDigests)
+}
+
+func BenchmarkOpen(b *testing.B) {
+	f := make(polynomial.MultiLin, 1<<maxNbVars)
+	for i := range f {
+		f[i].SetRandom()
+	}
+	point := make([]fr.Element, maxNbVars)
+	for i := range point {
+		point[i].SetRandom()
+	}
+	digest, _ := Commit(f, testSRS.Pk)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Open(f, &digest, point, testSRS.Pk, testSettings())
+	}
+}
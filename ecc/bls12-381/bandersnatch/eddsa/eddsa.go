@@ -17,12 +17,14 @@
 package eddsa
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
 	"errors"
 	"hash"
 	"io"
 	"math/big"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
 	"github.com/consensys/gnark-crypto/signature"
@@ -31,6 +33,7 @@ import (
 
 var errNotOnCurve = errors.New("point not on curve")
 var errHashNeeded = errors.New("hFunc cannot be nil. We need a hash for Fiat-Shamir")
+var errInvalidBatch = errors.New("public keys, signatures and messages must have the same length")
 
 const (
 	sizeFr         = fr.Bytes
@@ -208,25 +211,11 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 	}
 
 	// compute H(R, A, M), all parameters in data are in Montgomery form
-
-	hFunc.Reset()
-
-	sigRX := sig.R.X.Bytes()
-	sigRY := sig.R.Y.Bytes()
-	sigAX := pub.A.X.Bytes()
-	sigAY := pub.A.Y.Bytes()
-
-	toWrite := [][]byte{sigRX[:], sigRY[:], sigAX[:], sigAY[:], message}
-	for _, bytes := range toWrite {
-		if _, err := hFunc.Write(bytes); err != nil {
-			return false, err
-		}
+	hramInt, err := hram(&sig.R, &pub.A, message, hFunc)
+	if err != nil {
+		return false, err
 	}
 
-	var hramInt big.Int
-	hramBin := hFunc.Sum(nil)
-	hramInt.SetBytes(hramBin)
-
 	// lhs = cofactor*S*Base
 	var lhs twistededwards.PointAffine
 	var bCofactor, bs big.Int
@@ -241,7 +230,7 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 
 	// rhs = cofactor*(R + H(R,A,M)*A)
 	var rhs twistededwards.PointAffine
-	rhs.ScalarMultiplication(&pub.A, &hramInt).
+	rhs.ScalarMultiplication(&pub.A, hramInt).
 		Add(&rhs, &sig.R).
 		ScalarMultiplication(&rhs, &bCofactor)
 	if !rhs.IsOnCurve() {
@@ -255,3 +244,112 @@ func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, err
 
 	return true, nil
 }
+
+// hram returns H(R, A, M)
+func hram(R, A *twistededwards.PointAffine, message []byte, hFunc hash.Hash) (*big.Int, error) {
+	hFunc.Reset()
+
+	RX := R.X.Bytes()
+	RY := R.Y.Bytes()
+	AX := A.X.Bytes()
+	AY := A.Y.Bytes()
+	toWrite := [][]byte{RX[:], RY[:], AX[:], AY[:], message}
+	for _, bytes := range toWrite {
+		if _, err := hFunc.Write(bytes); err != nil {
+			return nil, err
+		}
+	}
+
+	var hramInt big.Int
+	hramInt.SetBytes(hFunc.Sum(nil))
+	return &hramInt, nil
+}
+
+// BatchVerify verifies the signatures sigs of the messages msgs under the public
+// keys pubs at once. It checks the random linear combination
+//
+//	cofactor·((∑ zᵢSᵢ)·Base - ∑ zᵢRᵢ - ∑ zᵢH(Rᵢ,Aᵢ,Mᵢ)·Aᵢ) = 0
+//
+// of the verification equations, with 128-bit random zᵢ, using a single
+// multi-scalar multiplication. If it doesn't hold, each signature is verified
+// separately. It returns the indices of the invalid signatures, nil if they are
+// all valid.
+func BatchVerify(pubs []PublicKey, sigs, msgs [][]byte, hFunc hash.Hash) ([]int, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	if len(pubs) != len(sigs) || len(pubs) != len(msgs) {
+		return nil, errInvalidBatch
+	}
+	if len(pubs) == 0 {
+		return nil, nil
+	}
+
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	// base, then Rᵢ and Aᵢ
+	points := make([]twistededwards.PointAffine, 1+2*len(pubs))
+	scalars := make([]big.Int, len(points))
+	points[0] = curveParams.Base
+
+	var sig Signature
+	var z, bs big.Int
+	bound := new(big.Int).Lsh(big.NewInt(1), 128)
+	for i := range pubs {
+		if !pubs[i].A.IsOnCurve() {
+			return fallbackVerify(pubs, sigs, msgs, hFunc)
+		}
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return fallbackVerify(pubs, sigs, msgs, hFunc)
+		}
+		hramInt, err := hram(&sig.R, &pubs[i].A, msgs[i], hFunc)
+		if err != nil {
+			return fallbackVerify(pubs, sigs, msgs, hFunc)
+		}
+
+		zi, err := rand.Int(rand.Reader, bound)
+		if err != nil {
+			return nil, err
+		}
+		z.Add(zi, big.NewInt(1))
+
+		// ∑ zᵢSᵢ
+		bs.SetBytes(sig.S[:])
+		bs.Mul(&bs, &z)
+		scalars[0].Add(&scalars[0], &bs)
+
+		// -zᵢRᵢ
+		points[1+2*i] = sig.R
+		scalars[1+2*i].Neg(&z).Mod(&scalars[1+2*i], &curveParams.Order)
+
+		// -zᵢH(Rᵢ,Aᵢ,Mᵢ)Aᵢ
+		points[2+2*i] = pubs[i].A
+		scalars[2+2*i].Mul(&z, hramInt).Neg(&scalars[2+2*i]).Mod(&scalars[2+2*i], &curveParams.Order)
+	}
+	scalars[0].Mod(&scalars[0], &curveParams.Order)
+
+	var bCofactor big.Int
+	curveParams.Cofactor.BigInt(&bCofactor)
+	var res twistededwards.PointExtended
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	res.ScalarMultiplication(&res, &bCofactor)
+	if res.IsZero() {
+		return nil, nil
+	}
+
+	return fallbackVerify(pubs, sigs, msgs, hFunc)
+}
+
+// fallbackVerify verifies the signatures one by one and returns the indices of
+// the invalid ones, including those that can't be decoded or hashed.
+func fallbackVerify(pubs []PublicKey, sigs, msgs [][]byte, hFunc hash.Hash) ([]int, error) {
+	var failed []int
+	for i := range pubs {
+		if valid, err := pubs[i].Verify(sigs[i], msgs[i], hFunc); err != nil || !valid {
+			failed = append(failed, i)
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,660 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package eddsa
+
+import (
+	"encoding/binary"
+	"hash"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+	"github.com/consensys/gnark-crypto/internal/multisig"
+)
+
+// Domain separation tags of the hashes to scalars
+const (
+	tagDKGProof   = "FROST/dkg proof"
+	tagFROSTNonce = "FROST/nonce"
+	tagRho        = "FROST/rho"
+)
+
+const sizeID = 4
+
+// FROSTDKG is the state of a participant to the distributed key generation of
+// FROST (https://eprint.iacr.org/2020/852.pdf, figure 1). Each of the
+// nbParticipants participants, identified by 1, …, nbParticipants:
+//
+//  1. calls NewFROSTDKG and broadcasts the returned FROSTDKGRound1
+//  2. calls Round2 with the broadcast packages of all the participants and sends
+//     each of the returned FROSTDKGRound2 to its recipient only
+//  3. calls Finalize with the packages it received to get its FROSTKeyShare
+//
+// Any threshold participants can then sign with the group key. The secret
+// polynomial is dropped by Finalize.
+type FROSTDKG struct {
+	id, threshold, nbParticipants uint32
+	context                       []byte    // session context Φ
+	coefficients                  []big.Int // secret polynomial f, lowest degree first
+	commitments                   [][]twistededwards.PointAffine
+}
+
+// FROSTDKGRound1 is the broadcast message of a participant: the commitments
+// Cₖ = aₖ⋅Base to the coefficients of its secret polynomial and a Schnorr proof
+// (R, μ) of knowledge of a₀.
+type FROSTDKGRound1 struct {
+	ID          uint32
+	Commitments []twistededwards.PointAffine
+	R           twistededwards.PointAffine
+	Mu          [sizeFr]byte
+}
+
+// FROSTDKGRound2 is the secret share f_From(To) sent by a participant to
+// another one. It must be sent over a confidential and authenticated channel.
+type FROSTDKGRound2 struct {
+	From, To uint32
+	Share    [sizeFr]byte
+}
+
+// FROSTKeyShare is the key of a FROST participant: its share sᵢ of the secret
+// key, the group public key and the public verification shares sⱼ⋅Base of all
+// the participants.
+type FROSTKeyShare struct {
+	ID                 uint32
+	Threshold          uint32
+	GroupKey           PublicKey
+	VerificationShares []twistededwards.PointAffine // indexed by identifier - 1
+	secret             [sizeFr]byte
+}
+
+// FROSTSecretNonce is the secret part of the nonce of a FROST signer. It is
+// zeroed by FROSTSession.Sign, so that it cannot be used for two signatures.
+// Copies of a FROSTSecretNonce share the same secret, so that signing with one
+// of them invalidates all of them.
+type FROSTSecretNonce struct {
+	secret *frostSecretNonce
+}
+
+// frostSecretNonce holds the secret scalars of a FROSTSecretNonce
+type frostSecretNonce struct {
+	id   uint32
+	d, e [sizeFr]byte
+}
+
+// FROSTNonceCommitment is the first round message of a FROST signer,
+// D = d⋅Base and E = e⋅Base.
+type FROSTNonceCommitment struct {
+	ID   uint32
+	D, E twistededwards.PointAffine
+}
+
+// FROSTSignatureShare is the second round message of a FROST signer,
+// zᵢ = dᵢ + eᵢ⋅ρᵢ + λᵢ⋅sᵢ⋅c mod l
+type FROSTSignatureShare struct {
+	ID uint32
+	Z  [sizeFr]byte
+}
+
+// FROSTSession holds the values shared by the signers of a message once their
+// nonce commitments are known.
+type FROSTSession struct {
+	groupKey    PublicKey
+	commitments []FROSTNonceCommitment // sorted by identifier
+	ids         []uint32               // identifiers of the signers, sorted
+	rho         []big.Int              // binding factors
+	lambda      []big.Int              // Lagrange coefficients at 0
+	c           big.Int                // challenge H(R, Y, m)
+	R           twistededwards.PointAffine
+}
+
+// NewFROSTDKG starts the distributed key generation of a threshold-out-of-
+// nbParticipants key for the participant id, drawing its secret polynomial
+// from rand. It returns the message to broadcast to the other participants.
+//
+// context is the context string Φ of the session, which all the participants
+// must agree on beforehand and which must not be reused for another key
+// generation: the proofs of knowledge are bound to it so that they can't be
+// replayed in another session.
+func NewFROSTDKG(id, threshold, nbParticipants uint32, context []byte, rand io.Reader) (*FROSTDKG, *FROSTDKGRound1, error) {
+	if err := multisig.CheckParameters(id, threshold, nbParticipants); err != nil {
+		return nil, nil, err
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	dkg := &FROSTDKG{
+		id:             id,
+		threshold:      threshold,
+		nbParticipants: nbParticipants,
+		context:        append([]byte{}, context...),
+		coefficients:   make([]big.Int, threshold),
+	}
+	round1 := &FROSTDKGRound1{
+		ID:          id,
+		Commitments: make([]twistededwards.PointAffine, threshold),
+	}
+	for i := range dkg.coefficients {
+		a, err := randScalar(rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		dkg.coefficients[i].Set(a)
+		round1.Commitments[i].ScalarMultiplication(&curveParams.Base, a)
+	}
+
+	// μ = k + a₀⋅H(id, Φ, C₀, R)
+	k, err := randScalar(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	round1.R.ScalarMultiplication(&curveParams.Base, k)
+	mu := dkgChallenge(id, dkg.context, &round1.Commitments[0], &round1.R)
+	mu.Mul(mu, &dkg.coefficients[0]).
+		Add(mu, k).
+		Mod(mu, &curveParams.Order)
+	mu.FillBytes(round1.Mu[:])
+
+	return dkg, round1, nil
+}
+
+// Round2 checks the broadcast messages of all the participants, including the
+// one of dkg, and returns the secret shares to send to the other participants.
+func (dkg *FROSTDKG) Round2(round1 []FROSTDKGRound1) ([]FROSTDKGRound2, error) {
+	if dkg.coefficients == nil || dkg.commitments != nil {
+		return nil, multisig.ErrDKGState
+	}
+	if len(round1) != int(dkg.nbParticipants) {
+		return nil, multisig.ErrNbPackages
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	commitments := make([][]twistededwards.PointAffine, dkg.nbParticipants)
+	received := make(multisig.Participants, dkg.nbParticipants)
+	for i := range round1 {
+		p := &round1[i]
+		if err := received.Add(p.ID); err != nil {
+			return nil, err
+		}
+		if len(p.Commitments) != int(dkg.threshold) {
+			return nil, multisig.ErrInvalidThreshold
+		}
+		for j := range p.Commitments {
+			if !p.Commitments[j].IsOnCurve() {
+				return nil, errNotOnCurve
+			}
+		}
+		if !p.R.IsOnCurve() {
+			return nil, errNotOnCurve
+		}
+
+		// μ⋅Base ?= R + H(id, Φ, C₀, R)⋅C₀
+		var mu big.Int
+		var lhs, rhs twistededwards.PointAffine
+		mu.SetBytes(p.Mu[:])
+		lhs.ScalarMultiplication(&curveParams.Base, &mu)
+		rhs.ScalarMultiplication(&p.Commitments[0], dkgChallenge(p.ID, dkg.context, &p.Commitments[0], &p.R)).
+			Add(&rhs, &p.R)
+		if !lhs.Equal(&rhs) {
+			return nil, multisig.ErrInvalidProof
+		}
+		commitments[p.ID-1] = append([]twistededwards.PointAffine{}, p.Commitments...)
+	}
+	dkg.commitments = commitments
+
+	res := make([]FROSTDKGRound2, 0, dkg.nbParticipants-1)
+	for j := uint32(1); j <= dkg.nbParticipants; j++ {
+		if j == dkg.id {
+			continue
+		}
+		share := FROSTDKGRound2{From: dkg.id, To: j}
+		multisig.Evaluate(dkg.coefficients, j, &curveParams.Order).FillBytes(share.Share[:])
+		res = append(res, share)
+	}
+	return res, nil
+}
+
+// Finalize checks the secret shares sent to dkg by the other participants and
+// returns the key share of dkg. The secret polynomial of dkg is dropped.
+func (dkg *FROSTDKG) Finalize(round2 []FROSTDKGRound2) (*FROSTKeyShare, error) {
+	if dkg.coefficients == nil || dkg.commitments == nil {
+		return nil, multisig.ErrDKGState
+	}
+	if len(round2) != int(dkg.nbParticipants)-1 {
+		return nil, multisig.ErrNbPackages
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	secret := multisig.Evaluate(dkg.coefficients, dkg.id, &curveParams.Order)
+	received := make(multisig.Participants, dkg.nbParticipants)
+	received.Add(dkg.id)
+	var share big.Int
+	var lhs twistededwards.PointAffine
+	for i := range round2 {
+		p := &round2[i]
+		if p.To != dkg.id {
+			return nil, multisig.ErrInvalidID
+		}
+		if err := received.Add(p.From); err != nil {
+			return nil, err
+		}
+
+		// f_From(id)⋅Base ?= ∑ idᵏ⋅Cₖ
+		if err := checkScalar(p.Share[:]); err != nil {
+			return nil, err
+		}
+		share.SetBytes(p.Share[:])
+		lhs.ScalarMultiplication(&curveParams.Base, &share)
+		rhs, err := evaluateCommitments(dkg.commitments[p.From-1], dkg.id)
+		if err != nil {
+			return nil, err
+		}
+		if !lhs.Equal(rhs) {
+			return nil, multisig.ErrInvalidShare
+		}
+		secret.Add(secret, &share)
+	}
+	secret.Mod(secret, &curveParams.Order)
+
+	// commitments to the coefficients of ∑ fⱼ
+	sum := make([]twistededwards.PointAffine, dkg.threshold)
+	for k := range sum {
+		sum[k].Set(&dkg.commitments[0][k])
+		for j := 1; j < len(dkg.commitments); j++ {
+			sum[k].Add(&sum[k], &dkg.commitments[j][k])
+		}
+	}
+
+	res := &FROSTKeyShare{
+		ID:                 dkg.id,
+		Threshold:          dkg.threshold,
+		VerificationShares: make([]twistededwards.PointAffine, dkg.nbParticipants),
+	}
+	res.GroupKey.A.Set(&sum[0])
+	for j := range res.VerificationShares {
+		Y, err := evaluateCommitments(sum, uint32(j+1))
+		if err != nil {
+			return nil, err
+		}
+		res.VerificationShares[j].Set(Y)
+	}
+	secret.FillBytes(res.secret[:])
+
+	dkg.coefficients = nil
+	return res, nil
+}
+
+// evaluateCommitments returns ∑ xᵏ⋅commitments[k]
+func evaluateCommitments(commitments []twistededwards.PointAffine, x uint32) (*twistededwards.PointAffine, error) {
+	scalars := make([]big.Int, len(commitments))
+	scalars[0].SetUint64(1)
+	bx := new(big.Int).SetUint64(uint64(x))
+	for k := 1; k < len(scalars); k++ {
+		scalars[k].Mul(&scalars[k-1], bx)
+	}
+	var res twistededwards.PointAffine
+	if _, err := res.MultiExp(commitments, scalars, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// dkgChallenge returns H(id, Φ, C₀, R), the challenge of the proof of
+// knowledge of a₀ in the session of context Φ. Φ is the only part of variable
+// length, so the encoding is unambiguous.
+func dkgChallenge(id uint32, context []byte, c0, R *twistededwards.PointAffine) *big.Int {
+	var bID [sizeID]byte
+	binary.BigEndian.PutUint32(bID[:], id)
+	bC0 := c0.Bytes()
+	bR := R.Bytes()
+	return hashToScalar(tagDKGProof, bID[:], context, bC0[:], bR[:])
+}
+
+// NewNonce generates the nonce of the key share for a FROST signature. The
+// secret nonce derives from the secret share and 32 bytes read from rand, and
+// must be used for a single signature.
+func (ks *FROSTKeyShare) NewNonce(rand io.Reader) (*FROSTSecretNonce, *FROSTNonceCommitment, error) {
+	var seed [32]byte
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	secret := &frostSecretNonce{id: ks.ID}
+	commitment := &FROSTNonceCommitment{ID: ks.ID}
+	d := hashToScalar(tagFROSTNonce, seed[:], ks.secret[:], []byte{1})
+	e := hashToScalar(tagFROSTNonce, seed[:], ks.secret[:], []byte{2})
+	d.FillBytes(secret.d[:])
+	e.FillBytes(secret.e[:])
+	commitment.D.ScalarMultiplication(&curveParams.Base, d)
+	commitment.E.ScalarMultiplication(&curveParams.Base, e)
+
+	return &FROSTSecretNonce{secret}, commitment, nil
+}
+
+// NewFROSTSession returns the signing session of message under groupKey by the
+// signers whose nonce commitments are given. hFunc is the hash used by
+// PublicKey.Verify.
+//
+// ρᵢ = H(i, Y, B, m) where B encodes the commitments
+// R = ∑ Dᵢ + ρᵢ⋅Eᵢ
+// c = H(R, Y, m)
+func NewFROSTSession(groupKey *PublicKey, commitments []FROSTNonceCommitment, message []byte, hFunc hash.Hash) (*FROSTSession, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	if len(commitments) == 0 {
+		return nil, multisig.ErrNoSigner
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	s := &FROSTSession{
+		groupKey:    *groupKey,
+		commitments: append([]FROSTNonceCommitment{}, commitments...),
+		rho:         make([]big.Int, len(commitments)),
+		lambda:      make([]big.Int, len(commitments)),
+	}
+	sort.Slice(s.commitments, func(i, j int) bool { return s.commitments[i].ID < s.commitments[j].ID })
+	s.ids = make([]uint32, len(s.commitments))
+	for i := range s.commitments {
+		s.ids[i] = s.commitments[i].ID
+	}
+	if err := multisig.SortIDs(s.ids); err != nil {
+		return nil, err
+	}
+
+	encoded := make([]byte, 0, len(commitments)*(sizeID+2*sizeFr))
+	for i := range s.commitments {
+		if !s.commitments[i].D.IsOnCurve() || !s.commitments[i].E.IsOnCurve() {
+			return nil, errNotOnCurve
+		}
+		encoded = append(encoded, s.commitments[i].Bytes()...)
+	}
+
+	var bID [sizeID]byte
+	var tmp twistededwards.PointAffine
+	for i := range s.commitments {
+		binary.BigEndian.PutUint32(bID[:], s.commitments[i].ID)
+		s.rho[i].Set(hashToScalar(tagRho, bID[:], groupKey.Bytes(), encoded, message))
+
+		tmp.ScalarMultiplication(&s.commitments[i].E, &s.rho[i]).
+			Add(&tmp, &s.commitments[i].D)
+		if i == 0 {
+			s.R.Set(&tmp)
+		} else {
+			s.R.Add(&s.R, &tmp)
+		}
+
+		s.lambda[i].Set(multisig.LagrangeCoefficient(s.ids, i, &curveParams.Order))
+	}
+
+	c, err := hram(&s.R, &groupKey.A, message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	s.c.Mod(c, &curveParams.Order)
+
+	return s, nil
+}
+
+// Sign returns the signature share of ks with the secret nonce, which is
+// zeroed, even if an error is returned.
+//
+// zᵢ = dᵢ + eᵢ⋅ρᵢ + λᵢ⋅sᵢ⋅c mod l
+func (s *FROSTSession) Sign(ks *FROSTKeyShare, nonce *FROSTSecretNonce) (*FROSTSignatureShare, error) {
+	if nonce.secret == nil {
+		return nil, multisig.ErrNonceUsed
+	}
+	var d, e big.Int
+	d.SetBytes(nonce.secret.d[:])
+	e.SetBytes(nonce.secret.e[:])
+	id := nonce.secret.id
+	*nonce.secret = frostSecretNonce{}
+	if d.Sign() == 0 || e.Sign() == 0 {
+		return nil, multisig.ErrNonceUsed
+	}
+	if id != ks.ID {
+		return nil, multisig.ErrNonceMismatch
+	}
+	if !ks.GroupKey.A.Equal(&s.groupKey.A) {
+		return nil, multisig.ErrGroupKeyMismatch
+	}
+	if len(s.commitments) < int(ks.Threshold) {
+		return nil, multisig.ErrNotEnoughSigners
+	}
+	i, err := multisig.Index(s.ids, ks.ID)
+	if err != nil {
+		return nil, err
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	var D, E twistededwards.PointAffine
+	D.ScalarMultiplication(&curveParams.Base, &d)
+	E.ScalarMultiplication(&curveParams.Base, &e)
+	if !D.Equal(&s.commitments[i].D) || !E.Equal(&s.commitments[i].E) {
+		return nil, multisig.ErrCommitmentInvalid
+	}
+
+	var secret big.Int
+	secret.SetBytes(ks.secret[:])
+	z := multisig.Response(&d, &e, &s.rho[i], &s.c, &s.lambda[i], &secret, false, &curveParams.Order)
+
+	share := &FROSTSignatureShare{ID: ks.ID}
+	z.FillBytes(share.Z[:])
+	return share, nil
+}
+
+// VerifyShare checks the signature share of a signer, whose public
+// verification share is FROSTKeyShare.VerificationShares[share.ID-1]
+//
+// zᵢ⋅Base ?= Dᵢ + ρᵢ⋅Eᵢ + c⋅λᵢ⋅Yᵢ
+func (s *FROSTSession) VerifyShare(share *FROSTSignatureShare, verificationShare *twistededwards.PointAffine) bool {
+	i, err := multisig.Index(s.ids, share.ID)
+	if err != nil {
+		return false
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	var lhs, rhs, tmp twistededwards.PointAffine
+	var z, e big.Int
+	z.SetBytes(share.Z[:])
+	lhs.ScalarMultiplication(&curveParams.Base, &z)
+
+	e.Mul(&s.c, &s.lambda[i])
+	rhs.ScalarMultiplication(verificationShare, &e)
+	tmp.ScalarMultiplication(&s.commitments[i].E, &s.rho[i])
+	rhs.Add(&rhs, &tmp).
+		Add(&rhs, &s.commitments[i].D)
+
+	return lhs.Equal(&rhs)
+}
+
+// Aggregate returns the eddsa signature (R, ∑ zᵢ mod l) made of the signature
+// shares of all the signers of the session.
+func (s *FROSTSession) Aggregate(shares []FROSTSignatureShare) ([]byte, error) {
+	if len(shares) != len(s.commitments) {
+		return nil, multisig.ErrNbPackages
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+
+	ids := make([]uint32, len(shares))
+	zs := make([][]byte, len(shares))
+	for j := range shares {
+		ids[j] = shares[j].ID
+		zs[j] = shares[j].Z[:]
+	}
+	if err := multisig.SortIDs(ids); err != nil {
+		return nil, err
+	}
+	for j := range ids {
+		if ids[j] != s.ids[j] {
+			return nil, multisig.ErrNotInSession
+		}
+	}
+	sum := multisig.Sum(zs, &curveParams.Order)
+
+	var sig Signature
+	sig.R.Set(&s.R)
+	sum.FillBytes(sig.S[:])
+	return sig.Bytes(), nil
+}
+
+// randScalar returns a random scalar mod l, read from rand with 128 extra bits
+// to make the bias negligible
+func randScalar(rand io.Reader) (*big.Int, error) {
+	buf := make([]byte, sizeFr+16)
+	if _, err := io.ReadFull(rand, buf); err != nil {
+		return nil, err
+	}
+	curveParams := twistededwards.GetEdwardsCurve()
+	res := new(big.Int).SetBytes(buf)
+	return res.Mod(res, &curveParams.Order), nil
+}
+
+// Bytes returns the binary representation of the broadcast message, as
+// ID||len(Commitments)||Commitments||R||μ where ID and len(Commitments) are
+// big endian uint32 and the points are compressed.
+func (p *FROSTDKGRound1) Bytes() []byte {
+	res := make([]byte, 2*sizeID, 2*sizeID+(len(p.Commitments)+2)*sizeFr)
+	binary.BigEndian.PutUint32(res[:sizeID], p.ID)
+	binary.BigEndian.PutUint32(res[sizeID:], uint32(len(p.Commitments)))
+	for i := range p.Commitments {
+		b := p.Commitments[i].Bytes()
+		res = append(res, b[:]...)
+	}
+	bR := p.R.Bytes()
+	res = append(res, bR[:]...)
+	return append(res, p.Mu[:]...)
+}
+
+// SetBytes sets the broadcast message from buf, as encoded by Bytes.
+// It returns the number of bytes read from buf.
+func (p *FROSTDKGRound1) SetBytes(buf []byte) (int, error) {
+	if len(buf) < 2*sizeID {
+		return 0, io.ErrShortBuffer
+	}
+	id := binary.BigEndian.Uint32(buf[:sizeID])
+	nbCommitments := binary.BigEndian.Uint32(buf[sizeID : 2*sizeID])
+	size := 2*sizeID + (uint64(nbCommitments)+2)*sizeFr
+	if uint64(len(buf)) < size {
+		return 0, io.ErrShortBuffer
+	}
+	n := 2 * sizeID
+	commitments := make([]twistededwards.PointAffine, nbCommitments)
+	for i := range commitments {
+		if _, err := commitments[i].SetBytes(buf[n : n+sizeFr]); err != nil {
+			return 0, err
+		}
+		n += sizeFr
+	}
+	var R twistededwards.PointAffine
+	if _, err := R.SetBytes(buf[n : n+sizeFr]); err != nil {
+		return 0, err
+	}
+	n += sizeFr
+	if err := checkScalar(buf[n : n+sizeFr]); err != nil {
+		return 0, err
+	}
+	p.ID = id
+	p.Commitments = commitments
+	p.R = R
+	copy(p.Mu[:], buf[n:n+sizeFr])
+	return n + sizeFr, nil
+}
+
+// Bytes returns the binary representation of the secret share, as
+// From||To||Share where From and To are big endian uint32 and Share is a big
+// endian integer of size sizeFr.
+func (p *FROSTDKGRound2) Bytes() []byte {
+	var res [2*sizeID + sizeFr]byte
+	binary.BigEndian.PutUint32(res[:sizeID], p.From)
+	binary.BigEndian.PutUint32(res[sizeID:2*sizeID], p.To)
+	copy(res[2*sizeID:], p.Share[:])
+	return res[:]
+}
+
+// SetBytes sets the secret share from buf, as encoded by Bytes.
+// It returns the number of bytes read from buf.
+func (p *FROSTDKGRound2) SetBytes(buf []byte) (int, error) {
+	if len(buf) < 2*sizeID+sizeFr {
+		return 0, io.ErrShortBuffer
+	}
+	if err := checkScalar(buf[2*sizeID : 2*sizeID+sizeFr]); err != nil {
+		return 0, err
+	}
+	p.From = binary.BigEndian.Uint32(buf[:sizeID])
+	p.To = binary.BigEndian.Uint32(buf[sizeID : 2*sizeID])
+	copy(p.Share[:], buf[2*sizeID:2*sizeID+sizeFr])
+	return 2*sizeID + sizeFr, nil
+}
+
+// Bytes returns the binary representation of the nonce commitment, as ID||D||E
+// where ID is a big endian uint32 and D, E are compressed points.
+func (c *FROSTNonceCommitment) Bytes() []byte {
+	var res [sizeID + 2*sizeFr]byte
+	binary.BigEndian.PutUint32(res[:sizeID], c.ID)
+	d := c.D.Bytes()
+	e := c.E.Bytes()
+	copy(res[sizeID:sizeID+sizeFr], d[:])
+	copy(res[sizeID+sizeFr:], e[:])
+	return res[:]
+}
+
+// SetBytes sets the nonce commitment from buf, as encoded by Bytes.
+// It returns the number of bytes read from buf.
+func (c *FROSTNonceCommitment) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeID+2*sizeFr {
+		return 0, io.ErrShortBuffer
+	}
+	var D, E twistededwards.PointAffine
+	if _, err := D.SetBytes(buf[sizeID : sizeID+sizeFr]); err != nil {
+		return 0, err
+	}
+	if _, err := E.SetBytes(buf[sizeID+sizeFr : sizeID+2*sizeFr]); err != nil {
+		return 0, err
+	}
+	if !D.IsOnCurve() || !E.IsOnCurve() {
+		return 0, errNotOnCurve
+	}
+	c.ID = binary.BigEndian.Uint32(buf[:sizeID])
+	c.D = D
+	c.E = E
+	return sizeID + 2*sizeFr, nil
+}
+
+// Bytes returns the binary representation of the signature share, as ID||Z
+// where ID is a big endian uint32 and Z is a big endian integer of size sizeFr.
+func (share *FROSTSignatureShare) Bytes() []byte {
+	var res [sizeID + sizeFr]byte
+	binary.BigEndian.PutUint32(res[:sizeID], share.ID)
+	copy(res[sizeID:], share.Z[:])
+	return res[:]
+}
+
+// SetBytes sets the signature share from buf, as encoded by Bytes.
+// It returns the number of bytes read from buf.
+func (share *FROSTSignatureShare) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeID+sizeFr {
+		return 0, io.ErrShortBuffer
+	}
+	if err := checkScalar(buf[sizeID : sizeID+sizeFr]); err != nil {
+		return 0, err
+	}
+	share.ID = binary.BigEndian.Uint32(buf[:sizeID])
+	copy(share.Z[:], buf[sizeID:sizeID+sizeFr])
+	return sizeID + sizeFr, nil
+}
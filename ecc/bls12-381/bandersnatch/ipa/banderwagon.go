@@ -0,0 +1,102 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipa
+
+import (
+	"errors"
+
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrNotOnCurve     = errors.New("x is not the coordinate of a point on the curve")
+	ErrNotInSubGroup  = errors.New("point is not a Banderwagon element")
+	ErrNonCanonicalFp = errors.New("encoded coordinate is not canonical")
+)
+
+// SizePointCompressed size in bytes of a serialized Banderwagon element
+const SizePointCompressed = fp.Bytes
+
+// Equal returns true if p and q represent the same Banderwagon element, that
+// is if x_p⋅y_q = x_q⋅y_p.
+func Equal(p, q *bandersnatch.PointAffine) bool {
+	var l, r fp.Element
+	l.Mul(&p.X, &q.Y)
+	r.Mul(&q.X, &p.Y)
+	return l.Equal(&r)
+}
+
+// Bytes returns the serialization of the Banderwagon element p, that is the
+// big-endian encoding of x⋅sign(y), where sign(y) = 1 if y is lexicographically
+// largest and -1 otherwise.
+func Bytes(p *bandersnatch.PointAffine) [SizePointCompressed]byte {
+	x := p.X
+	if !p.Y.LexicographicallyLargest() {
+		x.Neg(&x)
+	}
+	return x.Bytes()
+}
+
+// SetBytes sets p from the serialization of a Banderwagon element. It checks
+// that the encoded x coordinate is canonical and that the point lies in the
+// subgroup of order 2r. The y coordinate is chosen lexicographically largest.
+func SetBytes(p *bandersnatch.PointAffine, buf []byte) error {
+	var x fp.Element
+	if len(buf) != SizePointCompressed {
+		return ErrNonCanonicalFp
+	}
+	if err := x.SetBytesCanonical(buf); err != nil {
+		return ErrNonCanonicalFp
+	}
+	params := bandersnatch.GetEdwardsCurve()
+
+	// y² = (1-ax²)/(1-dx²)
+	var xx, num, den, y fp.Element
+	xx.Square(&x)
+	num.Mul(&xx, &params.A)
+	den.Mul(&xx, &params.D)
+	num.Sub(new(fp.Element).SetOne(), &num)
+	den.Sub(new(fp.Element).SetOne(), &den)
+
+	// (x, y) has order dividing 2r iff 1-ax² is a square
+	if num.Legendre() != 1 {
+		return ErrNotInSubGroup
+	}
+	y.Div(&num, &den)
+	if y.Sqrt(&y) == nil {
+		return ErrNotOnCurve
+	}
+	if !y.LexicographicallyLargest() {
+		y.Neg(&y)
+	}
+	p.X = x
+	p.Y = y
+	return nil
+}
+
+// MapToScalarField maps the Banderwagon element p to a scalar, by reducing the
+// integer x/y modulo the order of the group. This is well defined since
+// (x, y) and (-x, -y) are mapped to the same value.
+func MapToScalarField(p *bandersnatch.PointAffine) fr.Element {
+	var b fp.Element
+	b.Div(&p.X, &p.Y)
+	bBytes := b.Bytes()
+	var res fr.Element
+	res.SetBytes(bBytes[:])
+	return res
+}
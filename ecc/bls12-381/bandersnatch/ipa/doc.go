@@ -0,0 +1,37 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ipa provides a Pedersen vector commitment over Bandersnatch with an
+// inner product argument (IPA) opening proof, following the conventions of the
+// Ethereum Verkle trees.
+//
+// Vectors are committed in evaluation form over the domain {0, 1, ..., 255}.
+// Points are elements of the Banderwagon group, the quotient of the Bandersnatch
+// points of order dividing 2r by the 2-torsion point (0, -1): (x, y) and (-x, -y)
+// represent the same element. Scalars live in the scalar field of Bandersnatch
+// (see package bandersnatch/fr).
+//
+// The package provides:
+//   - Commit: Pedersen commitment to a vector of DomainSize scalars
+//   - CreateProof / CheckProof: IPA proof of the evaluation of the committed
+//     polynomial at any point
+//   - CreateMultiProof / CheckMultiProof: aggregation of many openings, of possibly
+//     different commitments, in a single IPA proof
+//   - Verkle tree conventions: MapToScalarField, stem and internal node
+//     commitments and tree key derivation
+//
+// See https://dankradfeist.de/ethereum/2021/07/27/inner-product-arguments.html,
+// https://dankradfeist.de/ethereum/2021/06/18/pcs-multiproofs.html and
+// https://github.com/crate-crypto/verkle-trie-ref.
+package ipa
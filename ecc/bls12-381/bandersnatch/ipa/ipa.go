@@ -0,0 +1,308 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrInvalidVectorSize = errors.New("invalid vector size (larger than the domain or == 0)")
+	ErrInvalidProofSize  = errors.New("invalid number of rounds in the proof")
+	ErrVerifyIPA         = errors.New("can't verify inner product argument")
+)
+
+const (
+	// DomainSize number of elements of a committed vector
+	DomainSize = 256
+
+	// generatorsSeed seed of the derivation of the commitment key
+	generatorsSeed = "eth_verkle_oct_2021"
+)
+
+// nbRounds number of halving rounds of the IPA
+var nbRounds = bits.TrailingZeros(DomainSize)
+
+// Config holds the commitment key and the precomputed values of the domain
+// {0, 1, ..., DomainSize-1} used to evaluate polynomials in evaluation form.
+type Config struct {
+	// SRS generators of the Pedersen commitment
+	SRS []bandersnatch.PointAffine
+	// Q point used to bind the inner product in the IPA
+	Q bandersnatch.PointAffine
+
+	// aPrime[i] = A'(i) = ∏_{j≠i}(i-j), where A = ∏ⱼ(X-j)
+	aPrime, aPrimeInv [DomainSize]fr.Element
+	// invDomain[i] = 1/i, for 0 < i < DomainSize
+	invDomain [DomainSize]fr.Element
+}
+
+// NewConfig returns the Verkle configuration: DomainSize generators derived
+// from the seed "eth_verkle_oct_2021", and Q the generator of Bandersnatch.
+func NewConfig() *Config {
+	c := &Config{
+		SRS: GenerateRandomPoints(DomainSize),
+		Q:   bandersnatch.GetEdwardsCurve().Base,
+	}
+
+	var tmp fr.Element
+	for i := 0; i < DomainSize; i++ {
+		c.aPrime[i].SetOne()
+		for j := 0; j < DomainSize; j++ {
+			if j != i {
+				tmp.SetInt64(int64(i - j))
+				c.aPrime[i].Mul(&c.aPrime[i], &tmp)
+			}
+		}
+		c.invDomain[i].SetUint64(uint64(i))
+	}
+	aPrimeInv := fr.BatchInvert(c.aPrime[:])
+	copy(c.aPrimeInv[:], aPrimeInv)
+	invDomain := fr.BatchInvert(c.invDomain[:])
+	copy(c.invDomain[:], invDomain)
+
+	return c
+}
+
+// GenerateRandomPoints derives n Banderwagon elements with unknown discrete
+// logarithms. The i-th candidate x coordinate is SHA256(seed || i), i encoded
+// in big-endian on 8 bytes, and is kept if it is the serialization of a
+// Banderwagon element.
+func GenerateRandomPoints(n int) []bandersnatch.PointAffine {
+	res := make([]bandersnatch.PointAffine, 0, n)
+	var b [8]byte
+	for i := uint64(0); len(res) < n; i++ {
+		h := sha256.New()
+		h.Write([]byte(generatorsSeed))
+		binary.BigEndian.PutUint64(b[:], i)
+		h.Write(b[:])
+
+		var x fp.Element
+		x.SetBytes(h.Sum(nil))
+		xBytes := x.Bytes()
+
+		var p bandersnatch.PointAffine
+		if err := SetBytes(&p, xBytes[:]); err != nil {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+// Commit returns the Pedersen commitment ∑ᵢvᵢGᵢ to a vector in evaluation form.
+// len(values) must be at most DomainSize.
+func (c *Config) Commit(values []fr.Element) (bandersnatch.PointAffine, error) {
+	if len(values) == 0 || len(values) > DomainSize {
+		return bandersnatch.PointAffine{}, ErrInvalidVectorSize
+	}
+	return multiExp(c.SRS[:len(values)], values), nil
+}
+
+// BarycentricCoefficients returns the vector b such that f(z) = <f, b> for any
+// polynomial f of degree < DomainSize given in evaluation form.
+func (c *Config) BarycentricCoefficients(z fr.Element) []fr.Element {
+	res := make([]fr.Element, DomainSize)
+
+	// z is in the domain: f(z) is one of the evaluations
+	if z.IsUint64() && z.Uint64() < DomainSize {
+		res[z.Uint64()].SetOne()
+		return res
+	}
+
+	// bᵢ = A(z) / (A'(i)(z-i))
+	var az, tmp fr.Element
+	az.SetOne()
+	for i := range res {
+		tmp.SetUint64(uint64(i))
+		res[i].Sub(&z, &tmp)
+		az.Mul(&az, &res[i])
+	}
+	res = fr.BatchInvert(res)
+	for i := range res {
+		res[i].Mul(&res[i], &c.aPrimeInv[i]).Mul(&res[i], &az)
+	}
+	return res
+}
+
+// Proof is an IPA proof that a committed vector a satisfies <a, b> = y, where
+// b is a public vector (the barycentric coefficients of the evaluation point).
+type Proof struct {
+	L, R []bandersnatch.PointAffine
+	A    fr.Element
+}
+
+// CreateProof returns a proof that the polynomial in evaluation form values,
+// committed in commitment, evaluates to the returned y at z.
+func CreateProof(transcript *Transcript, c *Config, commitment bandersnatch.PointAffine, values []fr.Element, z fr.Element) (Proof, fr.Element, error) {
+	if len(values) == 0 || len(values) > DomainSize {
+		return Proof{}, fr.Element{}, ErrInvalidVectorSize
+	}
+
+	a := make([]fr.Element, DomainSize)
+	copy(a, values)
+	b := c.BarycentricCoefficients(z)
+	g := make([]bandersnatch.PointAffine, DomainSize)
+	copy(g, c.SRS)
+	y := innerProduct(a, b)
+
+	transcript.DomainSep("ipa")
+	transcript.AppendPoint(&commitment, "C")
+	transcript.AppendScalar(&z, "input point")
+	transcript.AppendScalar(&y, "output point")
+	w := transcript.ChallengeScalar("w")
+
+	// Q' = [w]Q
+	var q bandersnatch.PointAffine
+	q.ScalarMultiplication(&c.Q, w.BigInt(new(big.Int)))
+
+	proof := Proof{
+		L: make([]bandersnatch.PointAffine, nbRounds),
+		R: make([]bandersnatch.PointAffine, nbRounds),
+	}
+
+	for r := 0; r < nbRounds; r++ {
+		m := len(a) / 2
+		aL, aR := a[:m], a[m:]
+		bL, bR := b[:m], b[m:]
+		gL, gR := g[:m], g[m:]
+
+		// L = <a_R, G_L> + <a_R, b_L>Q', R = <a_L, G_R> + <a_L, b_R>Q'
+		zL := innerProduct(aR, bL)
+		zR := innerProduct(aL, bR)
+		proof.L[r] = multiExp(append(append([]bandersnatch.PointAffine{}, gL...), q), append(append([]fr.Element{}, aR...), zL))
+		proof.R[r] = multiExp(append(append([]bandersnatch.PointAffine{}, gR...), q), append(append([]fr.Element{}, aL...), zR))
+
+		transcript.AppendPoint(&proof.L[r], "L")
+		transcript.AppendPoint(&proof.R[r], "R")
+		x := transcript.ChallengeScalar("x")
+		var xInv fr.Element
+		xInv.Inverse(&x)
+
+		// a ← a_L + x⋅a_R, b ← b_L + x⁻¹⋅b_R, G ← G_L + x⁻¹⋅G_R
+		foldScalars(aL, aR, x)
+		foldScalars(bL, bR, xInv)
+		foldPoints(gL, gR, xInv)
+		a, b, g = aL, bL, gL
+	}
+	proof.A = a[0]
+
+	return proof, y, nil
+}
+
+// CheckProof verifies that the polynomial committed in commitment evaluates
+// to y at z.
+func CheckProof(transcript *Transcript, c *Config, commitment bandersnatch.PointAffine, proof *Proof, z, y fr.Element) error {
+	if len(proof.L) != nbRounds || len(proof.R) != nbRounds {
+		return ErrInvalidProofSize
+	}
+
+	transcript.DomainSep("ipa")
+	transcript.AppendPoint(&commitment, "C")
+	transcript.AppendScalar(&z, "input point")
+	transcript.AppendScalar(&y, "output point")
+	w := transcript.ChallengeScalar("w")
+
+	var q bandersnatch.PointAffine
+	q.ScalarMultiplication(&c.Q, w.BigInt(new(big.Int)))
+
+	// challenges of the rounds
+	xs := make([]fr.Element, nbRounds)
+	for r := 0; r < nbRounds; r++ {
+		transcript.AppendPoint(&proof.L[r], "L")
+		transcript.AppendPoint(&proof.R[r], "R")
+		xs[r] = transcript.ChallengeScalar("x")
+	}
+	xInvs := fr.BatchInvert(xs)
+
+	// C' = C + yQ' + ∑ᵣ(xᵣLᵣ + xᵣ⁻¹Rᵣ)
+	points := make([]bandersnatch.PointAffine, 0, 2*nbRounds+2)
+	scalars := make([]fr.Element, 0, 2*nbRounds+2)
+	points = append(points, commitment, q)
+	scalars = append(scalars, fr.One(), y)
+	points = append(points, proof.L...)
+	scalars = append(scalars, xs...)
+	points = append(points, proof.R...)
+	scalars = append(scalars, xInvs...)
+	lhs := multiExp(points, scalars)
+
+	// sᵢ = ∏ᵣ xᵣ⁻¹ if the bit of i folded at round r is set, so that the folded
+	// generator is G₀ = <s, G> and the folded b is b₀ = <s, b>
+	s := make([]fr.Element, DomainSize)
+	s[0].SetOne()
+	for k := 0; k < nbRounds; k++ {
+		// bit k of the index is folded at round nbRounds-1-k
+		for i := 1 << k; i < 1<<(k+1); i++ {
+			s[i].Mul(&s[i-1<<k], &xInvs[nbRounds-1-k])
+		}
+	}
+	b := c.BarycentricCoefficients(z)
+	b0 := innerProduct(s, b)
+
+	// C' == a⋅G₀ + a⋅b₀⋅Q'
+	var ab0 fr.Element
+	ab0.Mul(&proof.A, &b0)
+	for i := range s {
+		s[i].Mul(&s[i], &proof.A)
+	}
+	rhs := multiExp(append(append([]bandersnatch.PointAffine{}, c.SRS...), q), append(s, ab0))
+
+	if !Equal(&lhs, &rhs) {
+		return ErrVerifyIPA
+	}
+	return nil
+}
+
+// innerProduct returns <a, b>
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, tmp fr.Element
+	for i := range a {
+		tmp.Mul(&a[i], &b[i])
+		res.Add(&res, &tmp)
+	}
+	return res
+}
+
+// foldScalars sets l ← l + x⋅r
+func foldScalars(l, r []fr.Element, x fr.Element) {
+	var tmp fr.Element
+	for i := range l {
+		tmp.Mul(&r[i], &x)
+		l[i].Add(&l[i], &tmp)
+	}
+}
+
+// foldPoints sets l ← l + x⋅r
+func foldPoints(l, r []bandersnatch.PointAffine, x fr.Element) {
+	var bx big.Int
+	x.BigInt(&bx)
+	parallel.Execute(len(l), func(start, end int) {
+		var tmp bandersnatch.PointAffine
+		for i := start; i < end; i++ {
+			tmp.ScalarMultiplication(&r[i], &bx)
+			l[i].Add(&l[i], &tmp)
+		}
+	})
+}
@@ -0,0 +1,279 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = NewConfig()
+
+func randomVector(size int) []fr.Element {
+	res := make([]fr.Element, size)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestBanderwagon(t *testing.T) {
+	assert := require.New(t)
+
+	assert.Equal(DomainSize, len(testConfig.SRS))
+	for i := range testConfig.SRS {
+		p := &testConfig.SRS[i]
+		assert.True(p.IsOnCurve())
+
+		// serialization round trip
+		b := Bytes(p)
+		var q bandersnatch.PointAffine
+		assert.NoError(SetBytes(&q, b[:]))
+		assert.True(Equal(p, &q))
+
+		// (x, y) and (-x, -y) are the same element
+		q.X.Neg(&p.X)
+		q.Y.Neg(&p.Y)
+		assert.True(Equal(p, &q))
+		assert.Equal(Bytes(p), Bytes(&q))
+		assert.Equal(MapToScalarField(p), MapToScalarField(&q))
+	}
+	assert.False(Equal(&testConfig.SRS[0], &testConfig.SRS[1]))
+
+	// the identity maps to 0
+	var identity bandersnatch.PointAffine
+	identity.Y.SetOne()
+	v := MapToScalarField(&identity)
+	assert.True(v.IsZero())
+}
+
+func TestBarycentricCoefficients(t *testing.T) {
+	assert := require.New(t)
+
+	// f(X) = X² in evaluation form
+	f := make([]fr.Element, DomainSize)
+	for i := range f {
+		f[i].SetUint64(uint64(i * i))
+	}
+	for _, z := range []uint64{3, 1000, 1 << 40} {
+		var zz, expected fr.Element
+		zz.SetUint64(z)
+		expected.Square(&zz)
+		y := innerProduct(f, testConfig.BarycentricCoefficients(zz))
+		assert.True(y.Equal(&expected))
+	}
+}
+
+func TestIPA(t *testing.T) {
+	assert := require.New(t)
+
+	values := randomVector(DomainSize)
+	commitment, err := testConfig.Commit(values)
+	assert.NoError(err)
+
+	var z fr.Element
+	z.SetRandom()
+	proof, y, err := CreateProof(NewTranscript("test"), testConfig, commitment, values, z)
+	assert.NoError(err)
+	assert.True(y.Equal(ptr(innerProduct(values, testConfig.BarycentricCoefficients(z)))))
+	assert.NoError(CheckProof(NewTranscript("test"), testConfig, commitment, &proof, z, y))
+
+	// wrong evaluation, point or transcript
+	var wrong fr.Element
+	wrong.SetOne().Add(&wrong, &y)
+	assert.ErrorIs(CheckProof(NewTranscript("test"), testConfig, commitment, &proof, z, wrong), ErrVerifyIPA)
+	assert.ErrorIs(CheckProof(NewTranscript("test"), testConfig, commitment, &proof, wrong, y), ErrVerifyIPA)
+	assert.ErrorIs(CheckProof(NewTranscript("other"), testConfig, commitment, &proof, z, y), ErrVerifyIPA)
+
+	// evaluation in the domain
+	z.SetUint64(42)
+	proof, y, err = CreateProof(NewTranscript("test"), testConfig, commitment, values, z)
+	assert.NoError(err)
+	assert.True(y.Equal(&values[42]))
+	assert.NoError(CheckProof(NewTranscript("test"), testConfig, commitment, &proof, z, y))
+
+	proof.L = proof.L[1:]
+	assert.ErrorIs(CheckProof(NewTranscript("test"), testConfig, commitment, &proof, z, y), ErrInvalidProofSize)
+}
+
+func TestMultiProof(t *testing.T) {
+	assert := require.New(t)
+
+	const nbQueries = 5
+	commitments := make([]bandersnatch.PointAffine, nbQueries)
+	values := make([][]fr.Element, nbQueries)
+	ys := make([]fr.Element, nbQueries)
+	zs := []uint8{0, 255, 17, 17, 3}
+	for i := range values {
+		values[i] = randomVector(DomainSize)
+		if i == 3 {
+			// same commitment opened twice
+			values[i] = values[i-1]
+		}
+		var err error
+		commitments[i], err = testConfig.Commit(values[i])
+		assert.NoError(err)
+		ys[i] = values[i][zs[i]]
+	}
+
+	proof, err := CreateMultiProof(NewTranscript("multiproof"), testConfig, commitments, values, zs)
+	assert.NoError(err)
+	assert.NoError(CheckMultiProof(NewTranscript("multiproof"), testConfig, proof, commitments, ys, zs))
+
+	ys[4].SetRandom()
+	assert.ErrorIs(CheckMultiProof(NewTranscript("multiproof"), testConfig, proof, commitments, ys, zs), ErrVerifyIPA)
+
+	_, err = CreateMultiProof(NewTranscript("multiproof"), testConfig, commitments, values, zs[1:])
+	assert.ErrorIs(err, ErrInvalidNbQueries)
+}
+
+func TestDivideOnDomain(t *testing.T) {
+	assert := require.New(t)
+
+	f := randomVector(DomainSize)
+	var x fr.Element
+	x.SetRandom()
+	fx := innerProduct(f, testConfig.BarycentricCoefficients(x))
+	for _, z := range []uint8{0, 1, 128, 255} {
+		// q(x)(x-z) == f(x) - f(z)
+		q := testConfig.divideOnDomain(f, z)
+		qx := innerProduct(q, testConfig.BarycentricCoefficients(x))
+		var lhs, rhs, zz fr.Element
+		zz.SetUint64(uint64(z))
+		lhs.Sub(&x, &zz).Mul(&lhs, &qx)
+		rhs.Sub(&fx, &f[z])
+		assert.True(lhs.Equal(&rhs))
+	}
+}
+
+func TestVerkleCommitments(t *testing.T) {
+	assert := require.New(t)
+
+	stem := make([]byte, StemSize)
+	stem[0] = 1
+	values := make([][]byte, DomainSize)
+	values[0] = make([]byte, LeafValueSize)
+	values[200] = make([]byte, LeafValueSize)
+	values[200][31] = 0xff
+
+	commitment, c1, c2, err := StemCommitment(testConfig, stem, values)
+	assert.NoError(err)
+
+	// an absent value differs from a zero value
+	values[0] = nil
+	other, _, c2Other, err := StemCommitment(testConfig, stem, values)
+	assert.NoError(err)
+	assert.False(Equal(&commitment, &other))
+	assert.True(Equal(&c2, &c2Other))
+	assert.False(Equal(&c1, &testConfig.SRS[0]))
+
+	root, err := InternalNodeCommitment(testConfig, []bandersnatch.PointAffine{commitment, other})
+	assert.NoError(err)
+	assert.True(root.IsOnCurve())
+
+	var address [32]byte
+	address[31] = 0xaa
+	k1, err := GetTreeKey(testConfig, address[:], big.NewInt(0), 0)
+	assert.NoError(err)
+	k2, err := GetTreeKey(testConfig, address[:], big.NewInt(0), 64)
+	assert.NoError(err)
+	k3, err := GetTreeKey(testConfig, address[:], big.NewInt(1), 0)
+	assert.NoError(err)
+	assert.Equal(k1[:StemSize], k2[:StemSize])
+	assert.Equal(byte(64), k2[StemSize])
+	assert.NotEqual(k1[:StemSize], k3[:StemSize])
+
+	_, _, _, err = StemCommitment(testConfig, stem[1:], values)
+	assert.ErrorIs(err, ErrInvalidStemSize)
+}
+
+// TestInteroperability checks the CRS, commitments and tree keys against
+// go-ipa and go-ethereum
+func TestInteroperability(t *testing.T) {
+	assert := require.New(t)
+
+	hexBytes := func(p *bandersnatch.PointAffine) string {
+		b := Bytes(p)
+		return hex.EncodeToString(b[:])
+	}
+
+	// CRS: first and last generators, and digest of all of them
+	assert.Equal("01587ad1336675eb912550ec2a28eb8923b824b490dd2ba82e48f14590a298a0", hexBytes(&testConfig.SRS[0]))
+	assert.Equal("3de2be346b539395b0c0de56a5ccca54a317f1b5c80107b0802af9a62276a4d8", hexBytes(&testConfig.SRS[DomainSize-1]))
+	h := sha256.New()
+	for i := range testConfig.SRS {
+		b := Bytes(&testConfig.SRS[i])
+		h.Write(b[:])
+	}
+	assert.Equal("1fcaea10bf24f750200e06fa473c76ff0468007291fa548e2d99f09ba9256fdb", hex.EncodeToString(h.Sum(nil)))
+
+	// commitments to 0..255 and to 1..32 repeated 8 times
+	values := make([]fr.Element, DomainSize)
+	for i := range values {
+		values[i].SetUint64(uint64(i))
+	}
+	commitment, err := testConfig.Commit(values)
+	assert.NoError(err)
+	assert.Equal("409c46ef4c6b44198ec5d4eaf2e2c590dfd7c2dca42d5065fd71a6db6a49d01e", hexBytes(&commitment))
+	for i := range values {
+		values[i].SetUint64(uint64(i%32 + 1))
+	}
+	commitment, err = testConfig.Commit(values)
+	assert.NoError(err)
+	assert.Equal("1b9dff8f5ebbac250d291dfe90e36283a227c64b113c37f1bfb9e7a743cdb128", hexBytes(&commitment))
+
+	// tree keys
+	address := make([]byte, 32)
+	for i := range address {
+		address[i] = byte(i)
+	}
+	treeIndex, _ := new(big.Int).SetString("0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20", 16)
+	for _, v := range []struct {
+		treeIndex *big.Int
+		subIndex  byte
+		key       string
+	}{
+		{big.NewInt(0), 0, "dcabd6637769919e347b82b931e7710c5019865a208705f543f73e45bfb4b300"},
+		{big.NewInt(1), 3, "fdb6df76aa5b7e1ce81039ea343f8df933d98e532ae1e4ff86d4eaf674a41d03"},
+		{treeIndex, 255, "cf3749624b87e0c63a9803b3a2c5cd766b95fbc557e07f9d28c2747d2126ffff"},
+	} {
+		key, err := GetTreeKey(testConfig, address, v.treeIndex, v.subIndex)
+		assert.NoError(err)
+		assert.Equal(v.key, hex.EncodeToString(key[:]))
+	}
+}
+
+func BenchmarkCheckProof(b *testing.B) {
+	values := randomVector(DomainSize)
+	commitment, _ := testConfig.Commit(values)
+	var z fr.Element
+	z.SetRandom()
+	proof, y, _ := CreateProof(NewTranscript("bench"), testConfig, commitment, values, z)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = CheckProof(NewTranscript("bench"), testConfig, commitment, &proof, z, y)
+	}
+}
+
+func ptr(e fr.Element) *fr.Element {
+	return &e
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipa
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// multiExp returns ∑ᵢsᵢPᵢ
+func multiExp(points []bandersnatch.PointAffine, scalars []fr.Element) bandersnatch.PointAffine {
+	s := make([]big.Int, len(scalars))
+	for i := range scalars {
+		scalars[i].BigInt(&s[i])
+	}
+
+	var res bandersnatch.PointAffine
+	if _, err := res.MultiExp(points, s, ecc.MultiExpConfig{}); err != nil {
+		// the callers always provide as many points as scalars
+		panic(err)
+	}
+	return res
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipa
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrInvalidNbQueries = errors.New("number of commitments, evaluations and points don't match")
+	ErrZeroNbQueries    = errors.New("number of queries is zero")
+)
+
+// MultiProof proves the openings of several committed vectors, each at one
+// point of the domain, with a single IPA.
+//
+// D is the commitment to g(X) = ∑ᵢrⁱ(fᵢ(X)-yᵢ)/(X-zᵢ), and IPA proves the
+// evaluation of h(X)-g(X) at a random point t, where h(X) = ∑ᵢrⁱfᵢ(X)/(t-zᵢ).
+type MultiProof struct {
+	IPA Proof
+	D   bandersnatch.PointAffine
+}
+
+// CreateMultiProof returns a proof that the vector values[i], committed in
+// commitments[i], has value values[i][zs[i]] at index zs[i].
+func CreateMultiProof(transcript *Transcript, c *Config, commitments []bandersnatch.PointAffine, values [][]fr.Element, zs []uint8) (*MultiProof, error) {
+	if len(commitments) != len(values) || len(commitments) != len(zs) {
+		return nil, ErrInvalidNbQueries
+	}
+	if len(commitments) == 0 {
+		return nil, ErrZeroNbQueries
+	}
+	for i := range values {
+		if len(values[i]) != DomainSize {
+			return nil, ErrInvalidVectorSize
+		}
+	}
+
+	transcript.DomainSep("multiproof")
+	for i := range commitments {
+		var z fr.Element
+		z.SetUint64(uint64(zs[i]))
+		transcript.AppendPoint(&commitments[i], "C")
+		transcript.AppendScalar(&z, "z")
+		transcript.AppendScalar(&values[i][zs[i]], "y")
+	}
+	r := transcript.ChallengeScalar("r")
+	powers := powersOf(r, len(commitments))
+
+	// g = ∑ᵢrⁱqᵢ where qᵢ = (fᵢ-yᵢ)/(X-zᵢ)
+	g := make([]fr.Element, DomainSize)
+	for i := range values {
+		q := c.divideOnDomain(values[i], zs[i])
+		foldScalars(g, q, powers[i])
+	}
+	d, err := c.Commit(g)
+	if err != nil {
+		return nil, err
+	}
+	transcript.AppendPoint(&d, "D")
+	t := transcript.ChallengeScalar("t")
+
+	// h = ∑ᵢrⁱfᵢ/(t-zᵢ)
+	coeffs := hCoefficients(powers, zs, t)
+	h := make([]fr.Element, DomainSize)
+	for i := range values {
+		foldScalars(h, values[i], coeffs[i])
+	}
+	e, err := c.Commit(h)
+	if err != nil {
+		return nil, err
+	}
+	transcript.AppendPoint(&e, "E")
+
+	// prove the evaluation of h-g at t
+	for i := range h {
+		h[i].Sub(&h[i], &g[i])
+	}
+	var eMinusD bandersnatch.PointAffine
+	eMinusD.Neg(&d).Add(&eMinusD, &e)
+	proof, _, err := CreateProof(transcript, c, eMinusD, h, t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiProof{IPA: proof, D: d}, nil
+}
+
+// CheckMultiProof verifies that the vector committed in commitments[i] has
+// value ys[i] at index zs[i], for all i.
+func CheckMultiProof(transcript *Transcript, c *Config, proof *MultiProof, commitments []bandersnatch.PointAffine, ys []fr.Element, zs []uint8) error {
+	if len(commitments) != len(ys) || len(commitments) != len(zs) {
+		return ErrInvalidNbQueries
+	}
+	if len(commitments) == 0 {
+		return ErrZeroNbQueries
+	}
+
+	transcript.DomainSep("multiproof")
+	for i := range commitments {
+		var z fr.Element
+		z.SetUint64(uint64(zs[i]))
+		transcript.AppendPoint(&commitments[i], "C")
+		transcript.AppendScalar(&z, "z")
+		transcript.AppendScalar(&ys[i], "y")
+	}
+	r := transcript.ChallengeScalar("r")
+	powers := powersOf(r, len(commitments))
+
+	transcript.AppendPoint(&proof.D, "D")
+	t := transcript.ChallengeScalar("t")
+
+	// E = ∑ᵢrⁱCᵢ/(t-zᵢ) and (h-g)(t) = ∑ᵢrⁱyᵢ/(t-zᵢ)
+	coeffs := hCoefficients(powers, zs, t)
+	e := multiExp(commitments, coeffs)
+	transcript.AppendPoint(&e, "E")
+	y := innerProduct(coeffs, ys)
+
+	var eMinusD bandersnatch.PointAffine
+	eMinusD.Neg(&proof.D).Add(&eMinusD, &e)
+	return CheckProof(transcript, c, eMinusD, &proof.IPA, t, y)
+}
+
+// divideOnDomain returns (f-f(z))/(X-z) in evaluation form, for z in the domain.
+func (c *Config) divideOnDomain(f []fr.Element, z uint8) []fr.Element {
+	res := make([]fr.Element, DomainSize)
+	m := int(z)
+	var tmp fr.Element
+	for j := range f {
+		if j == m {
+			continue
+		}
+		// qⱼ = (fⱼ-f(z))/(j-z)
+		res[j].Sub(&f[j], &f[m])
+		if j > m {
+			res[j].Mul(&res[j], &c.invDomain[j-m])
+		} else {
+			res[j].Mul(&res[j], &c.invDomain[m-j]).Neg(&res[j])
+		}
+		// q(z) = -∑_{j≠z} qⱼA'(z)/A'(j)
+		tmp.Mul(&res[j], &c.aPrimeInv[j])
+		res[m].Sub(&res[m], &tmp)
+	}
+	res[m].Mul(&res[m], &c.aPrime[m])
+	return res
+}
+
+// hCoefficients returns the list of rⁱ/(t-zᵢ)
+func hCoefficients(powers []fr.Element, zs []uint8, t fr.Element) []fr.Element {
+	res := make([]fr.Element, len(zs))
+	var z fr.Element
+	for i := range zs {
+		z.SetUint64(uint64(zs[i]))
+		res[i].Sub(&t, &z)
+	}
+	res = fr.BatchInvert(res)
+	for i := range res {
+		res[i].Mul(&res[i], &powers[i])
+	}
+	return res
+}
+
+// powersOf returns [1, r, ..., rⁿ⁻¹]
+func powersOf(r fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &r)
+	}
+	return res
+}
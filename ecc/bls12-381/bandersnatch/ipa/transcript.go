@@ -0,0 +1,95 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// Transcript is the Fiat-Shamir transcript used by the Verkle proofs.
+//
+// Unlike fiatshamir.Transcript, challenges are not declared beforehand: the
+// labels and the messages are absorbed in the order of the protocol, and each
+// challenge is derived from the whole history of the transcript.
+type Transcript struct {
+	state hash.Hash
+}
+
+// NewTranscript returns a new transcript, separated by the given label.
+func NewTranscript(label string) *Transcript {
+	t := &Transcript{state: sha256.New()}
+	t.DomainSep(label)
+	return t
+}
+
+// DomainSep adds a domain separator to the transcript.
+func (t *Transcript) DomainSep(label string) {
+	t.state.Write([]byte(label))
+}
+
+func (t *Transcript) appendMessage(message []byte, label string) {
+	t.state.Write([]byte(label))
+	t.state.Write(message)
+}
+
+// AppendScalar adds the little-endian encoding of s to the transcript.
+func (t *Transcript) AppendScalar(s *fr.Element, label string) {
+	var b [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&b, *s)
+	t.appendMessage(b[:], label)
+}
+
+// AppendScalars adds a list of scalars to the transcript, with the same label.
+func (t *Transcript) AppendScalars(scalars []fr.Element, label string) {
+	for i := range scalars {
+		t.AppendScalar(&scalars[i], label)
+	}
+}
+
+// AppendPoint adds the serialization of the Banderwagon element p to the
+// transcript.
+func (t *Transcript) AppendPoint(p *bandersnatch.PointAffine, label string) {
+	b := Bytes(p)
+	t.appendMessage(b[:], label)
+}
+
+// AppendPoints adds a list of points to the transcript, with the same label.
+func (t *Transcript) AppendPoints(points []bandersnatch.PointAffine, label string) {
+	for i := range points {
+		t.AppendPoint(&points[i], label)
+	}
+}
+
+// ChallengeScalar derives a challenge from the transcript. The state is then
+// reset and re-seeded with the challenge, which summarizes the history.
+func (t *Transcript) ChallengeScalar(label string) fr.Element {
+	t.DomainSep(label)
+	digest := t.state.Sum(nil)
+
+	// the digest is interpreted in little-endian and reduced
+	for i, j := 0, len(digest)-1; i < j; i, j = i+1, j-1 {
+		digest[i], digest[j] = digest[j], digest[i]
+	}
+	var res fr.Element
+	res.SetBytes(digest)
+
+	t.state.Reset()
+	t.AppendScalar(&res, label)
+	return res
+}
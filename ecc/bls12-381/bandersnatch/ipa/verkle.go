@@ -0,0 +1,178 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipa
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+var (
+	ErrInvalidStemSize  = errors.New("stem must be 31 bytes long")
+	ErrInvalidValueSize = errors.New("leaf value must be 32 bytes long")
+	ErrInvalidKeySize   = errors.New("address must be 32 bytes long")
+)
+
+const (
+	// StemSize size in bytes of the stem of a Verkle tree key
+	StemSize = 31
+	// LeafValueSize size in bytes of a value stored in a Verkle tree
+	LeafValueSize = 32
+)
+
+// InternalNodeCommitment returns the commitment of an internal node of a
+// Verkle tree, ∑ᵢMapToScalarField(Cᵢ)Gᵢ where Cᵢ are the commitments of the
+// children. Empty children are represented by the identity (0, 1).
+func InternalNodeCommitment(c *Config, children []bandersnatch.PointAffine) (bandersnatch.PointAffine, error) {
+	if len(children) == 0 || len(children) > DomainSize {
+		return bandersnatch.PointAffine{}, ErrInvalidVectorSize
+	}
+	values := make([]fr.Element, len(children))
+	for i := range children {
+		values[i] = MapToScalarField(&children[i])
+	}
+	return c.Commit(values)
+}
+
+// LeafValueScalars returns the two scalars encoding a 32-byte leaf value:
+// the lower 16 bytes in little-endian with the 2¹²⁸ leaf marker set, and the
+// upper 16 bytes in little-endian. An absent value (nil) is encoded as (0, 0).
+func LeafValueScalars(value []byte) (lo, hi fr.Element, err error) {
+	if value == nil {
+		return
+	}
+	if len(value) != LeafValueSize {
+		err = ErrInvalidValueSize
+		return
+	}
+	lo.SetBytes(reversed(value[:16]))
+	var marker fr.Element
+	marker.SetBigInt(new(big.Int).Lsh(big.NewInt(1), 128))
+	lo.Add(&lo, &marker)
+	hi.SetBytes(reversed(value[16:]))
+	return
+}
+
+// StemCommitment returns the commitment of a leaf node (extension) of a Verkle
+// tree, holding the values of the 256 keys sharing stem:
+//
+//	C = 1⋅G₀ + stem⋅G₁ + MapToScalarField(C₁)⋅G₂ + MapToScalarField(C₂)⋅G₃
+//
+// where C₁ (resp. C₂) commits to the values with suffixes 0..127 (resp.
+// 128..255), each value occupying two consecutive generators. values must have
+// 256 entries, nil for absent values. C₁ and C₂ are returned as well.
+func StemCommitment(c *Config, stem []byte, values [][]byte) (commitment, c1, c2 bandersnatch.PointAffine, err error) {
+	if len(stem) != StemSize {
+		err = ErrInvalidStemSize
+		return
+	}
+	if len(values) != DomainSize {
+		err = ErrInvalidVectorSize
+		return
+	}
+
+	var halves [2][]fr.Element
+	for h := range halves {
+		halves[h] = make([]fr.Element, DomainSize)
+		for i := 0; i < DomainSize/2; i++ {
+			halves[h][2*i], halves[h][2*i+1], err = LeafValueScalars(values[h*DomainSize/2+i])
+			if err != nil {
+				return
+			}
+		}
+	}
+	if c1, err = c.Commit(halves[0]); err != nil {
+		return
+	}
+	if c2, err = c.Commit(halves[1]); err != nil {
+		return
+	}
+
+	ext := make([]fr.Element, 4)
+	ext[0].SetOne()
+	ext[1].SetBytes(reversed(stem))
+	ext[2] = MapToScalarField(&c1)
+	ext[3] = MapToScalarField(&c2)
+	commitment, err = c.Commit(ext)
+	return
+}
+
+// PedersenHash hashes up to 255 16-byte chunks of input by committing to
+// [2 + 256⋅len(input), chunk₀, chunk₁, ...], the chunks being read in
+// little-endian, and serializing the commitment in little-endian (the reverse
+// of Bytes), as in EIP-6800.
+func PedersenHash(c *Config, input []byte) ([SizePointCompressed]byte, error) {
+	if len(input) > (DomainSize-1)*16 {
+		return [SizePointCompressed]byte{}, ErrInvalidVectorSize
+	}
+	values := make([]fr.Element, 1, DomainSize)
+	values[0].SetUint64(2 + 256*uint64(len(input)))
+	for i := 0; i < len(input); i += 16 {
+		end := i + 16
+		if end > len(input) {
+			end = len(input)
+		}
+		var v fr.Element
+		v.SetBytes(reversed(input[i:end]))
+		values = append(values, v)
+	}
+	commitment, err := c.Commit(values)
+	if err != nil {
+		return [SizePointCompressed]byte{}, err
+	}
+	res := Bytes(&commitment)
+	reverse(res[:])
+	return res, nil
+}
+
+// GetTreeKey returns the Verkle tree key of the given account address (32
+// bytes), tree index and sub index:
+//
+//	PedersenHash(address || LE₃₂(treeIndex))[:31] || subIndex
+func GetTreeKey(c *Config, address []byte, treeIndex *big.Int, subIndex byte) ([32]byte, error) {
+	var res [32]byte
+	if len(address) != 32 {
+		return res, ErrInvalidKeySize
+	}
+	input := make([]byte, 64)
+	copy(input, address)
+	treeIndex.FillBytes(input[32:])
+	reverse(input[32:])
+
+	h, err := PedersenHash(c, input)
+	if err != nil {
+		return res, err
+	}
+	copy(res[:StemSize], h[:StemSize])
+	res[StemSize] = subIndex
+	return res, nil
+}
+
+// reversed returns a reversed copy of b
+func reversed(b []byte) []byte {
+	res := make([]byte, len(b))
+	copy(res, b)
+	reverse(res)
+	return res
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -17,6 +17,7 @@
 package ecdsa
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/sha256"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
@@ -139,6 +140,54 @@ func TestNoZeros(t *testing.T) {
 	})
 }
 
+func TestSignDeterministic(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-381] RFC 6979 signatures are deterministic and valid", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing deterministic ECDSA")
+			sig1, err := privKey.SignDeterministic(msg, sha256.New)
+			if err != nil {
+				return false
+			}
+			sig2, _ := privKey.SignDeterministic(msg, sha256.New)
+			sig3, _ := privKey.SignDeterministic([]byte("another message"), sha256.New)
+			if !bytes.Equal(sig1, sig2) || bytes.Equal(sig1, sig3) {
+				return false
+			}
+			flag, _ := publicKey.Verify(sig1, msg, sha256.New())
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-381] low-S normalized signatures stay valid", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.PublicKey
+
+			msg := []byte("testing low-S")
+			bSig, _ := privKey.Sign(msg, nil)
+			var sig Signature
+			if _, err := sig.SetBytes(bSig); err != nil {
+				return false
+			}
+			wasHigh := !sig.IsLowS()
+			if sig.NormalizeS() != wasHigh || !sig.IsLowS() || sig.NormalizeS() {
+				return false
+			}
+			flag, _ := publicKey.Verify(sig.Bytes(), msg, nil)
+			return flag
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 // ------------------------------------------------------------
 // benches
 
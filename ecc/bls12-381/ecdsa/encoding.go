@@ -0,0 +1,470 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	errInvalidEncoding = errors.New("invalid key encoding")
+	errWrongCurve      = errors.New("key is not an ECDSA key on bls12-381")
+	errInvalidPoint    = errors.New("public key is not a point of the subgroup")
+	errKeyMismatch     = errors.New("public key doesn't match the private key")
+)
+
+var (
+	// id-ecPublicKey (1.2.840.10045.2.1), RFC 5480
+	oidPublicKeyECDSA = []byte{0x2a, 0x86, 0x48, 0xce, 0x3d, 0x02, 0x01}
+	// bls12-381 (2.25.46608167216907221384985006267746188518.1.4), under the UUID arc of gnark-crypto
+	// (ITU-T X.667), as the curve has no standard object identifier
+	oidNamedCurve = []byte{0x69, 0xc6, 0x90, 0xb4, 0x83, 0xf6, 0xbe, 0xba, 0x88, 0xd7, 0xa4, 0xc5, 0xb1, 0xa0, 0xc8, 0x9d, 0xad, 0xf9, 0x66, 0x01, 0x04}
+)
+
+const (
+	// jwkCurve is the "crv" parameter of the JSON Web Keys
+	jwkCurve = "bls12-381"
+
+	pemPrivateKey    = "PRIVATE KEY"
+	pemECPrivateKey  = "EC PRIVATE KEY"
+	pemPublicKey     = "PUBLIC KEY"
+	sizeEncodedPoint = 1 + 2*sizeFp
+	versionSEC1      = 1
+	versionPKCS8     = 0
+)
+
+var (
+	tagParameters = asn1.Tag(0).Constructed().ContextSpecific()
+	tagPublicKey  = asn1.Tag(1).Constructed().ContextSpecific()
+)
+
+// jwk is the JSON Web Key of an elliptic curve key, RFC 7518 Section 6.2
+type jwk struct {
+	Kty string  `json:"kty"`
+	Crv string  `json:"crv"`
+	X   string  `json:"x"`
+	Y   string  `json:"y"`
+	D   *string `json:"d,omitempty"` // nil if absent
+}
+
+// MarshalPKIX returns the DER encoding of the public key as an X.509
+// SubjectPublicKeyInfo (RFC 5480), with the uncompressed point of SEC 1.
+func (pk *PublicKey) MarshalPKIX() []byte {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		addAlgorithm(b)
+		b.AddASN1BitString(pk.marshalPoint())
+	})
+	return b.BytesOrPanic()
+}
+
+// UnmarshalPKIX sets the public key from its DER encoded X.509
+// SubjectPublicKeyInfo. The algorithm must be id-ecPublicKey on bls12-381
+// and the point uncompressed, in the subgroup and not the point at infinity.
+func (pk *PublicKey) UnmarshalPKIX(der []byte) error {
+	var spki, alg cryptobyte.String
+	var point []byte
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&spki, asn1.SEQUENCE) || !input.Empty() ||
+		!spki.ReadASN1(&alg, asn1.SEQUENCE) ||
+		!spki.ReadASN1BitStringAsBytes(&point) || !spki.Empty() {
+		return errInvalidEncoding
+	}
+	if err := readAlgorithm(&alg); err != nil {
+		return err
+	}
+	return pk.unmarshalPoint(point)
+}
+
+// MarshalPEM returns the PEM encoding of the public key, as a "PUBLIC KEY"
+// block of its SubjectPublicKeyInfo.
+func (pk *PublicKey) MarshalPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKey, Bytes: pk.MarshalPKIX()})
+}
+
+// UnmarshalPEM sets the public key from a single "PUBLIC KEY" PEM block.
+func (pk *PublicKey) UnmarshalPEM(data []byte) error {
+	block, err := decodePEM(data)
+	if err != nil {
+		return err
+	}
+	if block.Type != pemPublicKey {
+		return errInvalidEncoding
+	}
+	return pk.UnmarshalPKIX(block.Bytes)
+}
+
+// MarshalJWK returns the JSON Web Key of the public key, of type "EC" with the
+// curve "bls12-381".
+func (pk *PublicKey) MarshalJWK() []byte {
+	x, y := pk.A.X.Bytes(), pk.A.Y.Bytes()
+	res, _ := json.Marshal(jwk{
+		Kty: "EC",
+		Crv: jwkCurve,
+		X:   base64.RawURLEncoding.EncodeToString(x[:]),
+		Y:   base64.RawURLEncoding.EncodeToString(y[:]),
+	})
+	return res
+}
+
+// UnmarshalJWK sets the public key from its JSON Web Key. The coordinates
+// must be unpadded base64url encoded of size sizeFp, and the key must not
+// contain the private parameter "d" nor any member other than kty, crv, x and y.
+func (pk *PublicKey) UnmarshalJWK(data []byte) error {
+	key, err := decodeJWK(data)
+	if err != nil {
+		return err
+	}
+	if key.D != nil {
+		return errInvalidEncoding
+	}
+	return pk.unmarshalJWK(key)
+}
+
+// MarshalSEC1 returns the DER encoding of the private key as a SEC 1
+// ECPrivateKey (RFC 5915), with the curve and the public key.
+func (privKey *PrivateKey) MarshalSEC1() []byte {
+	return privKey.marshalSEC1(true)
+}
+
+// UnmarshalSEC1 sets the private key from its DER encoded SEC 1 ECPrivateKey.
+// The scalar must be in [1, order-1] and of size sizeFr, and the curve and the
+// public key, if present, must match.
+func (privKey *PrivateKey) UnmarshalSEC1(der []byte) error {
+	return privKey.unmarshalSEC1(der)
+}
+
+// MarshalPKCS8 returns the DER encoding of the private key as a PKCS #8
+// PrivateKeyInfo (RFC 5208), wrapping its SEC 1 ECPrivateKey without the
+// curve, given by the algorithm.
+func (privKey *PrivateKey) MarshalPKCS8() []byte {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1Int64(versionPKCS8)
+		addAlgorithm(b)
+		b.AddASN1OctetString(privKey.marshalSEC1(false))
+	})
+	return b.BytesOrPanic()
+}
+
+// UnmarshalPKCS8 sets the private key from its DER encoded PKCS #8
+// PrivateKeyInfo. The algorithm must be id-ecPublicKey on bls12-381, the key
+// must not have attributes, and the wrapped ECPrivateKey is checked as in
+// UnmarshalSEC1.
+func (privKey *PrivateKey) UnmarshalPKCS8(der []byte) error {
+	var info, alg, sec1 cryptobyte.String
+	var version int64
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&info, asn1.SEQUENCE) || !input.Empty() ||
+		!info.ReadASN1Integer(&version) ||
+		!info.ReadASN1(&alg, asn1.SEQUENCE) ||
+		!info.ReadASN1(&sec1, asn1.OCTET_STRING) || !info.Empty() {
+		return errInvalidEncoding
+	}
+	if version != versionPKCS8 {
+		return errInvalidEncoding
+	}
+	if err := readAlgorithm(&alg); err != nil {
+		return err
+	}
+	return privKey.unmarshalSEC1(sec1)
+}
+
+// MarshalPEM returns the PEM encoding of the private key, as a "PRIVATE KEY"
+// block of its PKCS #8 PrivateKeyInfo.
+func (privKey *PrivateKey) MarshalPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Bytes: privKey.MarshalPKCS8()})
+}
+
+// UnmarshalPEM sets the private key from a single PEM block, either a PKCS #8
+// "PRIVATE KEY" or a SEC 1 "EC PRIVATE KEY". Encrypted blocks are rejected.
+func (privKey *PrivateKey) UnmarshalPEM(data []byte) error {
+	block, err := decodePEM(data)
+	if err != nil {
+		return err
+	}
+	switch block.Type {
+	case pemPrivateKey:
+		return privKey.UnmarshalPKCS8(block.Bytes)
+	case pemECPrivateKey:
+		return privKey.UnmarshalSEC1(block.Bytes)
+	default:
+		return errInvalidEncoding
+	}
+}
+
+// MarshalJWK returns the JSON Web Key of the private key, of type "EC" with
+// the curve "bls12-381".
+func (privKey *PrivateKey) MarshalJWK() []byte {
+	d := base64.RawURLEncoding.EncodeToString(privKey.scalar[:])
+	x, y := privKey.PublicKey.A.X.Bytes(), privKey.PublicKey.A.Y.Bytes()
+	res, _ := json.Marshal(jwk{
+		Kty: "EC",
+		Crv: jwkCurve,
+		X:   base64.RawURLEncoding.EncodeToString(x[:]),
+		Y:   base64.RawURLEncoding.EncodeToString(y[:]),
+		D:   &d,
+	})
+	return res
+}
+
+// UnmarshalJWK sets the private key from its JSON Web Key. The parameters must
+// be unpadded base64url encoded of size sizeFp for the coordinates and sizeFr
+// for d, the coordinates must be those of the public key, and the key must not
+// contain any member other than kty, crv, x, y and d.
+func (privKey *PrivateKey) UnmarshalJWK(data []byte) error {
+	key, err := decodeJWK(data)
+	if err != nil {
+		return err
+	}
+	var pk PublicKey
+	if err = pk.unmarshalJWK(key); err != nil {
+		return err
+	}
+	if key.D == nil {
+		return errInvalidEncoding
+	}
+	d, err := base64.RawURLEncoding.Strict().DecodeString(*key.D)
+	if err != nil || len(d) != sizeFr {
+		return errInvalidEncoding
+	}
+	return privKey.setScalar(d, &pk)
+}
+
+func (privKey *PrivateKey) marshalSEC1(withCurve bool) []byte {
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1Int64(versionSEC1)
+		b.AddASN1OctetString(privKey.scalar[:])
+		if withCurve {
+			b.AddASN1(tagParameters, func(b *cryptobyte.Builder) {
+				b.AddASN1(asn1.OBJECT_IDENTIFIER, func(b *cryptobyte.Builder) {
+					b.AddBytes(oidNamedCurve)
+				})
+			})
+		}
+		b.AddASN1(tagPublicKey, func(b *cryptobyte.Builder) {
+			b.AddASN1BitString(privKey.PublicKey.marshalPoint())
+		})
+	})
+	return b.BytesOrPanic()
+}
+
+// unmarshalSEC1 sets the private key from its DER encoded ECPrivateKey, whose
+// curve is optional.
+func (privKey *PrivateKey) unmarshalSEC1(der []byte) error {
+	var key, curve, point cryptobyte.String
+	var scalar []byte
+	var version int64
+	var hasCurve, hasPoint bool
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&key, asn1.SEQUENCE) || !input.Empty() ||
+		!key.ReadASN1Integer(&version) ||
+		!key.ReadASN1Bytes(&scalar, asn1.OCTET_STRING) ||
+		!key.ReadOptionalASN1(&curve, &hasCurve, tagParameters) ||
+		!key.ReadOptionalASN1(&point, &hasPoint, tagPublicKey) || !key.Empty() {
+		return errInvalidEncoding
+	}
+	if version != versionSEC1 || len(scalar) != sizeFr {
+		return errInvalidEncoding
+	}
+	if hasCurve {
+		var oid cryptobyte.String
+		if !curve.ReadASN1(&oid, asn1.OBJECT_IDENTIFIER) || !curve.Empty() {
+			return errInvalidEncoding
+		}
+		if !bytes.Equal(oid, oidNamedCurve) {
+			return errWrongCurve
+		}
+	}
+	if !hasPoint {
+		return privKey.setScalar(scalar, nil)
+	}
+	var pk PublicKey
+	var pointBytes []byte
+	if !point.ReadASN1BitStringAsBytes(&pointBytes) || !point.Empty() {
+		return errInvalidEncoding
+	}
+	if err := pk.unmarshalPoint(pointBytes); err != nil {
+		return err
+	}
+	return privKey.setScalar(scalar, &pk)
+}
+
+// setScalar sets the private key from its big endian scalar of size sizeFr,
+// which must be in [1, order-1]. The public key is computed from it, and must
+// be equal to pk if it is not nil.
+func (privKey *PrivateKey) setScalar(scalar []byte, pk *PublicKey) error {
+	k := new(big.Int).SetBytes(scalar)
+	if k.Sign() == 0 {
+		return errZero
+	}
+	if k.Cmp(order) >= 0 {
+		return errInvalidEncoding
+	}
+	var A bls12381.G1Affine
+	A.ScalarMultiplicationBase(k)
+	if pk != nil && !pk.A.Equal(&A) {
+		return errKeyMismatch
+	}
+	privKey.PublicKey.A = A
+	copy(privKey.scalar[:], scalar)
+	return nil
+}
+
+// marshalPoint returns the SEC 1 uncompressed encoding 0x04||x||y of the public
+// key, where x, y are big endian integers of size sizeFp.
+func (pk *PublicKey) marshalPoint() []byte {
+	res := make([]byte, sizeEncodedPoint)
+	res[0] = 4
+	x, y := pk.A.X.Bytes(), pk.A.Y.Bytes()
+	copy(res[1:], x[:])
+	copy(res[1+sizeFp:], y[:])
+	return res
+}
+
+// unmarshalPoint sets the public key from its SEC 1 uncompressed encoding.
+func (pk *PublicKey) unmarshalPoint(buf []byte) error {
+	if len(buf) != sizeEncodedPoint || buf[0] != 4 {
+		return errInvalidEncoding
+	}
+	var A bls12381.G1Affine
+	if A.X.SetBytesCanonical(buf[1:1+sizeFp]) != nil || A.Y.SetBytesCanonical(buf[1+sizeFp:]) != nil {
+		return errInvalidEncoding
+	}
+	return pk.setAffine(&A)
+}
+
+// setAffine sets the public key to A, which must be a point of the subgroup
+// other than the point at infinity.
+func (pk *PublicKey) setAffine(A *bls12381.G1Affine) error {
+	if A.IsInfinity() || !A.IsOnCurve() || !A.IsInSubGroup() {
+		return errInvalidPoint
+	}
+	pk.A = *A
+	return nil
+}
+
+func (pk *PublicKey) unmarshalJWK(key *jwk) error {
+	if key.Kty != "EC" || key.Crv != jwkCurve {
+		return errWrongCurve
+	}
+	x, err := base64.RawURLEncoding.Strict().DecodeString(key.X)
+	if err != nil || len(x) != sizeFp {
+		return errInvalidEncoding
+	}
+	y, err := base64.RawURLEncoding.Strict().DecodeString(key.Y)
+	if err != nil || len(y) != sizeFp {
+		return errInvalidEncoding
+	}
+	var A bls12381.G1Affine
+	if A.X.SetBytesCanonical(x) != nil || A.Y.SetBytesCanonical(y) != nil {
+		return errInvalidEncoding
+	}
+	return pk.setAffine(&A)
+}
+
+// addAlgorithm adds the AlgorithmIdentifier id-ecPublicKey with the namedCurve
+// bls12-381
+func addAlgorithm(b *cryptobyte.Builder) {
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(asn1.OBJECT_IDENTIFIER, func(b *cryptobyte.Builder) {
+			b.AddBytes(oidPublicKeyECDSA)
+		})
+		b.AddASN1(asn1.OBJECT_IDENTIFIER, func(b *cryptobyte.Builder) {
+			b.AddBytes(oidNamedCurve)
+		})
+	})
+}
+
+// readAlgorithm reads the contents of an AlgorithmIdentifier, which must be
+// id-ecPublicKey with the namedCurve bls12-381
+func readAlgorithm(alg *cryptobyte.String) error {
+	var algorithm, curve cryptobyte.String
+	if !alg.ReadASN1(&algorithm, asn1.OBJECT_IDENTIFIER) ||
+		!alg.ReadASN1(&curve, asn1.OBJECT_IDENTIFIER) || !alg.Empty() {
+		return errInvalidEncoding
+	}
+	if !bytes.Equal(algorithm, oidPublicKeyECDSA) || !bytes.Equal(curve, oidNamedCurve) {
+		return errWrongCurve
+	}
+	return nil
+}
+
+// decodePEM returns the only PEM block of data, which must not have headers
+func decodePEM(data []byte) (*pem.Block, error) {
+	block, rest := pem.Decode(data)
+	if block == nil || len(block.Headers) != 0 || len(bytes.TrimSpace(rest)) != 0 {
+		return nil, errInvalidEncoding
+	}
+	return block, nil
+}
+
+// decodeJWK decodes the JSON Web Key in data, which must only have the members
+// kty, crv, x, y and d, with these exact names and at most once each.
+func decodeJWK(data []byte) (*jwk, error) {
+	// encoding/json matches the names of the members case-insensitively and
+	// keeps the last of duplicate members: check the names first
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, errInvalidEncoding
+	}
+	seen := make(map[string]bool)
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, errInvalidEncoding
+		}
+		switch name := t.(string); name {
+		case "kty", "crv", "x", "y", "d":
+			if seen[name] {
+				return nil, errInvalidEncoding
+			}
+			seen[name] = true
+		default:
+			return nil, errInvalidEncoding
+		}
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, errInvalidEncoding
+		}
+	}
+	if t, err := dec.Token(); err != nil || t != json.Delim('}') {
+		return nil, errInvalidEncoding
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errInvalidEncoding
+	}
+
+	var key jwk
+	dec = json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&key); err != nil {
+		return nil, errInvalidEncoding
+	}
+	return &key, nil
+}
@@ -0,0 +1,248 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+func TestEncoding(t *testing.T) {
+
+	for i := 0; i < 8; i++ {
+		privKey, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		private := []struct {
+			name      string
+			marshal   func() []byte
+			unmarshal func(*PrivateKey, []byte) error
+		}{
+			{"SEC1", privKey.MarshalSEC1, (*PrivateKey).UnmarshalSEC1},
+			{"PKCS8", privKey.MarshalPKCS8, (*PrivateKey).UnmarshalPKCS8},
+			{"PEM", privKey.MarshalPEM, (*PrivateKey).UnmarshalPEM},
+			{"JWK", privKey.MarshalJWK, (*PrivateKey).UnmarshalJWK},
+		}
+		for _, e := range private {
+			var end PrivateKey
+			enc := e.marshal()
+			if err := e.unmarshal(&end, enc); err != nil {
+				t.Fatal(e.name, err)
+			}
+			if end.scalar != privKey.scalar || !end.PublicKey.Equal(&privKey.PublicKey) {
+				t.Fatal(e.name, "private key should stay the same")
+			}
+		}
+
+		publicKey := &privKey.PublicKey
+		public := []struct {
+			name      string
+			marshal   func() []byte
+			unmarshal func(*PublicKey, []byte) error
+		}{
+			{"PKIX", publicKey.MarshalPKIX, (*PublicKey).UnmarshalPKIX},
+			{"PEM", publicKey.MarshalPEM, (*PublicKey).UnmarshalPEM},
+			{"JWK", publicKey.MarshalJWK, (*PublicKey).UnmarshalJWK},
+		}
+		for _, e := range public {
+			var end PublicKey
+			if err := e.unmarshal(&end, e.marshal()); err != nil {
+				t.Fatal(e.name, err)
+			}
+			if !end.Equal(publicKey) {
+				t.Fatal(e.name, "public key should stay the same")
+			}
+		}
+
+		// a SEC 1 key is also accepted in PEM
+		var end PrivateKey
+		sec1 := pem.EncodeToMemory(&pem.Block{Type: pemECPrivateKey, Bytes: privKey.MarshalSEC1()})
+		if err := end.UnmarshalPEM(sec1); err != nil || end.scalar != privKey.scalar {
+			t.Fatal("SEC 1 PEM block should be accepted")
+		}
+	}
+}
+
+func TestEncodingFailures(t *testing.T) {
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var privEnd PrivateKey
+	var pubEnd PublicKey
+
+	// sec1 builds an ECPrivateKey from its fields
+	sec1 := func(version int64, scalar, curve, point []byte) []byte {
+		var b cryptobyte.Builder
+		b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1Int64(version)
+			b.AddASN1OctetString(scalar)
+			if curve != nil {
+				b.AddASN1(tagParameters, func(b *cryptobyte.Builder) {
+					b.AddASN1(asn1.OBJECT_IDENTIFIER, func(b *cryptobyte.Builder) {
+						b.AddBytes(curve)
+					})
+				})
+			}
+			if point != nil {
+				b.AddASN1(tagPublicKey, func(b *cryptobyte.Builder) {
+					b.AddASN1BitString(point)
+				})
+			}
+		})
+		return b.BytesOrPanic()
+	}
+	scalar := privKey.scalar[:]
+	point := privKey.PublicKey.marshalPoint()
+	if err = privEnd.UnmarshalSEC1(sec1(versionSEC1, scalar, nil, nil)); err != nil || privEnd.scalar != privKey.scalar {
+		t.Fatal("the curve and the public key are optional")
+	}
+
+	wrongCurve := append([]byte{}, oidNamedCurve...)
+	wrongCurve[len(wrongCurve)-1] ^= 1
+	orderBytes := make([]byte, sizeFr)
+	order.FillBytes(orderBytes)
+	offCurve := append([]byte{}, point...)
+	offCurve[len(offCurve)-1] ^= 1
+	compressed := append([]byte{2 + byte(privKey.PublicKey.A.Y.BigInt(new(big.Int)).Bit(0))}, point[1:1+sizeFp]...)
+
+	for _, c := range []struct {
+		name string
+		der  []byte
+	}{
+		{"trailing data", append(privKey.MarshalSEC1(), 0)},
+		{"truncated", privKey.MarshalSEC1()[:20]},
+		{"version", sec1(0, scalar, oidNamedCurve, point)},
+		{"short scalar", sec1(versionSEC1, scalar[1:], oidNamedCurve, point)},
+		{"zero scalar", sec1(versionSEC1, make([]byte, sizeFr), oidNamedCurve, nil)},
+		{"scalar ≥ order", sec1(versionSEC1, orderBytes, oidNamedCurve, nil)},
+		{"wrong curve", sec1(versionSEC1, scalar, wrongCurve, point)},
+		{"public key mismatch", sec1(versionSEC1, scalar, oidNamedCurve, other.PublicKey.marshalPoint())},
+		{"point not on curve", sec1(versionSEC1, scalar, oidNamedCurve, offCurve)},
+		{"compressed point", sec1(versionSEC1, scalar, oidNamedCurve, compressed)},
+	} {
+		if err = privEnd.UnmarshalSEC1(c.der); err == nil {
+			t.Fatal("SEC1", c.name, "should be rejected")
+		}
+	}
+
+	pkcs8 := privKey.MarshalPKCS8()
+	var b cryptobyte.Builder
+	b.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1Int64(versionPKCS8)
+		addAlgorithm(b)
+		b.AddASN1OctetString(privKey.marshalSEC1(false))
+		b.AddASN1(asn1.Tag(0).Constructed().ContextSpecific(), func(b *cryptobyte.Builder) {})
+	})
+	withAttributes := b.BytesOrPanic()
+	wrongAlgorithm := append([]byte{}, oidPublicKeyECDSA...)
+	wrongAlgorithm[len(wrongAlgorithm)-1] ^= 1
+	for _, c := range []struct {
+		name string
+		der  []byte
+	}{
+		{"trailing data", append(pkcs8, 0)},
+		{"attributes", withAttributes},
+		{"SEC1", privKey.MarshalSEC1()},
+		{"wrong curve", bytes.Replace(pkcs8, oidNamedCurve, wrongCurve, 1)},
+		{"wrong algorithm", bytes.Replace(pkcs8, oidPublicKeyECDSA, wrongAlgorithm, 1)},
+	} {
+		if err = privEnd.UnmarshalPKCS8(c.der); err == nil {
+			t.Fatal("PKCS8", c.name, "should be rejected")
+		}
+	}
+
+	pkix := privKey.PublicKey.MarshalPKIX()
+	for _, c := range []struct {
+		name string
+		der  []byte
+	}{
+		{"trailing data", append(pkix, 0)},
+		{"truncated", pkix[:len(pkix)-1]},
+		{"wrong curve", bytes.Replace(pkix, oidNamedCurve, wrongCurve, 1)},
+		{"point not on curve", bytes.Replace(pkix, point, offCurve, 1)},
+	} {
+		if err = pubEnd.UnmarshalPKIX(c.der); err == nil {
+			t.Fatal("PKIX", c.name, "should be rejected")
+		}
+	}
+
+	privPEM := privKey.MarshalPEM()
+	for _, c := range []struct {
+		name string
+		pem  []byte
+	}{
+		{"public key", privKey.PublicKey.MarshalPEM()},
+		{"two blocks", append(privKey.MarshalPEM(), privPEM...)},
+		{"headers", pem.EncodeToMemory(&pem.Block{Type: pemPrivateKey, Headers: map[string]string{"Proc-Type": "4,ENCRYPTED"}, Bytes: privKey.MarshalPKCS8()})},
+		{"not PEM", privKey.MarshalPKCS8()},
+	} {
+		if err = privEnd.UnmarshalPEM(c.pem); err == nil {
+			t.Fatal("PEM", c.name, "should be rejected")
+		}
+	}
+	if err = pubEnd.UnmarshalPEM(privPEM); err == nil {
+		t.Fatal("PEM private key should be rejected as public key")
+	}
+
+	privJWK := string(privKey.MarshalJWK())
+	pubJWK := string(privKey.PublicKey.MarshalJWK())
+	for _, c := range []struct {
+		name string
+		jwk  string
+	}{
+		{"kty", strings.Replace(privJWK, `"EC"`, `"OKP"`, 1)},
+		{"crv", strings.Replace(privJWK, `"`+jwkCurve+`"`, `"P-256"`, 1)},
+		{"padding", strings.Replace(privJWK, `","y"`, `=","y"`, 1)},
+		{"missing d", pubJWK},
+		{"public key mismatch", strings.Replace(privJWK, pubJWK[:len(pubJWK)-1], string(other.PublicKey.MarshalJWK()[:len(pubJWK)-1]), 1)},
+		{"not JSON", privJWK[1:]},
+		{"uppercase member", strings.Replace(privJWK, `"kty"`, `"KTY"`, 1)},
+		{"mixed case member", strings.Replace(privJWK, `"d"`, `"D"`, 1)},
+		{"unknown member", `{"kid":"1",` + privJWK[1:]},
+		{"trailing data", privJWK + "{}"},
+		{"duplicate member", `{"d":"AA",` + privJWK[1:]},
+		{"null d", `{"d":null,` + pubJWK[1:]},
+	} {
+		if err = privEnd.UnmarshalJWK([]byte(c.jwk)); err == nil {
+			t.Fatal("JWK", c.name, "should be rejected")
+		}
+	}
+	if err = pubEnd.UnmarshalJWK([]byte(privJWK)); err == nil {
+		t.Fatal("JWK private key should be rejected as public key")
+	}
+	if err = pubEnd.UnmarshalJWK([]byte(`{"d":"",` + pubJWK[1:])); err == nil {
+		t.Fatal("JWK public key with an empty d should be rejected")
+	}
+	if err = pubEnd.UnmarshalJWK([]byte(`{"kty":"OKP",` + pubJWK[1:])); err == nil {
+		t.Fatal("JWK public key with a duplicate member should be rejected")
+	}
+}
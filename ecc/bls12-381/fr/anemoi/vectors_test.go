@@ -0,0 +1,54 @@
+package anemoi
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func elementOf(s string) (res fr.Element) {
+	if _, err := res.SetString(s); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// known-answer vectors of the instance with ℓ = 1 of ePrint 2022/840, computed with
+// a transliteration of the authors' anemoi.py (permutation, jive and sponge_hash)
+func TestVectors(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	assert.Equal(5, params.Alpha)
+	assert.Equal(21, params.NbRounds)
+	assert.Equal("39", params.C[0].String())
+	assert.Equal("14981678621464625851270783002338847382197300714436467949315331057125308909900", params.D[0].String())
+	assert.Equal("1442682574593111726669580215226707864874727525884816275719319731039421692145", params.C[1].String())
+	assert.Equal("25303802814210301675797956352442412878494556846104100059929997421859837788055", params.D[1].String())
+
+	var x, y fr.Element
+	params.Permutation(&x, &y)
+	assert.Equal("2217421750029741369401495691271208002884301815557943879798853944237701475506", x.String())
+	assert.Equal("2179243446232496715099509712092368265959709565449432545284026399076526028686", y.String())
+
+	x, y = elementOf("1"), elementOf("2")
+	params.Permutation(&x, &y)
+	assert.Equal("37689761519302190692358872825094327990315686037487583808238476451356880922755", x.String())
+	assert.Equal("42885879599383038937912097785169060302303181624925913610532850620679725646556", y.String())
+
+	c := params.Compress(elementOf("1"), elementOf("2"))
+	assert.Equal("28139765943559039150823230102077422454928315161885859596167668372098025384801", c.String())
+
+	for _, v := range []struct {
+		in  []fr.Element
+		out string
+	}{
+		{nil, "0"},
+		{[]fr.Element{elementOf("1")}, "50151318529859305307840466654959337697459344480647832430328963196487221859144"},
+		{[]fr.Element{elementOf("1"), elementOf("2")}, "42375992948854409881539320448173800531797046256872530686065550511541961926980"},
+		{[]fr.Element{elementOf("1"), elementOf("2"), elementOf("3")}, "48870056936392444719194319501217887620131075257552437432898874584453288620754"},
+	} {
+		h := params.Hash(v.in)
+		assert.Equal(v.out, h.String())
+	}
+}
@@ -0,0 +1,458 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidSize        = errors.New("the size must be a non zero power of two")
+	ErrSetupTooSmall      = errors.New("the setup is too small for the statement")
+	ErrLengthMismatch     = errors.New("vectors must have the same length")
+	ErrInvalidProof       = errors.New("the proof does not match the statement")
+	ErrVerifyInnerProduct = errors.New("inner product proof verification failed")
+	ErrZeroChallenge      = errors.New("challenge is zero")
+)
+
+// Setup holds the public generators of the scheme. All the generators are
+// independent: nobody knows a discrete logarithm relation between them.
+type Setup struct {
+	// G and H are the generators for the vector commitments.
+	G, H []curve.G1Affine
+
+	// B and BlindingB are the bases of the value commitments v·B + γ·BlindingB.
+	B, BlindingB curve.G1Affine
+
+	// U is the base to which the inner product is bound.
+	U curve.G1Affine
+}
+
+// NewSetup derives a Setup for vectors of length up to size, which must be a
+// power of two. The generators are hashed to the curve from dst, so that two
+// calls with the same dst produce compatible setups. B is the generator of G1.
+func NewSetup(size int, dst []byte) (Setup, error) {
+	if size <= 0 || bits.OnesCount(uint(size)) != 1 {
+		return Setup{}, ErrInvalidSize
+	}
+
+	hashToPoint := func(prefix byte, i uint32) (curve.G1Affine, error) {
+		var msg [5]byte
+		msg[0] = prefix
+		binary.BigEndian.PutUint32(msg[1:], i)
+		return curve.HashToG1(msg[:], dst)
+	}
+
+	var s Setup
+	var err error
+	_, _, s.B, _ = curve.Generators()
+	if s.BlindingB, err = hashToPoint('B', 0); err != nil {
+		return Setup{}, err
+	}
+	if s.U, err = hashToPoint('U', 0); err != nil {
+		return Setup{}, err
+	}
+
+	s.G = make([]curve.G1Affine, size)
+	s.H = make([]curve.G1Affine, size)
+	errs := make([]error, size)
+	parallel.Execute(size, func(start, end int) {
+		for i := start; i < end; i++ {
+			if s.G[i], errs[i] = hashToPoint('G', uint32(i)); errs[i] != nil {
+				return
+			}
+			s.H[i], errs[i] = hashToPoint('H', uint32(i))
+		}
+	})
+	for i := range errs {
+		if errs[i] != nil {
+			return Setup{}, errs[i]
+		}
+	}
+
+	return s, nil
+}
+
+// Commit returns the Pedersen commitment v·B + γ·BlindingB.
+func (s *Setup) Commit(v, gamma *fr.Element) (curve.G1Affine, error) {
+	var res curve.G1Affine
+	if err := multiExp(&res, []curve.G1Affine{s.B, s.BlindingB}, []fr.Element{*v, *gamma}); err != nil {
+		return curve.G1Affine{}, err
+	}
+	return res, nil
+}
+
+// CommitVectors returns ⟨a,G⟩ + ⟨b,H⟩.
+func (s *Setup) CommitVectors(a, b []fr.Element) (curve.G1Affine, error) {
+	if len(a) != len(b) {
+		return curve.G1Affine{}, ErrLengthMismatch
+	}
+	if len(a) > len(s.G) {
+		return curve.G1Affine{}, ErrSetupTooSmall
+	}
+	n := len(a)
+	points := append(append(make([]curve.G1Affine, 0, 2*n), s.G[:n]...), s.H[:n]...)
+	scalars := append(append(make([]fr.Element, 0, 2*n), a...), b...)
+
+	var res curve.G1Affine
+	if err := multiExp(&res, points, scalars); err != nil {
+		return curve.G1Affine{}, err
+	}
+	return res, nil
+}
+
+// InnerProductProof is a proof that a commitment P = ⟨a,G⟩ + ⟨b,H⟩ opens to
+// vectors a, b such that ⟨a,b⟩ = c. It contains log₂(len(a)) pairs (L, R).
+type InnerProductProof struct {
+	L, R []curve.G1Affine
+	A, B fr.Element
+}
+
+// ProveInnerProduct proves that ⟨a,b⟩ is the inner product of the vectors
+// committed to in setup.CommitVectors(a, b). The length of the vectors must
+// be a power of two.
+//
+// hf is the hash function used for the fiat-shamir challenges, dataTranscript
+// is bound to the first challenge.
+func ProveInnerProduct(setup *Setup, a, b []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (InnerProductProof, error) {
+	n := len(a)
+	if n != len(b) {
+		return InnerProductProof{}, ErrLengthMismatch
+	}
+	if n == 0 || bits.OnesCount(uint(n)) != 1 {
+		return InnerProductProof{}, ErrInvalidSize
+	}
+	if n > len(setup.G) {
+		return InnerProductProof{}, ErrSetupTooSmall
+	}
+
+	commitment, err := setup.CommitVectors(a, b)
+	if err != nil {
+		return InnerProductProof{}, err
+	}
+	c := innerProduct(a, b)
+
+	fs := fiatshamir.NewTranscript(hf, innerProductChallenges(bits.TrailingZeros(uint(n)))...)
+	u, err := deriveInnerProductBase(&fs, setup, &commitment, &c, dataTranscript)
+	if err != nil {
+		return InnerProductProof{}, err
+	}
+
+	return proveInnerProduct(&fs, setup.G[:n], setup.H[:n], &u, a, b)
+}
+
+// VerifyInnerProduct checks that proof shows that commitment opens to two
+// vectors with inner product c.
+func VerifyInnerProduct(setup *Setup, commitment *curve.G1Affine, c *fr.Element, proof *InnerProductProof, hf hash.Hash, dataTranscript ...[]byte) error {
+	k := len(proof.L)
+	if k != len(proof.R) || k >= bits.UintSize-1 {
+		return ErrInvalidProof
+	}
+	n := 1 << k
+	if n > len(setup.G) {
+		return ErrSetupTooSmall
+	}
+
+	fs := fiatshamir.NewTranscript(hf, innerProductChallenges(k)...)
+	var w fr.Element
+	if err := bindInnerProductBase(&fs, commitment, c, dataTranscript); err != nil {
+		return err
+	}
+	if err := deriveChallenge(&fs, "w", &w); err != nil {
+		return err
+	}
+	x, xInv, err := innerProductRoundChallenges(&fs, proof)
+	if err != nil {
+		return err
+	}
+	s := innerProductScalars(x, xInv)
+
+	// P + c·w·U + ∑ⱼ(xⱼ²Lⱼ + xⱼ⁻²Rⱼ) - ⟨a·s,G⟩ - ⟨b·s⁻¹,H⟩ - ab·w·U == 0
+	points := make([]curve.G1Affine, 0, 2*n+2+2*k)
+	scalars := make([]fr.Element, 0, 2*n+2+2*k)
+	var tmp fr.Element
+	for i := 0; i < n; i++ {
+		tmp.Mul(&proof.A, &s[i]).Neg(&tmp)
+		scalars = append(scalars, tmp)
+	}
+	for i := 0; i < n; i++ {
+		tmp.Mul(&proof.B, &s[n-1-i]).Neg(&tmp)
+		scalars = append(scalars, tmp)
+	}
+	points = append(points, setup.G[:n]...)
+	points = append(points, setup.H[:n]...)
+
+	tmp.Mul(&proof.A, &proof.B).Sub(c, &tmp).Mul(&tmp, &w)
+	points = append(points, setup.U, *commitment)
+	scalars = append(scalars, tmp, fr.One())
+	points, scalars = appendRoundTerms(points, scalars, proof, x, xInv)
+
+	var res curve.G1Affine
+	if err := multiExp(&res, points, scalars); err != nil {
+		return err
+	}
+	if !res.IsInfinity() {
+		return ErrVerifyInnerProduct
+	}
+	return nil
+}
+
+// innerProductChallenges returns the names of the challenges of an inner
+// product argument with k rounds.
+func innerProductChallenges(k int) []string {
+	res := make([]string, 0, k+1)
+	res = append(res, "w")
+	return append(res, roundChallenges(k)...)
+}
+
+// roundChallenges returns the names of the challenges of the k folding rounds.
+func roundChallenges(k int) []string {
+	res := make([]string, k)
+	for i := range res {
+		res[i] = "ipa" + strconv.Itoa(i)
+	}
+	return res
+}
+
+func bindInnerProductBase(fs *fiatshamir.Transcript, commitment *curve.G1Affine, c *fr.Element, dataTranscript [][]byte) error {
+	for i := range dataTranscript {
+		if err := fs.Bind("w", dataTranscript[i]); err != nil {
+			return err
+		}
+	}
+	bCommitment := commitment.RawBytes()
+	if err := fs.Bind("w", bCommitment[:]); err != nil {
+		return err
+	}
+	return fs.Bind("w", c.Marshal())
+}
+
+// deriveInnerProductBase binds the statement to the transcript and returns
+// w·U, where w is the first challenge.
+func deriveInnerProductBase(fs *fiatshamir.Transcript, setup *Setup, commitment *curve.G1Affine, c *fr.Element, dataTranscript [][]byte) (curve.G1Affine, error) {
+	if err := bindInnerProductBase(fs, commitment, c, dataTranscript); err != nil {
+		return curve.G1Affine{}, err
+	}
+	var w fr.Element
+	if err := deriveChallenge(fs, "w", &w); err != nil {
+		return curve.G1Affine{}, err
+	}
+	var u curve.G1Affine
+	var bW big.Int
+	u.ScalarMultiplication(&setup.U, w.BigInt(&bW))
+	return u, nil
+}
+
+// proveInnerProduct runs the folding rounds of the inner product argument for
+// P = ⟨a,G⟩ + ⟨b,H⟩ + ⟨a,b⟩·u. The first round challenge must be the next one
+// to be computed in fs. a and b are not modified.
+func proveInnerProduct(fs *fiatshamir.Transcript, G, H []curve.G1Affine, u *curve.G1Affine, a, b []fr.Element) (InnerProductProof, error) {
+	n := len(a)
+	k := bits.TrailingZeros(uint(n))
+	names := roundChallenges(k)
+	proof := InnerProductProof{
+		L: make([]curve.G1Affine, k),
+		R: make([]curve.G1Affine, k),
+	}
+
+	a = append([]fr.Element(nil), a...)
+	b = append([]fr.Element(nil), b...)
+	points := make([]curve.G1Affine, n+1)
+	scalars := make([]fr.Element, n+1)
+
+	var x, xInv fr.Element
+	for j := 0; n > 1; j++ {
+		n /= 2
+		aLo, aHi, bLo, bHi := a[:n], a[n:], b[:n], b[n:]
+		gLo, gHi, hLo, hHi := G[:n], G[n:], H[:n], H[n:]
+
+		// L = ⟨a_lo,G_hi⟩ + ⟨b_hi,H_lo⟩ + ⟨a_lo,b_hi⟩·u
+		copy(points, gHi)
+		copy(points[n:], hLo)
+		points[2*n] = *u
+		copy(scalars, aLo)
+		copy(scalars[n:], bHi)
+		scalars[2*n] = innerProduct(aLo, bHi)
+		if err := multiExp(&proof.L[j], points[:2*n+1], scalars[:2*n+1]); err != nil {
+			return InnerProductProof{}, err
+		}
+
+		// R = ⟨a_hi,G_lo⟩ + ⟨b_lo,H_hi⟩ + ⟨a_hi,b_lo⟩·u
+		copy(points, gLo)
+		copy(points[n:], hHi)
+		copy(scalars, aHi)
+		copy(scalars[n:], bLo)
+		scalars[2*n] = innerProduct(aHi, bLo)
+		if err := multiExp(&proof.R[j], points[:2*n+1], scalars[:2*n+1]); err != nil {
+			return InnerProductProof{}, err
+		}
+
+		bL, bR := proof.L[j].RawBytes(), proof.R[j].RawBytes()
+		if err := fs.Bind(names[j], bL[:]); err != nil {
+			return InnerProductProof{}, err
+		}
+		if err := fs.Bind(names[j], bR[:]); err != nil {
+			return InnerProductProof{}, err
+		}
+		if err := deriveChallenge(fs, names[j], &x); err != nil {
+			return InnerProductProof{}, err
+		}
+		xInv.Inverse(&x)
+
+		// a' = x·a_lo + x⁻¹·a_hi, b' = x⁻¹·b_lo + x·b_hi
+		var t fr.Element
+		for i := 0; i < n; i++ {
+			aLo[i].Mul(&aLo[i], &x)
+			t.Mul(&aHi[i], &xInv)
+			aLo[i].Add(&aLo[i], &t)
+			bLo[i].Mul(&bLo[i], &xInv)
+			t.Mul(&bHi[i], &x)
+			bLo[i].Add(&bLo[i], &t)
+		}
+		a, b = aLo, bLo
+
+		// G' = x⁻¹·G_lo + x·G_hi, H' = x·H_lo + x⁻¹·H_hi
+		if n > 1 {
+			G = foldGenerators(gLo, gHi, &xInv, &x)
+			H = foldGenerators(hLo, hHi, &x, &xInv)
+		}
+	}
+
+	proof.A, proof.B = a[0], b[0]
+	return proof, nil
+}
+
+// innerProductRoundChallenges binds the pairs (L, R) of the proof to the
+// transcript and returns the round challenges and their inverses.
+func innerProductRoundChallenges(fs *fiatshamir.Transcript, proof *InnerProductProof) (x, xInv []fr.Element, err error) {
+	names := roundChallenges(len(proof.L))
+	x = make([]fr.Element, len(names))
+	for j := range names {
+		bL, bR := proof.L[j].RawBytes(), proof.R[j].RawBytes()
+		if err = fs.Bind(names[j], bL[:]); err != nil {
+			return
+		}
+		if err = fs.Bind(names[j], bR[:]); err != nil {
+			return
+		}
+		if err = deriveChallenge(fs, names[j], &x[j]); err != nil {
+			return
+		}
+	}
+	xInv = fr.BatchInvert(x)
+	return
+}
+
+// innerProductScalars returns the vector s such that the generators folded by
+// the prover are ⟨s,G⟩ and ⟨s⁻¹,H⟩, with s⁻¹ᵢ = s_{n-1-i}. sᵢ is the product of
+// the xⱼ or xⱼ⁻¹ depending on whether the bit k-1-j of i is set.
+func innerProductScalars(x, xInv []fr.Element) []fr.Element {
+	k := len(x)
+	s := make([]fr.Element, 1<<k)
+	s[0].SetOne()
+	for j := range xInv {
+		s[0].Mul(&s[0], &xInv[j])
+	}
+	xSquare := make([]fr.Element, k)
+	for j := range x {
+		xSquare[j].Square(&x[j])
+	}
+	for i := 1; i < len(s); i++ {
+		lg := bits.Len(uint(i)) - 1
+		s[i].Mul(&s[i-(1<<lg)], &xSquare[k-1-lg])
+	}
+	return s
+}
+
+// appendRoundTerms appends xⱼ²·Lⱼ + xⱼ⁻²·Rⱼ to the multi-exponentiation.
+func appendRoundTerms(points []curve.G1Affine, scalars []fr.Element, proof *InnerProductProof, x, xInv []fr.Element) ([]curve.G1Affine, []fr.Element) {
+	var tmp fr.Element
+	for j := range x {
+		points = append(points, proof.L[j], proof.R[j])
+		scalars = append(scalars, *tmp.Square(&x[j]))
+		scalars = append(scalars, *tmp.Square(&xInv[j]))
+	}
+	return points, scalars
+}
+
+// foldGenerators returns a·lo + b·hi.
+func foldGenerators(lo, hi []curve.G1Affine, a, b *fr.Element) []curve.G1Affine {
+	res := make([]curve.G1Jac, len(lo))
+	var bA, bB big.Int
+	a.BigInt(&bA)
+	b.BigInt(&bB)
+	parallel.Execute(len(lo), func(start, end int) {
+		var t curve.G1Jac
+		for i := start; i < end; i++ {
+			res[i].ScalarMultiplicationAffine(&lo[i], &bA)
+			t.ScalarMultiplicationAffine(&hi[i], &bB)
+			res[i].AddAssign(&t)
+		}
+	})
+	return curve.BatchJacobianToAffineG1(res)
+}
+
+// scaleGenerators returns (sᵢ·Gᵢ)ᵢ.
+func scaleGenerators(G []curve.G1Affine, s []fr.Element) []curve.G1Affine {
+	res := make([]curve.G1Jac, len(G))
+	parallel.Execute(len(G), func(start, end int) {
+		var b big.Int
+		for i := start; i < end; i++ {
+			res[i].ScalarMultiplicationAffine(&G[i], s[i].BigInt(&b))
+		}
+	})
+	return curve.BatchJacobianToAffineG1(res)
+}
+
+// multiExp sets res to ⟨scalars,points⟩.
+func multiExp(res *curve.G1Affine, points []curve.G1Affine, scalars []fr.Element) error {
+	_, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	return err
+}
+
+// deriveChallenge computes the challenge named id and reduces it in fr.
+func deriveChallenge(fs *fiatshamir.Transcript, id string, res *fr.Element) error {
+	b, err := fs.ComputeChallenge(id)
+	if err != nil {
+		return err
+	}
+	res.SetBytes(b)
+	if res.IsZero() {
+		return ErrZeroChallenge
+	}
+	return nil
+}
+
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, tmp fr.Element
+	for i := range a {
+		tmp.Mul(&a[i], &b[i])
+		res.Add(&res, &tmp)
+	}
+	return res
+}
@@ -0,0 +1,519 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrInvalidNbBits   = errors.New("the number of bits must be a power of two between 1 and 64")
+	ErrValueOutOfRange = errors.New("value out of range")
+	ErrVerifyRange     = errors.New("range proof verification failed")
+	ErrZeroNbProofs    = errors.New("no proof to verify")
+	ErrNbCommitments   = errors.New("the number of commitments must be a non zero power of two")
+)
+
+// RangeProof is an aggregated proof that m committed values lie in [0, 2ⁿ).
+// Its size is 2·log₂(n·m)+4 points and 5 field elements.
+type RangeProof struct {
+	// A commits to the bits of the values, S to the blinding vectors.
+	A, S curve.G1Affine
+
+	// T1, T2 commit to the coefficients of the polynomial t(X) = ⟨l(X),r(X)⟩.
+	T1, T2 curve.G1Affine
+
+	// TauX and Mu are the blindings of t(x) and of ⟨l,G⟩ + ⟨r,H'⟩, T is t(x).
+	TauX, Mu, T fr.Element
+
+	// IPA proves that T = ⟨l,r⟩.
+	IPA InnerProductProof
+}
+
+// ProveRange proves that values are all in [0, 2^nbBits). It returns the proof
+// and the commitments values[j]·B + blindings[j]·BlindingB the proof is
+// checked against.
+//
+// nbBits must be a power of two no larger than 64 and the number of values a
+// power of two, with nbBits·len(values) not larger than the setup.
+//
+// hf is the hash function used for the fiat-shamir challenges, dataTranscript
+// is bound to the first challenge.
+func ProveRange(setup *Setup, values []uint64, blindings []fr.Element, nbBits int, hf hash.Hash, dataTranscript ...[]byte) (RangeProof, []curve.G1Affine, error) {
+	m := len(values)
+	if m != len(blindings) {
+		return RangeProof{}, nil, ErrLengthMismatch
+	}
+	if err := checkRangeSize(setup, m, nbBits); err != nil {
+		return RangeProof{}, nil, err
+	}
+	N := nbBits * m
+	for _, v := range values {
+		if nbBits < 64 && v>>nbBits != 0 {
+			return RangeProof{}, nil, ErrValueOutOfRange
+		}
+	}
+
+	commitments := make([]curve.G1Affine, m)
+	for j := range values {
+		var v fr.Element
+		v.SetUint64(values[j])
+		var err error
+		if commitments[j], err = setup.Commit(&v, &blindings[j]); err != nil {
+			return RangeProof{}, nil, err
+		}
+	}
+
+	var proof RangeProof
+
+	// aL holds the bits of the values and aR = aL - 1
+	aL := make([]fr.Element, N)
+	aR := make([]fr.Element, N)
+	minusOne := fr.One()
+	minusOne.Neg(&minusOne)
+	for j := range values {
+		for i := 0; i < nbBits; i++ {
+			if (values[j]>>i)&1 == 1 {
+				aL[j*nbBits+i].SetOne()
+			} else {
+				aR[j*nbBits+i] = minusOne
+			}
+		}
+	}
+
+	// the blindings of the prover
+	sL := make([]fr.Element, N)
+	sR := make([]fr.Element, N)
+	for i := range sL {
+		if _, err := sL[i].SetRandom(); err != nil {
+			return RangeProof{}, nil, err
+		}
+		if _, err := sR[i].SetRandom(); err != nil {
+			return RangeProof{}, nil, err
+		}
+	}
+	var alpha, rho, tau1, tau2 fr.Element
+	for _, r := range []*fr.Element{&alpha, &rho, &tau1, &tau2} {
+		if _, err := r.SetRandom(); err != nil {
+			return RangeProof{}, nil, err
+		}
+	}
+
+	// A = ⟨aL,G⟩ + ⟨aR,H⟩ + α·BlindingB, S = ⟨sL,G⟩ + ⟨sR,H⟩ + ρ·BlindingB
+	points := make([]curve.G1Affine, 0, 2*N+1)
+	points = append(points, setup.G[:N]...)
+	points = append(points, setup.H[:N]...)
+	points = append(points, setup.BlindingB)
+	scalars := make([]fr.Element, 0, 2*N+1)
+	scalars = append(append(append(scalars, aL...), aR...), alpha)
+	if err := multiExp(&proof.A, points, scalars); err != nil {
+		return RangeProof{}, nil, err
+	}
+	scalars = append(append(append(scalars[:0], sL...), sR...), rho)
+	if err := multiExp(&proof.S, points, scalars); err != nil {
+		return RangeProof{}, nil, err
+	}
+
+	fs := fiatshamir.NewTranscript(hf, rangeChallenges(N)...)
+	var y, z fr.Element
+	if err := bindRangeStatement(&fs, commitments, nbBits, &proof, dataTranscript); err != nil {
+		return RangeProof{}, nil, err
+	}
+	if err := deriveChallenge(&fs, "y", &y); err != nil {
+		return RangeProof{}, nil, err
+	}
+	if err := deriveChallenge(&fs, "z", &z); err != nil {
+		return RangeProof{}, nil, err
+	}
+
+	// l(X) = l0 + l1·X with l0 = aL - z·1, l1 = sL
+	// r(X) = r0 + r1·X with r0 = yᴺ∘(aR + z·1) + ∑ⱼ z^{2+j}·(0,...,2ⁿ,...,0), r1 = yᴺ∘sR
+	l0 := aL
+	for i := range l0 {
+		l0[i].Sub(&l0[i], &z)
+	}
+	r0 := aR
+	r1 := sR
+	twos := powersOfTwoTimesZ(&z, nbBits, m)
+	var yi fr.Element
+	yi.SetOne()
+	for i := range r0 {
+		r0[i].Add(&r0[i], &z).Mul(&r0[i], &yi).Add(&r0[i], &twos[i])
+		r1[i].Mul(&r1[i], &yi)
+		yi.Mul(&yi, &y)
+	}
+	l1 := sL
+
+	// t(X) = t0 + t1·X + t2·X²
+	var t1, t2 fr.Element
+	t1 = innerProduct(l0, r1)
+	tmp := innerProduct(l1, r0)
+	t1.Add(&t1, &tmp)
+	t2 = innerProduct(l1, r1)
+
+	var err error
+	if proof.T1, err = setup.Commit(&t1, &tau1); err != nil {
+		return RangeProof{}, nil, err
+	}
+	if proof.T2, err = setup.Commit(&t2, &tau2); err != nil {
+		return RangeProof{}, nil, err
+	}
+
+	var x fr.Element
+	bT1, bT2 := proof.T1.RawBytes(), proof.T2.RawBytes()
+	if err = fs.Bind("x", bT1[:]); err != nil {
+		return RangeProof{}, nil, err
+	}
+	if err = fs.Bind("x", bT2[:]); err != nil {
+		return RangeProof{}, nil, err
+	}
+	if err = deriveChallenge(&fs, "x", &x); err != nil {
+		return RangeProof{}, nil, err
+	}
+
+	// l = l(x), r = r(x), T = ⟨l,r⟩
+	l, r := l0, r0
+	for i := range l {
+		tmp.Mul(&l1[i], &x)
+		l[i].Add(&l[i], &tmp)
+		tmp.Mul(&r1[i], &x)
+		r[i].Add(&r[i], &tmp)
+	}
+	proof.T = innerProduct(l, r)
+
+	// TauX = τ2·x² + τ1·x + ∑ⱼ z^{2+j}·γⱼ and Mu = α + ρ·x
+	proof.TauX.Mul(&tau2, &x).Add(&proof.TauX, &tau1).Mul(&proof.TauX, &x)
+	var zj fr.Element
+	zj.Square(&z)
+	for j := range blindings {
+		tmp.Mul(&zj, &blindings[j])
+		proof.TauX.Add(&proof.TauX, &tmp)
+		zj.Mul(&zj, &z)
+	}
+	proof.Mu.Mul(&rho, &x).Add(&proof.Mu, &alpha)
+
+	var w fr.Element
+	if err = bindRangeOpening(&fs, &proof); err != nil {
+		return RangeProof{}, nil, err
+	}
+	if err = deriveChallenge(&fs, "w", &w); err != nil {
+		return RangeProof{}, nil, err
+	}
+
+	// the inner product argument is run on H'ᵢ = y⁻ⁱ·Hᵢ and w·U
+	var u curve.G1Affine
+	var bW big.Int
+	u.ScalarMultiplication(&setup.U, w.BigInt(&bW))
+	var yInv fr.Element
+	yInv.Inverse(&y)
+	hPrime := scaleGenerators(setup.H[:N], powers(&yInv, N))
+
+	if proof.IPA, err = proveInnerProduct(&fs, setup.G[:N], hPrime, &u, l, r); err != nil {
+		return RangeProof{}, nil, err
+	}
+
+	return proof, commitments, nil
+}
+
+// VerifyRange checks that proof shows that the values committed to in
+// commitments are all in [0, 2^nbBits).
+func VerifyRange(setup *Setup, commitments []curve.G1Affine, nbBits int, proof *RangeProof, hf hash.Hash, dataTranscript ...[]byte) error {
+	return BatchVerifyRange(setup, [][]curve.G1Affine{commitments}, nbBits, []RangeProof{*proof}, hf, dataTranscript...)
+}
+
+// BatchVerifyRange checks several range proofs at once, proofs[i] being the
+// proof for commitments[i]. All the proofs must be for the same nbBits and
+// share the same dataTranscript, but may aggregate different numbers of
+// values. The proofs are folded with random coefficients and checked with a
+// single multi-scalar multiplication, so the error does not identify the
+// faulty proof.
+func BatchVerifyRange(setup *Setup, commitments [][]curve.G1Affine, nbBits int, proofs []RangeProof, hf hash.Hash, dataTranscript ...[]byte) error {
+	if len(proofs) == 0 {
+		return ErrZeroNbProofs
+	}
+	if len(proofs) != len(commitments) {
+		return ErrLengthMismatch
+	}
+
+	// sizes of the statements
+	maxN := 0
+	for i := range proofs {
+		m := len(commitments[i])
+		if err := checkRangeSize(setup, m, nbBits); err != nil {
+			return err
+		}
+		if len(proofs[i].IPA.L) != len(proofs[i].IPA.R) || 1<<len(proofs[i].IPA.L) != m*nbBits {
+			return ErrInvalidProof
+		}
+		if m*nbBits > maxN {
+			maxN = m * nbBits
+		}
+	}
+
+	// the first 2·maxN+3 terms of the multi-exponentiation are shared by all
+	// the proofs, on G, H, B, BlindingB and U.
+	nbShared := 2*maxN + 3
+	points := make([]curve.G1Affine, nbShared, nbShared+len(proofs)*(4+2*bits.Len(uint(maxN))+1))
+	copy(points, setup.G[:maxN])
+	copy(points[maxN:], setup.H[:maxN])
+	points[2*maxN], points[2*maxN+1], points[2*maxN+2] = setup.B, setup.BlindingB, setup.U
+	scalars := make([]fr.Element, nbShared, cap(points))
+
+	var coeff fr.Element
+	coeff.SetOne()
+	for i := range proofs {
+		var err error
+		if i > 0 {
+			if _, err = coeff.SetRandom(); err != nil {
+				return err
+			}
+		}
+		if points, scalars, err = appendRangeTerms(points, scalars, maxN, setup, commitments[i], nbBits, &proofs[i], &coeff, hf, dataTranscript); err != nil {
+			return err
+		}
+	}
+
+	var res curve.G1Affine
+	if err := multiExp(&res, points, scalars); err != nil {
+		return err
+	}
+	if !res.IsInfinity() {
+		return ErrVerifyRange
+	}
+	return nil
+}
+
+// appendRangeTerms adds coeff times the verification equation of a proof to
+// the multi-exponentiation. The verification equations are
+//
+//	T·B + TauX·BlindingB == ∑ⱼ z^{2+j}·Vⱼ + δ(y,z)·B + x·T1 + x²·T2
+//	A + x·S - z·⟨1,G⟩ + ⟨z·1 + y⁻ᴺ∘twos,H⟩ - Mu·BlindingB + T·w·U == P_IPA
+//
+// where P_IPA is the commitment the inner product argument opens. Both are
+// combined with a random coefficient.
+func appendRangeTerms(points []curve.G1Affine, scalars []fr.Element, maxN int, setup *Setup, commitments []curve.G1Affine, nbBits int, proof *RangeProof, coeff *fr.Element, hf hash.Hash, dataTranscript [][]byte) ([]curve.G1Affine, []fr.Element, error) {
+	m := len(commitments)
+	N := m * nbBits
+
+	fs := fiatshamir.NewTranscript(hf, rangeChallenges(N)...)
+	var y, z, x, w fr.Element
+	if err := bindRangeStatement(&fs, commitments, nbBits, proof, dataTranscript); err != nil {
+		return nil, nil, err
+	}
+	if err := deriveChallenge(&fs, "y", &y); err != nil {
+		return nil, nil, err
+	}
+	if err := deriveChallenge(&fs, "z", &z); err != nil {
+		return nil, nil, err
+	}
+	bT1, bT2 := proof.T1.RawBytes(), proof.T2.RawBytes()
+	if err := fs.Bind("x", bT1[:]); err != nil {
+		return nil, nil, err
+	}
+	if err := fs.Bind("x", bT2[:]); err != nil {
+		return nil, nil, err
+	}
+	if err := deriveChallenge(&fs, "x", &x); err != nil {
+		return nil, nil, err
+	}
+	if err := bindRangeOpening(&fs, proof); err != nil {
+		return nil, nil, err
+	}
+	if err := deriveChallenge(&fs, "w", &w); err != nil {
+		return nil, nil, err
+	}
+	xs, xInvs, err := innerProductRoundChallenges(&fs, &proof.IPA)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := innerProductScalars(xs, xInvs)
+
+	// c weighs the first equation against the second
+	var c fr.Element
+	if _, err := c.SetRandom(); err != nil {
+		return nil, nil, err
+	}
+	c.Mul(&c, coeff)
+
+	var yInv fr.Element
+	yInv.Inverse(&y)
+	yInvPowers := powers(&yInv, N)
+	twos := powersOfTwoTimesZ(&z, nbBits, m)
+
+	// G: -z - a·sᵢ, H: z + y⁻ⁱ·(twosᵢ - b·s_{N-1-i})
+	var tmp fr.Element
+	for i := 0; i < N; i++ {
+		tmp.Mul(&proof.IPA.A, &s[i]).Add(&tmp, &z).Neg(&tmp).Mul(&tmp, coeff)
+		scalars[i].Add(&scalars[i], &tmp)
+
+		tmp.Mul(&proof.IPA.B, &s[N-1-i]).Sub(&twos[i], &tmp).Mul(&tmp, &yInvPowers[i]).Add(&tmp, &z).Mul(&tmp, coeff)
+		scalars[maxN+i].Add(&scalars[maxN+i], &tmp)
+	}
+
+	// B: c·(T - δ(y,z))
+	delta := rangeDelta(&y, &z, nbBits, m)
+	tmp.Sub(&proof.T, &delta).Mul(&tmp, &c)
+	scalars[2*maxN].Add(&scalars[2*maxN], &tmp)
+
+	// BlindingB: c·TauX - Mu
+	var mu fr.Element
+	mu.Mul(&proof.Mu, coeff)
+	tmp.Mul(&proof.TauX, &c).Sub(&tmp, &mu)
+	scalars[2*maxN+1].Add(&scalars[2*maxN+1], &tmp)
+
+	// U: w·(T - a·b)
+	tmp.Mul(&proof.IPA.A, &proof.IPA.B).Sub(&proof.T, &tmp).Mul(&tmp, &w).Mul(&tmp, coeff)
+	scalars[2*maxN+2].Add(&scalars[2*maxN+2], &tmp)
+
+	// Vⱼ: -c·z^{2+j}
+	var zj fr.Element
+	zj.Square(&z)
+	for j := range commitments {
+		tmp.Mul(&zj, &c).Neg(&tmp)
+		points = append(points, commitments[j])
+		scalars = append(scalars, tmp)
+		zj.Mul(&zj, &z)
+	}
+
+	// T1: -c·x, T2: -c·x², A: 1, S: x
+	var cx, cx2, xc fr.Element
+	cx.Mul(&c, &x).Neg(&cx)
+	cx2.Mul(&cx, &x)
+	xc.Mul(&x, coeff)
+	points = append(points, proof.T1, proof.T2, proof.A, proof.S)
+	scalars = append(scalars, cx, cx2, *coeff, xc)
+
+	// Lⱼ: xⱼ², Rⱼ: xⱼ⁻²
+	nbScalars := len(scalars)
+	points, scalars = appendRoundTerms(points, scalars, &proof.IPA, xs, xInvs)
+	for i := nbScalars; i < len(scalars); i++ {
+		scalars[i].Mul(&scalars[i], coeff)
+	}
+
+	return points, scalars, nil
+}
+
+// rangeChallenges returns the names of the challenges of a range proof on N bits.
+func rangeChallenges(N int) []string {
+	return append([]string{"y", "z", "x"}, innerProductChallenges(bits.TrailingZeros(uint(N)))...)
+}
+
+func bindRangeStatement(fs *fiatshamir.Transcript, commitments []curve.G1Affine, nbBits int, proof *RangeProof, dataTranscript [][]byte) error {
+	for i := range dataTranscript {
+		if err := fs.Bind("y", dataTranscript[i]); err != nil {
+			return err
+		}
+	}
+	if err := fs.Bind("y", []byte{byte(nbBits)}); err != nil {
+		return err
+	}
+	for i := range commitments {
+		b := commitments[i].RawBytes()
+		if err := fs.Bind("y", b[:]); err != nil {
+			return err
+		}
+	}
+	bA, bS := proof.A.RawBytes(), proof.S.RawBytes()
+	if err := fs.Bind("y", bA[:]); err != nil {
+		return err
+	}
+	return fs.Bind("y", bS[:])
+}
+
+func bindRangeOpening(fs *fiatshamir.Transcript, proof *RangeProof) error {
+	for _, e := range []*fr.Element{&proof.TauX, &proof.Mu, &proof.T} {
+		if err := fs.Bind("w", e.Marshal()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRangeSize checks that m values on nbBits bits can be proven with setup.
+func checkRangeSize(setup *Setup, m, nbBits int) error {
+	if nbBits <= 0 || nbBits > 64 || bits.OnesCount(uint(nbBits)) != 1 {
+		return ErrInvalidNbBits
+	}
+	if m <= 0 || bits.OnesCount(uint(m)) != 1 {
+		return ErrNbCommitments
+	}
+	if m*nbBits > len(setup.G) || m*nbBits > len(setup.H) {
+		return ErrSetupTooSmall
+	}
+	return nil
+}
+
+// rangeDelta returns δ(y,z) = (z - z²)·⟨1,yᴺ⟩ - ∑ⱼ z^{3+j}·⟨1,2ⁿ⟩.
+func rangeDelta(y, z *fr.Element, nbBits, m int) fr.Element {
+	var sumY, yi fr.Element
+	yi.SetOne()
+	for i := 0; i < nbBits*m; i++ {
+		sumY.Add(&sumY, &yi)
+		yi.Mul(&yi, y)
+	}
+
+	var sumTwos, two fr.Element
+	two.SetOne()
+	for i := 0; i < nbBits; i++ {
+		sumTwos.Add(&sumTwos, &two)
+		two.Double(&two)
+	}
+
+	var zSquare, res, zj, sumZ fr.Element
+	zSquare.Square(z)
+	res.Sub(z, &zSquare).Mul(&res, &sumY)
+	zj.Mul(&zSquare, z)
+	for j := 0; j < m; j++ {
+		sumZ.Add(&sumZ, &zj)
+		zj.Mul(&zj, z)
+	}
+	sumZ.Mul(&sumZ, &sumTwos)
+	return *res.Sub(&res, &sumZ)
+}
+
+// powersOfTwoTimesZ returns the vector of size nbBits·m whose i-th entry is
+// z^{2+j}·2^k, for i = j·nbBits + k.
+func powersOfTwoTimesZ(z *fr.Element, nbBits, m int) []fr.Element {
+	res := make([]fr.Element, nbBits*m)
+	var zj fr.Element
+	zj.Square(z)
+	for j := 0; j < m; j++ {
+		res[j*nbBits] = zj
+		for k := 1; k < nbBits; k++ {
+			res[j*nbBits+k].Double(&res[j*nbBits+k-1])
+		}
+		zj.Mul(&zj, z)
+	}
+	return res
+}
+
+// powers returns [1, x, ..., xⁿ⁻¹].
+func powers(x *fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], x)
+	}
+	return res
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package griffin provides a hash function built on the Griffin permutation.
+//
+// The permutation acts on a state of 3 field elements and is used in a sponge of
+// rate 2 and capacity 1, targeting 128 bits of security. Its structure is taken from
+// "Horst meets Fluid-SPN: Griffin for Zero-Knowledge Applications" (ePrint 2022/403):
+// the exponent d is the smallest integer ≥ 3 coprime with r-1, the number of rounds
+// is bound by the Gröbner basis attack with a 20% margin, and the round constants
+// and the Horst parameters α, β are drawn from SHAKE128.
+//
+// As in the reference implementation of the paper's authors, SHAKE128 is seeded with
+// "Griffin" and the modulus as little endian 64-bit words, the round constants are
+// drawn first and α, β are then drawn non-zero and distinct until α²-4β is a
+// non-square. The sponge pads its inputs with a one and zeros up to a multiple of the rate.
+package griffin
@@ -0,0 +1,49 @@
+package griffin
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func elementOf(s string) (res fr.Element) {
+	if _, err := res.SetString(s); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// known-answer vectors computed with a transliteration of the reference
+// implementation of the authors of ePrint 2022/403
+func TestVectors(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	assert.Equal(5, params.D)
+	assert.Equal(12, params.NbRounds)
+	assert.Equal("20950244155795017333954742965657628047481163604901233004908207073969011285354", params.Alpha.String())
+	assert.Equal("3710185818436319233594998810848289882480745979515096857371562288200759554874", params.Beta.String())
+	assert.Equal("34128550609306794648855049790941029207260430992267281605932459023961690971527", params.RoundConstants[0].String())
+	assert.Equal("35780857593405893371916330187109234255729583537475686449856260508724718012888", params.RoundConstants[len(params.RoundConstants)-1].String())
+
+	state := [Width]fr.Element{elementOf("0"), elementOf("1"), elementOf("2")}
+	params.Permutation(&state)
+	assert.Equal("27379052990992335868007513827616442821891910747512806453448496790052721925738", state[0].String())
+	assert.Equal("24772506163846602410384726533562269659751910620421046453035480040071869301700", state[1].String())
+	assert.Equal("49516412382145609386411188123247611096923270094753177293676945877936768369921", state[2].String())
+
+	for _, v := range []struct {
+		in  []fr.Element
+		out [Rate]string
+	}{
+		{nil, [Rate]string{"32321052306370338167751543818959880164567415936991564554413922585358868812445", "47778579114972733683205916724842362765468842619136044555443329357854110232604"}},
+		{[]fr.Element{elementOf("1")}, [Rate]string{"45690250450557049086406210385368838579257820335234400225063114664642979195134", "43766354001120453915907967513787090162729803869545150528654062468613874255341"}},
+		{[]fr.Element{elementOf("1"), elementOf("2")}, [Rate]string{"10262935076269093800801480048163660915144271940017780912144840570621642527770", "27771712112059599912033406911373179947930579034259771432959775064589611692885"}},
+		{[]fr.Element{elementOf("1"), elementOf("2"), elementOf("3")}, [Rate]string{"51700565730168305523231735593035046900692611943232300863095235140599867356040", "46026349312362953560813683515680280036862765204995005640380720788553355753730"}},
+	} {
+		h := params.Hash(v.in)
+		for i := range h {
+			assert.Equal(v.out[i], h[i].String())
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package rescue
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func elementOf(s string) (res fr.Element) {
+	if _, err := res.SetString(s); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// known-answer vectors computed with a transliteration of rescue_prime.py, the
+// reference implementation of the Rescue-Prime specification
+func TestVectors(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	assert.Equal(5, params.Alpha)
+	assert.Equal(14, params.NbRounds)
+	assert.Equal("343", params.MDS[0][0].String())
+	assert.Equal("52435875175126190479447740508185965837690552500527637822603658699938580066914", params.MDS[2][1].String())
+	assert.Equal("35495817390819093545263349384941809089491580678942832859579453034368810736263", params.RoundConstants[0].String())
+	assert.Equal("966835047744911231490794763166379188555949592683359886287393788918898119684", params.RoundConstants[len(params.RoundConstants)-1].String())
+
+	state := [Width]fr.Element{elementOf("0"), elementOf("1"), elementOf("2")}
+	params.Permutation(&state)
+	assert.Equal("20837336434853470849910909576721791703386530727763098803394615300550680488910", state[0].String())
+	assert.Equal("25771045850287316209319297577315389859184751579565922583267218707663223737221", state[1].String())
+	assert.Equal("47778332175771177523183464148522719206884558815624567948365727904575578981390", state[2].String())
+
+	for _, v := range []struct {
+		in  []fr.Element
+		out [Rate]string
+	}{
+		{nil, [Rate]string{"45993037853272783328790042058527048059282173798497580423926667558766040168892", "187574453274371882323712298204892413834887742756496940869597550275281091251"}},
+		{[]fr.Element{elementOf("1")}, [Rate]string{"41011951273150345694468419471079630531820430334778639570623573903916367281048", "27437461783060567635524915951747000735905811939926409738399527223918703373163"}},
+		{[]fr.Element{elementOf("1"), elementOf("2")}, [Rate]string{"42303628707484006548021885135693419317605301127947247732044606598698050891451", "5568822378828862916683630056109958946823505663626286118631195740236217058843"}},
+		{[]fr.Element{elementOf("1"), elementOf("2"), elementOf("3")}, [Rate]string{"40680226227177108080022864259371398129751503830048925349945246715351252571203", "52234600216070409372878235358460013670280481519732786028962403590783223874653"}},
+	} {
+		h := params.Hash(v.in)
+		for i := range h {
+			assert.Equal(v.out[i], h[i].String())
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package anemoi
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+const (
+	securityLevel = 128
+
+	BlockSize = fr.Bytes // BlockSize size that Anemoi consumes
+
+	// decimal digits of π, used to derive the round constants
+	pi0 = "1415926535897932384626433832795028841971693993751058209749445923078164062862"
+	pi1 = "0899862803482534211706798214808651328230664709384460955058223172535940812848"
+)
+
+var (
+	defaultParams *Params
+	once          sync.Once
+)
+
+// Params of the Anemoi permutation with ℓ = 1
+type Params struct {
+	Alpha           int
+	alpha, alphaInv big.Int
+	NbRounds        int
+	G, Delta        fr.Element // g generates fr*, δ = g⁻¹
+	C, D            []fr.Element
+}
+
+// GetParams returns the Anemoi parameters for fr
+func GetParams() *Params {
+	once.Do(func() {
+		defaultParams = newParams()
+	})
+	return defaultParams
+}
+
+func newParams() *Params {
+	p := new(Params)
+
+	// smallest α ≥ 3 coprime with r-1, and its inverse mod r-1
+	var rMinusOne, gcd big.Int
+	rMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	p.Alpha = 3
+	for gcd.GCD(nil, nil, &rMinusOne, big.NewInt(int64(p.Alpha))).Cmp(big.NewInt(1)) != 0 {
+		p.Alpha++
+	}
+	p.alpha.SetInt64(int64(p.Alpha))
+	p.alphaInv.ModInverse(&p.alpha, &rMinusOne)
+
+	p.NbRounds = nbRounds(p.Alpha)
+
+	p.G.SetUint64(7)
+
+	p.Delta.Inverse(&p.G)
+
+	p.C = make([]fr.Element, p.NbRounds)
+	p.D = make([]fr.Element, p.NbRounds)
+	for r := range p.C {
+		// ℓ = 1: the only column is i = 0
+		p.C[r], p.D[r] = p.roundConstants(r, 0)
+	}
+	return p
+}
+
+// roundConstants returns the constants of the column i in the round r:
+// Cᵣ,ᵢ = g·(π₀ʳ)² + (π₀ʳ + π₁ⁱ)^α and Dᵣ,ᵢ = g·(π₁ⁱ)² + (π₀ʳ + π₁ⁱ)^α + δ
+func (p *Params) roundConstants(r, i int) (c, d fr.Element) {
+	var pi0r, pi1i, powAlpha, t fr.Element
+	var b big.Int
+	b.SetString(pi0, 10)
+	pi0r.SetBigInt(&b)
+	pi0r.Exp(pi0r, big.NewInt(int64(r)))
+	b.SetString(pi1, 10)
+	pi1i.SetBigInt(&b)
+	pi1i.Exp(pi1i, big.NewInt(int64(i)))
+
+	powAlpha.Add(&pi0r, &pi1i)
+	p.sbox(&powAlpha)
+
+	t.Square(&pi0r).Mul(&t, &p.G)
+	c.Add(&t, &powAlpha)
+	t.Square(&pi1i).Mul(&t, &p.G)
+	d.Add(&t, &powAlpha).Add(&d, &p.Delta)
+	return
+}
+
+// nbRounds returns the number of rounds for ℓ = 1: the smallest r such that
+// C(4r+κ, 2r)² ≥ 2^securityLevel, plus 2 rounds and a margin of min(5, ℓ+1) = 2,
+// and at least 8.
+func nbRounds(alpha int) int {
+	kappa := map[int]int64{3: 1, 5: 2, 7: 4, 9: 7, 11: 9}
+	k, ok := kappa[alpha]
+	if !ok {
+		panic(fmt.Errorf("no Anemoi round count known for α = %d", alpha))
+	}
+	var target big.Int
+	target.Lsh(big.NewInt(1), securityLevel)
+	r := 1
+	for ; ; r++ {
+		var b big.Int
+		b.Binomial(int64(4*r)+k, int64(2*r))
+		b.Mul(&b, &b)
+		if b.Cmp(&target) >= 0 {
+			break
+		}
+	}
+	r += 2 + 2
+	if r < 8 {
+		r = 8
+	}
+	return r
+}
+
+// sbox sets x = x^α
+func (p *Params) sbox(x *fr.Element) {
+	t := *x
+	switch p.Alpha {
+	case 3:
+		x.Square(&t).Mul(x, &t)
+	case 5:
+		x.Square(&t).Square(x).Mul(x, &t)
+	case 7:
+		var t2 fr.Element
+		t2.Square(&t)
+		x.Square(&t2).Mul(x, &t2).Mul(x, &t)
+	default:
+		x.Exp(t, &p.alpha)
+	}
+}
+
+// Permutation applies the Anemoi permutation to (x, y)
+func (p *Params) Permutation(x, y *fr.Element) {
+	for r := 0; r < p.NbRounds; r++ {
+		x.Add(x, &p.C[r])
+		y.Add(y, &p.D[r])
+		linear(x, y)
+		p.flystel(x, y)
+	}
+	linear(x, y)
+}
+
+// linear applies the pseudo-Hadamard transform
+func linear(x, y *fr.Element) {
+	y.Add(y, x)
+	x.Add(x, y)
+}
+
+// flystel applies the open Flystel: x -= g·y², y -= x^(1/α), x += g·y² + δ
+func (p *Params) flystel(x, y *fr.Element) {
+	var t fr.Element
+	t.Square(y).Mul(&t, &p.G)
+	x.Sub(x, &t)
+	t.Exp(*x, &p.alphaInv)
+	y.Sub(y, &t)
+	t.Square(y).Mul(&t, &p.G).Add(&t, &p.Delta)
+	x.Add(x, &t)
+}
+
+// Compress returns the Jive compression of x and y: x + y + u + v where
+// (u, v) is the image of (x, y) by the permutation.
+func (p *Params) Compress(x, y fr.Element) fr.Element {
+	u, v := x, y
+	p.Permutation(&u, &v)
+	var res fr.Element
+	res.Add(&x, &y).Add(&res, &u).Add(&res, &v)
+	return res
+}
+
+// Hash absorbs the inputs in x, the rate of the sponge, and returns x. As in the
+// reference sponge, the inputs are padded only when their number is not a
+// multiple of the rate, which never happens with a rate of 1.
+func (p *Params) Hash(inputs []fr.Element) fr.Element {
+	var x, y fr.Element
+	for i := range inputs {
+		x.Add(&x, &inputs[i])
+		p.Permutation(&x, &y)
+	}
+	return x
+}
+
+// digest accumulates the field elements to hash
+type digest struct {
+	data   []fr.Element
+	params *Params
+}
+
+// NewAnemoi returns an Anemoi hash.Hash, the sponge of rate 1.
+func NewAnemoi() hash.Hash {
+	return &digest{params: GetParams()}
+}
+
+// Reset resets the Hash to its initial state.
+func (d *digest) Reset() {
+	d.data = d.data[:0]
+}
+
+// Sum appends the hash of the data written so far to b.
+// It does not change the underlying hash state.
+func (d *digest) Sum(b []byte) []byte {
+	h := d.params.Hash(d.data)
+	bytes := h.Bytes()
+	return append(b, bytes[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest) Size() int {
+	return BlockSize
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+//
+// Each []byte block of size BlockSize represents a big endian fr.Element. If len(p)
+// is not a multiple of BlockSize or any of the blocks represents an integer larger
+// than fr.Modulus, this function returns an error.
+func (d *digest) Write(p []byte) (int, error) {
+	if len(p)%BlockSize != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	elems := make([]fr.Element, len(p)/BlockSize)
+	for i := range elems {
+		var err error
+		if elems[i], err = fr.BigEndian.Element((*[BlockSize]byte)(p[i*BlockSize : (i+1)*BlockSize])); err != nil {
+			return 0, err
+		}
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+// WriteElements adds field elements to the running hash.
+func (d *digest) WriteElements(elems ...fr.Element) {
+	d.data = append(d.data, elems...)
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bulletproofs
+
+import (
+	"crypto/sha256"
+	"math"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/stretchr/testify/require"
+)
+
+// Test setup re-used across tests
+var testSetup Setup
+
+func init() {
+	var err error
+	testSetup, err = NewSetup(64, []byte("bulletproofs test"))
+	if err != nil {
+		panic(err)
+	}
+}
+
+func randomVector(t *testing.T, size int) []fr.Element {
+	res := make([]fr.Element, size)
+	for i := range res {
+		_, err := res[i].SetRandom()
+		require.NoError(t, err)
+	}
+	return res
+}
+
+func TestSetup(t *testing.T) {
+	assert := require.New(t)
+
+	// the setup is deterministic
+	s, err := NewSetup(8, []byte("bulletproofs test"))
+	assert.NoError(err)
+	assert.Equal(testSetup.G[:8], s.G)
+	assert.Equal(testSetup.H[:8], s.H)
+	assert.False(s.G[0].Equal(&s.H[0]))
+
+	_, err = NewSetup(12, nil)
+	assert.ErrorIs(err, ErrInvalidSize)
+}
+
+func TestInnerProduct(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{1, 2, 16, 64} {
+		a, b := randomVector(t, n), randomVector(t, n)
+		commitment, err := testSetup.CommitVectors(a, b)
+		assert.NoError(err)
+		c := innerProduct(a, b)
+
+		proof, err := ProveInnerProduct(&testSetup, a, b, sha256.New(), []byte("data"))
+		assert.NoError(err)
+		assert.NoError(VerifyInnerProduct(&testSetup, &commitment, &c, &proof, sha256.New(), []byte("data")))
+
+		// wrong inner product
+		var wrong fr.Element
+		wrong.SetOne().Add(&wrong, &c)
+		assert.ErrorIs(VerifyInnerProduct(&testSetup, &commitment, &wrong, &proof, sha256.New(), []byte("data")), ErrVerifyInnerProduct)
+
+		// wrong transcript, without folding rounds the vectors are sent in clear
+		if n > 1 {
+			assert.Error(VerifyInnerProduct(&testSetup, &commitment, &c, &proof, sha256.New(), []byte("other data")))
+		}
+	}
+
+	_, err := ProveInnerProduct(&testSetup, randomVector(t, 3), randomVector(t, 3), sha256.New())
+	assert.ErrorIs(err, ErrInvalidSize)
+	_, err = ProveInnerProduct(&testSetup, randomVector(t, 128), randomVector(t, 128), sha256.New())
+	assert.ErrorIs(err, ErrSetupTooSmall)
+}
+
+func TestRangeProof(t *testing.T) {
+	assert := require.New(t)
+
+	for _, tc := range []struct {
+		values []uint64
+		nbBits int
+	}{
+		{[]uint64{0}, 1},
+		{[]uint64{1}, 1},
+		{[]uint64{200}, 8},
+		{[]uint64{math.MaxUint64}, 64},
+		{[]uint64{3, 60000, 0, 65535}, 16},
+		{[]uint64{7, 1 << 31}, 32},
+	} {
+		blindings := randomVector(t, len(tc.values))
+		proof, commitments, err := ProveRange(&testSetup, tc.values, blindings, tc.nbBits, sha256.New())
+		assert.NoError(err)
+		for j := range commitments {
+			var v fr.Element
+			v.SetUint64(tc.values[j])
+			expected, err := testSetup.Commit(&v, &blindings[j])
+			assert.NoError(err)
+			assert.True(expected.Equal(&commitments[j]))
+		}
+		assert.NoError(VerifyRange(&testSetup, commitments, tc.nbBits, &proof, sha256.New()))
+
+		// tampered proof
+		wrong := proof
+		wrong.T.SetOne()
+		assert.ErrorIs(VerifyRange(&testSetup, commitments, tc.nbBits, &wrong, sha256.New()), ErrVerifyRange)
+
+		// wrong commitment
+		wrongCommitments := append([]curve.G1Affine(nil), commitments...)
+		wrongCommitments[0].Add(&wrongCommitments[0], &testSetup.B)
+		assert.ErrorIs(VerifyRange(&testSetup, wrongCommitments, tc.nbBits, &proof, sha256.New()), ErrVerifyRange)
+	}
+
+	blindings := randomVector(t, 2)
+	_, _, err := ProveRange(&testSetup, []uint64{1, 256}, blindings, 8, sha256.New())
+	assert.ErrorIs(err, ErrValueOutOfRange)
+	_, _, err = ProveRange(&testSetup, []uint64{1, 2}, blindings, 12, sha256.New())
+	assert.ErrorIs(err, ErrInvalidNbBits)
+	_, _, err = ProveRange(&testSetup, []uint64{1, 2, 3}, randomVector(t, 3), 8, sha256.New())
+	assert.ErrorIs(err, ErrNbCommitments)
+	_, _, err = ProveRange(&testSetup, []uint64{1, 2}, blindings, 64, sha256.New())
+	assert.ErrorIs(err, ErrSetupTooSmall)
+}
+
+func TestBatchVerifyRange(t *testing.T) {
+	assert := require.New(t)
+
+	const nbBits = 16
+	var proofs []RangeProof
+	var commitments [][]curve.G1Affine
+	values := [][]uint64{
+		{1},
+		{2, 3},
+		{4, 5, 6, 7},
+		{65535},
+	}
+	for i := range values {
+		proof, c, err := ProveRange(&testSetup, values[i], randomVector(t, len(values[i])), nbBits, sha256.New())
+		assert.NoError(err)
+		proofs = append(proofs, proof)
+		commitments = append(commitments, c)
+	}
+	assert.NoError(BatchVerifyRange(&testSetup, commitments, nbBits, proofs, sha256.New()))
+
+	proofs[2].Mu.SetOne()
+	assert.ErrorIs(BatchVerifyRange(&testSetup, commitments, nbBits, proofs, sha256.New()), ErrVerifyRange)
+
+	assert.ErrorIs(BatchVerifyRange(&testSetup, nil, nbBits, nil, sha256.New()), ErrZeroNbProofs)
+	assert.ErrorIs(BatchVerifyRange(&testSetup, commitments[:1], nbBits, proofs[1:2], sha256.New()), ErrInvalidProof)
+}
+
+func BenchmarkProveRange(b *testing.B) {
+	blindings := make([]fr.Element, 1)
+	blindings[0].SetRandom()
+	values := []uint64{42}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProveRange(&testSetup, values, blindings, 64, sha256.New())
+	}
+}
+
+func BenchmarkVerifyRange(b *testing.B) {
+	blindings := make([]fr.Element, 1)
+	blindings[0].SetRandom()
+	proof, commitments, _ := ProveRange(&testSetup, []uint64{42}, blindings, 64, sha256.New())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyRange(&testSetup, commitments, 64, &proof, sha256.New())
+	}
+}
@@ -14,5 +14,10 @@
 
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
-// Package mimc provides MiMC hash function using Miyaguchi–Preneel construction.
+// Package mimc provides MiMC hash function using Miyaguchi–Preneel construction,
+// and the MiMC-Feistel sponge (compatible with circomlib's MiMCSponge).
+//
+// The number of rounds, the exponent and the seed the round constants are derived from
+// can be customized with options; the raw encryption and Feistel permutation are
+// exposed through Params.
 package mimc
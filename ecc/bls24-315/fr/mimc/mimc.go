@@ -18,6 +18,7 @@ package mimc
 
 import (
 	"errors"
+	"fmt"
 	"hash"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
@@ -28,21 +29,256 @@ import (
 
 const (
 	mimcNbRounds = 109
-	seed         = "seed"   // seed to derive the constants
-	BlockSize    = fr.Bytes // BlockSize size that mimc consumes
+	mimcExponent = 5
+	seed         = "seed"            // seed to derive the constants
+	BlockSize    = fr.Bytes          // BlockSize size that mimc consumes
+	ChunkSize    = (fr.Bits - 1) / 8 // ChunkSize number of bytes per field element with ChunkPadding
+
+	// parameters of circomlib's MiMCSponge
+	spongeSeed     = "mimcsponge"
+	spongeNbRounds = 220
+	spongeExponent = 5
 )
 
 // Params constants for the mimc hash function
 var (
-	mimcConstants [mimcNbRounds]fr.Element
+	defaultParams *Params
 	once          sync.Once
 )
 
+// Params of a MiMC instance: the round function is x ↦ (x+k+cᵢ)^Exponent, the
+// cᵢ being the round constants.
+type Params struct {
+	Constants []fr.Element
+	Exponent  int
+}
+
+// Padding defines how the Write method of a hash interprets its input
+type Padding uint8
+
+const (
+	// NoPadding is the default: the input of Write must be a concatenation of
+	// big endian, canonical encodings of field elements, of BlockSize bytes each.
+	NoPadding Padding = iota
+
+	// ChunkPadding accepts arbitrary byte streams. The bytes written are split
+	// in chunks of ChunkSize bytes, each read as a big endian integer, which is
+	// always smaller than the modulus. The stream is padded with a 0x80 byte
+	// followed by zeros up to a multiple of ChunkSize, when Sum is called or
+	// field elements are written. The padding is always applied once bytes were
+	// written, so that distinct streams hash to distinct digests.
+	ChunkPadding
+)
+
+type config struct {
+	seed        string
+	nbRounds    int
+	exponent    int
+	exponentSet bool
+	padding     Padding
+	custom      bool // the parameters differ from the defaults
+}
+
+// Option customizes the parameters of a MiMC instance
+type Option func(*config)
+
+// WithSeed sets the seed the round constants are derived from
+func WithSeed(seed string) Option {
+	return func(c *config) {
+		c.seed = seed
+		c.custom = true
+	}
+}
+
+// WithNbRounds sets the number of rounds
+func WithNbRounds(nbRounds int) Option {
+	return func(c *config) {
+		c.nbRounds = nbRounds
+		c.custom = true
+	}
+}
+
+// WithPadding sets how the Write method of a hash interprets its input. It has
+// no effect on the parameters returned by NewParams and NewSpongeParams.
+func WithPadding(padding Padding) Option {
+	return func(c *config) {
+		c.padding = padding
+	}
+}
+
+// WithExponent sets the exponent of the round function. It must be coprime with
+// r-1, for the round function to be a permutation. This is not enforced on the
+// default exponents, kept as they are for backward compatibility.
+func WithExponent(exponent int) Option {
+	return func(c *config) {
+		c.exponent = exponent
+		c.exponentSet = true
+		c.custom = true
+	}
+}
+
+// NewParams returns the parameters of the Miyaguchi–Preneel MiMC hash. By default,
+// they are those used by NewMiMC without options: bls24-315 specific number of rounds
+// and exponent, and constants iteratively derived from seed "seed" with keccak256.
+func NewParams(opts ...Option) (*Params, error) {
+	conf := config{seed: seed, nbRounds: mimcNbRounds, exponent: mimcExponent}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	if err := conf.check(); err != nil {
+		return nil, err
+	}
+
+	// cᵢ = keccak256⁽ⁱ⁺²⁾(seed)
+	res := &Params{Constants: make([]fr.Element, conf.nbRounds), Exponent: conf.exponent}
+	rnd := keccak256([]byte(conf.seed))
+	for i := range res.Constants {
+		rnd = keccak256(rnd)
+		res.Constants[i].SetBytes(rnd)
+	}
+	return res, nil
+}
+
+// NewSpongeParams returns the parameters of the MiMC-Feistel permutation used by
+// the sponge. By default, they are those of circomlib's MiMCSponge: 220 rounds,
+// exponent 5 and constants derived from seed "mimcsponge" as
+// c₀ = 0, cᵢ = keccak256⁽ⁱ⁺¹⁾(seed), c₂₁₉ = 0.
+func NewSpongeParams(opts ...Option) (*Params, error) {
+	conf := config{seed: spongeSeed, nbRounds: spongeNbRounds, exponent: spongeExponent}
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	if err := conf.check(); err != nil {
+		return nil, err
+	}
+
+	res := &Params{Constants: make([]fr.Element, conf.nbRounds), Exponent: conf.exponent}
+	rnd := keccak256([]byte(conf.seed))
+	for i := 1; i+1 < conf.nbRounds; i++ {
+		rnd = keccak256(rnd)
+		res.Constants[i].SetBytes(rnd)
+	}
+	return res, nil
+}
+
+func (c *config) check() error {
+	if c.padding > ChunkPadding {
+		return fmt.Errorf("unknown padding %d", c.padding)
+	}
+	if c.nbRounds <= 0 {
+		return errors.New("the number of rounds must be positive")
+	}
+	if !c.exponentSet {
+		return nil
+	}
+	if c.exponent < 3 {
+		return errors.New("the exponent must be at least 3")
+	}
+	var gcd, rMinusOne big.Int
+	rMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	if gcd.GCD(nil, nil, &rMinusOne, big.NewInt(int64(c.exponent))).Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("x ↦ x^%d is not a permutation of fr", c.exponent)
+	}
+	return nil
+}
+
+func keccak256(b []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(b)
+	return h.Sum(nil)
+}
+
+// Encrypt returns the MiMC encryption of m with key k
+func (p *Params) Encrypt(m, k fr.Element) fr.Element {
+	var tmp fr.Element
+	for i := range p.Constants {
+		// m = (m+k+c)^e
+		tmp.Add(&m, &k).Add(&tmp, &p.Constants[i])
+		p.pow(&m, &tmp)
+	}
+	m.Add(&m, &k)
+	return m
+}
+
+// Permute applies the MiMC-Feistel permutation with key k to (xL, xR): each round
+// maps (xL, xR) to (xR + (xL+k+cᵢ)^e, xL), the last one without swapping.
+func (p *Params) Permute(xL, xR, k fr.Element) (fr.Element, fr.Element) {
+	var tmp fr.Element
+	for i := range p.Constants {
+		tmp.Add(&xL, &k).Add(&tmp, &p.Constants[i])
+		p.pow(&tmp, &tmp)
+		if i+1 < len(p.Constants) {
+			xR.Add(&xR, &tmp)
+			xL, xR = xR, xL
+		} else {
+			xR.Add(&xR, &tmp)
+		}
+	}
+	return xL, xR
+}
+
+// SpongeHash absorbs the inputs in the MiMC-Feistel sponge with key k and squeezes
+// nbOutputs elements, as circomlib's MiMCSponge.
+func (p *Params) SpongeHash(inputs []fr.Element, k fr.Element, nbOutputs int) []fr.Element {
+	var r, c fr.Element
+	for i := range inputs {
+		r.Add(&r, &inputs[i])
+		r, c = p.Permute(r, c, k)
+	}
+	res := make([]fr.Element, nbOutputs)
+	for i := range res {
+		if i != 0 {
+			r, c = p.Permute(r, c, k)
+		}
+		res[i] = r
+	}
+	return res
+}
+
+// pow sets z = x^e
+func (p *Params) pow(z, x *fr.Element) {
+	t := *x
+	switch p.Exponent {
+	case 3:
+		z.Square(&t).Mul(z, &t)
+	case 5:
+		z.Square(&t).Square(z).Mul(z, &t)
+	case 7:
+		var t2 fr.Element
+		t2.Square(&t)
+		z.Square(&t2).Mul(z, &t2).Mul(z, &t)
+	case 17:
+		z.Square(&t).Square(z).Square(z).Square(z).Mul(z, &t)
+	default:
+		z.Exp(t, big.NewInt(int64(p.Exponent)))
+	}
+}
+
+// FieldHasher is a hash.Hash that also accepts field elements directly,
+// as the hashes returned by NewMiMC and NewMiMCSponge.
+type FieldHasher interface {
+	hash.Hash
+
+	// WriteElements adds field elements to the running hash. With
+	// ChunkPadding, the bytes written before are padded first.
+	WriteElements(elems ...fr.Element)
+}
+
 // digest represents the partial evaluation of the checksum
 // along with the params of the mimc function
 type digest struct {
-	h    fr.Element
-	data []fr.Element // data to hash
+	h fr.Element
+	input
+	params *Params
+}
+
+// input holds the field elements written to a hash and not absorbed yet, and
+// with ChunkPadding, the bytes not forming a full chunk yet.
+type input struct {
+	data    []fr.Element // data to hash
+	padding Padding
+	bytes   []byte
+	pending bool // bytes were written since the last padding
 }
 
 // GetConstants exposed to be used in gnark
@@ -50,27 +286,54 @@ func GetConstants() []big.Int {
 	once.Do(initConstants) // init constants
 	res := make([]big.Int, mimcNbRounds)
 	for i := 0; i < mimcNbRounds; i++ {
-		mimcConstants[i].BigInt(&res[i])
+		defaultParams.Constants[i].BigInt(&res[i])
 	}
 	return res
 }
 
-// NewMiMC returns a MiMCImpl object, pure-go reference implementation
-func NewMiMC() hash.Hash {
-	d := new(digest)
+// NewMiMC returns a MiMCImpl object, pure-go reference implementation.
+// Without options, the parameters are the default ones of NewParams.
+// It panics if the options are invalid, see NewParams.
+func NewMiMC(opts ...Option) hash.Hash {
+	var conf config
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	if conf.padding > ChunkPadding {
+		panic(fmt.Errorf("unknown padding %d", conf.padding))
+	}
+	d := &digest{input: input{padding: conf.padding}}
+	if !conf.custom {
+		once.Do(initConstants) // init constants
+		d.params = defaultParams
+	} else {
+		params, err := NewParams(opts...)
+		if err != nil {
+			panic(err)
+		}
+		d.params = params
+	}
+	d.Reset()
+	return d
+}
+
+// NewMiMCWithParams returns the Miyaguchi–Preneel MiMC hash with the given parameters
+func NewMiMCWithParams(params *Params) hash.Hash {
+	d := &digest{params: params}
 	d.Reset()
 	return d
 }
 
 // Reset resets the Hash to its initial state.
 func (d *digest) Reset() {
-	d.data = d.data[:0]
-	d.h = fr.Element{0, 0, 0, 0}
+	d.input.reset()
+	d.h = fr.Element{}
 }
 
 // Sum appends the current hash to b and returns the resulting slice.
 // It does not change the underlying hash state.
 func (d *digest) Sum(b []byte) []byte {
+	d.pad()
 	buffer := d.checksum()
 	d.data = nil // flush the data already hashed
 	hash := buffer.Bytes()
@@ -98,24 +361,80 @@ func (d *digest) BlockSize() int {
 // If len(p) is not a multiple of BlockSize and any of the []byte in p represent an integer
 // larger than fr.Modulus, this function returns an error.
 //
-// To hash arbitrary data ([]byte not representing canonical field elements) use fr.Hash first
+// To hash arbitrary data ([]byte not representing canonical field elements) use fr.Hash first,
+// or the ChunkPadding mode, in which case Write accepts any input.
 func (d *digest) Write(p []byte) (int, error) {
+	return d.input.write(p)
+}
 
-	var start int
-	for start = 0; start < len(p); start += BlockSize {
-		if elem, err := fr.BigEndian.Element((*[BlockSize]byte)(p[start : start+BlockSize])); err == nil {
-			d.data = append(d.data, elem)
-		} else {
-			return 0, err
+// WriteElements adds field elements to the running hash, see FieldHasher
+func (d *digest) WriteElements(elems ...fr.Element) {
+	d.input.writeElements(elems)
+}
+
+func (in *input) write(p []byte) (int, error) {
+	if in.padding == ChunkPadding {
+		in.pending = true
+		in.bytes = append(in.bytes, p...)
+		nbChunks := len(in.bytes) / ChunkSize
+		for i := 0; i < nbChunks; i++ {
+			var x fr.Element
+			x.SetBytes(in.bytes[i*ChunkSize : (i+1)*ChunkSize])
+			in.data = append(in.data, x)
 		}
+		in.bytes = in.bytes[:copy(in.bytes, in.bytes[nbChunks*ChunkSize:])]
+		return len(p), nil
 	}
 
-	if start != len(p) {
-		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	elems, err := bytesToElements(p)
+	if err != nil {
+		return 0, err
 	}
+	in.data = append(in.data, elems...)
 	return len(p), nil
 }
 
+func (in *input) writeElements(elems []fr.Element) {
+	in.pad()
+	in.data = append(in.data, elems...)
+}
+
+// pad completes the pending bytes into a last chunk, see ChunkPadding
+func (in *input) pad() {
+	if !in.pending {
+		return
+	}
+	var chunk [ChunkSize]byte
+	copy(chunk[:], in.bytes)
+	chunk[len(in.bytes)] = 0x80
+	var x fr.Element
+	x.SetBytes(chunk[:])
+	in.data = append(in.data, x)
+	in.bytes = in.bytes[:0]
+	in.pending = false
+}
+
+func (in *input) reset() {
+	in.data = in.data[:0]
+	in.bytes = in.bytes[:0]
+	in.pending = false
+}
+
+func bytesToElements(p []byte) ([]fr.Element, error) {
+	if len(p)%BlockSize != 0 {
+		return nil, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	res := make([]fr.Element, 0, len(p)/BlockSize)
+	for start := 0; start < len(p); start += BlockSize {
+		elem, err := fr.BigEndian.Element((*[BlockSize]byte)(p[start : start+BlockSize]))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, elem)
+	}
+	return res, nil
+}
+
 // Hash hash using Miyaguchi-Preneel:
 // https://en.wikipedia.org/wiki/One-way_compression_function
 // The XOR operation is replaced by field addition, data is in Montgomery form
@@ -140,23 +459,12 @@ func (d *digest) checksum() fr.Element {
 // m: message
 // k: encryption key
 func (d *digest) encrypt(m fr.Element) fr.Element {
-	once.Do(initConstants) // init constants
-
-	var tmp fr.Element
-	for i := 0; i < mimcNbRounds; i++ {
-		// m = (m+k+c)^5
-		tmp.Add(&m, &d.h).Add(&tmp, &mimcConstants[i])
-		m.Square(&tmp).
-			Square(&m).
-			Mul(&m, &tmp)
-	}
-	m.Add(&m, &d.h)
-	return m
+	return d.params.Encrypt(m, d.h)
 }
 
 // Sum computes the mimc hash of msg from seed
 func Sum(msg []byte) ([]byte, error) {
-	var d digest
+	d := NewMiMC().(*digest)
 	if _, err := d.Write(msg); err != nil {
 		return nil, err
 	}
@@ -166,19 +474,9 @@ func Sum(msg []byte) ([]byte, error) {
 }
 
 func initConstants() {
-	bseed := ([]byte)(seed)
-
-	hash := sha3.NewLegacyKeccak256()
-	_, _ = hash.Write(bseed)
-	rnd := hash.Sum(nil) // pre hash before use
-	hash.Reset()
-	_, _ = hash.Write(rnd)
-
-	for i := 0; i < mimcNbRounds; i++ {
-		rnd = hash.Sum(nil)
-		mimcConstants[i].SetBytes(rnd)
-		hash.Reset()
-		_, _ = hash.Write(rnd)
+	var err error
+	if defaultParams, err = NewParams(); err != nil {
+		panic(err)
 	}
 }
 
@@ -187,6 +485,60 @@ func (d *digest) WriteString(rawBytes []byte) {
 	if elems, err := fr.Hash(rawBytes, []byte("string:"), 1); err != nil {
 		panic(err)
 	} else {
-		d.data = append(d.data, elems[0])
+		d.writeElements(elems)
 	}
 }
+
+// spongeDigest is the MiMC-Feistel sponge with key 0 and one output, as a hash.Hash
+type spongeDigest struct {
+	input
+	params *Params
+}
+
+// NewMiMCSponge returns the MiMC-Feistel sponge hash with a single output and key 0,
+// compatible with circomlib's MiMCSponge(nInputs, 220, 1) by default. The options
+// are those of NewSpongeParams. It panics if they are invalid.
+func NewMiMCSponge(opts ...Option) hash.Hash {
+	var conf config
+	for _, opt := range opts {
+		opt(&conf)
+	}
+	params, err := NewSpongeParams(opts...)
+	if err != nil {
+		panic(err)
+	}
+	return &spongeDigest{params: params, input: input{padding: conf.padding}}
+}
+
+// Write adds data, interpreted as in digest.Write, to the running hash.
+func (d *spongeDigest) Write(p []byte) (int, error) {
+	return d.input.write(p)
+}
+
+// WriteElements adds field elements to the running hash, see FieldHasher
+func (d *spongeDigest) WriteElements(elems ...fr.Element) {
+	d.input.writeElements(elems)
+}
+
+// Sum appends the hash of the data written so far to b
+func (d *spongeDigest) Sum(b []byte) []byte {
+	d.pad()
+	h := d.params.SpongeHash(d.data, fr.Element{}, 1)[0]
+	bytes := h.Bytes()
+	return append(b, bytes[:]...)
+}
+
+// Reset resets the Hash to its initial state.
+func (d *spongeDigest) Reset() {
+	d.input.reset()
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *spongeDigest) Size() int {
+	return BlockSize
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *spongeDigest) BlockSize() int {
+	return BlockSize
+}
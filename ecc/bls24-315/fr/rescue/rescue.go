@@ -0,0 +1,302 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package rescue
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// Width of the state
+	Width = 3
+	// Rate of the sponge
+	Rate          = Width - capacity
+	capacity      = 1
+	securityLevel = 128
+
+	BlockSize = fr.Bytes // BlockSize size that Rescue-Prime consumes
+)
+
+var (
+	defaultParams *Params
+	once          sync.Once
+)
+
+// Params of the Rescue-Prime permutation
+type Params struct {
+	Alpha           int
+	alpha, alphaInv big.Int
+	NbRounds        int
+	MDS             [Width][Width]fr.Element
+	// RoundConstants holds 2·Width constants per round
+	RoundConstants []fr.Element
+}
+
+// GetParams returns the Rescue-Prime parameters for fr
+func GetParams() *Params {
+	once.Do(func() {
+		defaultParams = newParams()
+	})
+	return defaultParams
+}
+
+func newParams() *Params {
+	p := new(Params)
+
+	// smallest α ≥ 3 coprime with r-1, and its inverse mod r-1
+	var rMinusOne, gcd big.Int
+	rMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	p.Alpha = 3
+	for gcd.GCD(nil, nil, &rMinusOne, big.NewInt(int64(p.Alpha))).Cmp(big.NewInt(1)) != 0 {
+		p.Alpha++
+	}
+	p.alpha.SetInt64(int64(p.Alpha))
+	p.alphaInv.ModInverse(&p.alpha, &rMinusOne)
+
+	p.NbRounds = nbRounds(p.Alpha)
+	p.MDS = mdsMatrix()
+	p.RoundConstants = roundConstants(p.NbRounds)
+	return p
+}
+
+// nbRounds returns the number of rounds resisting Gröbner basis attacks, with a
+// minimum of 5 rounds and a 50% security margin.
+func nbRounds(alpha int) int {
+	var target big.Int
+	target.Lsh(big.NewInt(1), securityLevel)
+	l1 := 1
+	for ; l1 < 25; l1++ {
+		dcon := int64((alpha-1)*Width*(l1-1)/2 + 2)
+		v := int64(Width*(l1-1) + Rate)
+		var b big.Int
+		b.Binomial(v+dcon, v)
+		b.Mul(&b, &b)
+		if b.Cmp(&target) > 0 {
+			break
+		}
+	}
+	if l1 < 5 {
+		l1 = 5
+	}
+	return (3*l1 + 1) / 2
+}
+
+// roundConstants returns 2·Width·nbRounds constants, read as little endian
+// integers from SHAKE256("Rescue-XLIX(r,Width,capacity,securityLevel)").
+func roundConstants(nbRounds int) []fr.Element {
+	bytesPerInt := (fr.Bits+7)/8 + 1
+	shake := sha3.NewShake256()
+	_, _ = fmt.Fprintf(shake, "Rescue-XLIX(%s,%d,%d,%d)", fr.Modulus().String(), Width, capacity, securityLevel)
+
+	res := make([]fr.Element, 2*Width*nbRounds)
+	buf := make([]byte, bytesPerInt)
+	var x big.Int
+	for i := range res {
+		_, _ = shake.Read(buf)
+		for j := 0; j < len(buf)/2; j++ {
+			buf[j], buf[len(buf)-1-j] = buf[len(buf)-1-j], buf[j]
+		}
+		res[i].SetBigInt(x.SetBytes(buf))
+	}
+	return res
+}
+
+// mdsMatrix returns the transpose of the right half of the reduced echelon form
+// of the Width×2·Width Vandermonde matrix (g^{i·j}), g generating fr*.
+func mdsMatrix() (res [Width][Width]fr.Element) {
+	var g fr.Element
+
+	g.SetUint64(7)
+
+	var v [Width][2 * Width]fr.Element
+	var gi fr.Element
+	gi.SetOne()
+	for i := range v {
+		v[i][0].SetOne()
+		for j := 1; j < 2*Width; j++ {
+			v[i][j].Mul(&v[i][j-1], &gi)
+		}
+		gi.Mul(&gi, &g)
+	}
+
+	// Gauss-Jordan elimination; the left half is invertible, being a Vandermonde matrix
+	for col := 0; col < Width; col++ {
+		pivot := col
+		for v[pivot][col].IsZero() {
+			pivot++
+		}
+		v[col], v[pivot] = v[pivot], v[col]
+		var inv fr.Element
+		inv.Inverse(&v[col][col])
+		for j := range v[col] {
+			v[col][j].Mul(&v[col][j], &inv)
+		}
+		for i := range v {
+			if i == col {
+				continue
+			}
+			factor := v[i][col]
+			for j := range v[i] {
+				var t fr.Element
+				t.Mul(&factor, &v[col][j])
+				v[i][j].Sub(&v[i][j], &t)
+			}
+		}
+	}
+
+	for i := 0; i < Width; i++ {
+		for j := 0; j < Width; j++ {
+			res[i][j] = v[j][Width+i]
+		}
+	}
+	return
+}
+
+// Permutation applies the Rescue-Prime permutation to the state
+func (p *Params) Permutation(state *[Width]fr.Element) {
+	for r := 0; r < p.NbRounds; r++ {
+		for i := range state {
+			p.sbox(&state[i])
+		}
+		p.mix(state, p.RoundConstants[2*Width*r:])
+		for i := range state {
+			state[i].Exp(state[i], &p.alphaInv)
+		}
+		p.mix(state, p.RoundConstants[2*Width*r+Width:])
+	}
+}
+
+// sbox sets x = x^α
+func (p *Params) sbox(x *fr.Element) {
+	t := *x
+	switch p.Alpha {
+	case 3:
+		x.Square(&t).Mul(x, &t)
+	case 5:
+		x.Square(&t).Square(x).Mul(x, &t)
+	case 7:
+		var t2 fr.Element
+		t2.Square(&t)
+		x.Square(&t2).Mul(x, &t2).Mul(x, &t)
+	default:
+		x.Exp(t, &p.alpha)
+	}
+}
+
+// mix multiplies the state by the MDS matrix and adds the constants
+func (p *Params) mix(state *[Width]fr.Element, constants []fr.Element) {
+	var res [Width]fr.Element
+	for i := range res {
+		res[i] = constants[i]
+		for j := range state {
+			var t fr.Element
+			t.Mul(&p.MDS[i][j], &state[j])
+			res[i].Add(&res[i], &t)
+		}
+	}
+	*state = res
+}
+
+// Hash absorbs the inputs in the sponge, padded with a one and zeros up to a
+// multiple of Rate, and returns the Rate elements squeezed.
+func (p *Params) Hash(inputs []fr.Element) [Rate]fr.Element {
+	padded := make([]fr.Element, len(inputs)+1, len(inputs)+Rate)
+	copy(padded, inputs)
+	padded[len(inputs)].SetOne()
+	for len(padded)%Rate != 0 {
+		padded = append(padded, fr.Element{})
+	}
+
+	var state [Width]fr.Element
+	for ; len(padded) != 0; padded = padded[Rate:] {
+		for i := 0; i < Rate; i++ {
+			state[i].Add(&state[i], &padded[i])
+		}
+		p.Permutation(&state)
+	}
+
+	var res [Rate]fr.Element
+	copy(res[:], state[:Rate])
+	return res
+}
+
+// digest accumulates the field elements to hash
+type digest struct {
+	data   []fr.Element
+	params *Params
+}
+
+// NewRescuePrime returns a Rescue-Prime hash.Hash. Its digest is the first
+// element squeezed from the sponge.
+func NewRescuePrime() hash.Hash {
+	return &digest{params: GetParams()}
+}
+
+// Reset resets the Hash to its initial state.
+func (d *digest) Reset() {
+	d.data = d.data[:0]
+}
+
+// Sum appends the hash of the data written so far to b.
+// It does not change the underlying hash state.
+func (d *digest) Sum(b []byte) []byte {
+	h := d.params.Hash(d.data)
+	bytes := h[0].Bytes()
+	return append(b, bytes[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest) Size() int {
+	return BlockSize
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+//
+// Each []byte block of size BlockSize represents a big endian fr.Element. If len(p)
+// is not a multiple of BlockSize or any of the blocks represents an integer larger
+// than fr.Modulus, this function returns an error.
+func (d *digest) Write(p []byte) (int, error) {
+	if len(p)%BlockSize != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	elems := make([]fr.Element, len(p)/BlockSize)
+	for i := range elems {
+		var err error
+		if elems[i], err = fr.BigEndian.Element((*[BlockSize]byte)(p[i*BlockSize : (i+1)*BlockSize])); err != nil {
+			return 0, err
+		}
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+// WriteElements adds field elements to the running hash.
+func (d *digest) WriteElements(elems ...fr.Element) {
+	d.data = append(d.data, elems...)
+}
@@ -0,0 +1,217 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package tbls
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+var (
+	ErrDKGState           = errors.New("distributed key generation rounds called out of order")
+	ErrNbMessages         = errors.New("wrong number of messages")
+	ErrInvalidCommitments = errors.New("commitments in G1 and G2 are inconsistent")
+	ErrInvalidShare       = errors.New("share doesn't match the commitments of its dealer")
+)
+
+// DKG is the state of a participant to the Joint-Feldman distributed key
+// generation (Pedersen, Eurocrypt 1991), in which every participant deals a
+// secret and the group secret is the sum of the dealt secrets. Each of the n
+// participants, identified by 1, …, n:
+//
+//  1. calls NewDKG and broadcasts the returned DKGRound1
+//  2. calls Round2 with the broadcast messages of all the participants and sends
+//     each of the returned DKGRound2 to its recipient only
+//  3. calls Finalize with the messages it received to get its KeyShare
+//
+// If Finalize returns ErrInvalidShare, the participants should agree on the
+// disqualification of the dealer before running the protocol again. The
+// commitments are given in both G1 and G2, so that the key can sign in either
+// group.
+type DKG struct {
+	id, threshold, n uint32
+	f                Polynomial
+	commitmentsG1    []CommitmentsG1 // indexed by dealer - 1
+	commitmentsG2    []CommitmentsG2
+}
+
+// DKGRound1 is the broadcast message of a dealer: the Feldman commitments to
+// its secret polynomial in G1 and G2.
+type DKGRound1 struct {
+	ID            uint32
+	CommitmentsG1 CommitmentsG1
+	CommitmentsG2 CommitmentsG2
+}
+
+// DKGRound2 is the share f_From(To) dealt to a participant. It must be sent
+// over a confidential and authenticated channel.
+type DKGRound2 struct {
+	From, To uint32
+	Share    fr.Element
+}
+
+// KeyShare is the key of a participant at the end of the distributed key
+// generation: its share of the group secret and the commitments to the group
+// polynomial ∑ fⱼ, which give the group public key and the public key shares.
+type KeyShare struct {
+	Share
+	Threshold     uint32
+	CommitmentsG1 CommitmentsG1
+	CommitmentsG2 CommitmentsG2
+}
+
+// NewDKG starts the distributed key generation of a threshold-out-of-n key for
+// the participant id. It returns the message to broadcast to the other
+// participants.
+func NewDKG(id, threshold, n uint32) (*DKG, *DKGRound1, error) {
+	if threshold == 0 || threshold > n {
+		return nil, nil, ErrInvalidThreshold
+	}
+	if id == 0 || id > n {
+		return nil, nil, ErrInvalidID
+	}
+
+	var secret fr.Element
+	if _, err := secret.SetRandom(); err != nil {
+		return nil, nil, err
+	}
+	f, err := NewPolynomial(&secret, int(threshold))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dkg := &DKG{id: id, threshold: threshold, n: n, f: f}
+	round1 := &DKGRound1{
+		ID:            id,
+		CommitmentsG1: f.CommitG1(),
+		CommitmentsG2: f.CommitG2(),
+	}
+	return dkg, round1, nil
+}
+
+// Round2 checks the broadcast messages of all the participants, including the
+// one of dkg, and returns the shares to send to the other participants.
+func (dkg *DKG) Round2(round1 []DKGRound1) ([]DKGRound2, error) {
+	if dkg.f == nil || dkg.commitmentsG1 != nil {
+		return nil, ErrDKGState
+	}
+	if len(round1) != int(dkg.n) {
+		return nil, ErrNbMessages
+	}
+
+	commitmentsG1 := make([]CommitmentsG1, dkg.n)
+	commitmentsG2 := make([]CommitmentsG2, dkg.n)
+	for i := range round1 {
+		m := &round1[i]
+		if m.ID == 0 || m.ID > dkg.n {
+			return nil, ErrInvalidID
+		}
+		if commitmentsG1[m.ID-1] != nil {
+			return nil, ErrDuplicateID
+		}
+		if len(m.CommitmentsG1) != int(dkg.threshold) || len(m.CommitmentsG2) != int(dkg.threshold) {
+			return nil, ErrInvalidThreshold
+		}
+		ok, err := consistent(m.CommitmentsG1, m.CommitmentsG2)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrInvalidCommitments
+		}
+		commitmentsG1[m.ID-1] = m.CommitmentsG1
+		commitmentsG2[m.ID-1] = m.CommitmentsG2
+	}
+	dkg.commitmentsG1 = commitmentsG1
+	dkg.commitmentsG2 = commitmentsG2
+
+	res := make([]DKGRound2, 0, dkg.n-1)
+	for j := uint32(1); j <= dkg.n; j++ {
+		if j == dkg.id {
+			continue
+		}
+		res = append(res, DKGRound2{From: dkg.id, To: j, Share: dkg.f.Evaluate(j)})
+	}
+	return res, nil
+}
+
+// Finalize checks the shares dealt to dkg by the other participants and returns
+// the key share of dkg. The secret polynomial of dkg is dropped.
+func (dkg *DKG) Finalize(round2 []DKGRound2) (*KeyShare, error) {
+	if dkg.f == nil || dkg.commitmentsG1 == nil {
+		return nil, ErrDKGState
+	}
+	if len(round2) != int(dkg.n)-1 {
+		return nil, ErrNbMessages
+	}
+
+	res := &KeyShare{
+		Share:         Share{ID: dkg.id, Value: dkg.f.Evaluate(dkg.id)},
+		Threshold:     dkg.threshold,
+		CommitmentsG1: dkg.commitmentsG1[dkg.id-1],
+		CommitmentsG2: dkg.commitmentsG2[dkg.id-1],
+	}
+	received := make([]bool, dkg.n)
+	received[dkg.id-1] = true
+	for i := range round2 {
+		m := &round2[i]
+		if m.To != dkg.id || m.From == 0 || m.From > dkg.n {
+			return nil, ErrInvalidID
+		}
+		if received[m.From-1] {
+			return nil, ErrDuplicateID
+		}
+		received[m.From-1] = true
+
+		commitments := dkg.commitmentsG1[m.From-1]
+		if !commitments.VerifyShare(&Share{ID: dkg.id, Value: m.Share}) {
+			return nil, ErrInvalidShare
+		}
+		res.Value.Add(&res.Value, &m.Share)
+		res.CommitmentsG1 = res.CommitmentsG1.add(commitments)
+		res.CommitmentsG2 = res.CommitmentsG2.add(dkg.commitmentsG2[m.From-1])
+	}
+
+	dkg.f = nil
+	return res, nil
+}
+
+// consistent returns true if c1 and c2 commit to the same coefficients, checking
+// e(∑ ρₖ⋅c1ₖ, g₂) = e(g₁, ∑ ρₖ⋅c2ₖ) for random ρₖ.
+func consistent(c1 CommitmentsG1, c2 CommitmentsG2) (bool, error) {
+	rho := make([]fr.Element, len(c1))
+	for k := range rho {
+		if _, err := rho[k].SetRandom(); err != nil {
+			return false, err
+		}
+	}
+	var a curve.G1Affine
+	var b curve.G2Affine
+	if _, err := a.MultiExp(c1, rho, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+	if _, err := b.MultiExp(c2, rho, ecc.MultiExpConfig{}); err != nil {
+		return false, err
+	}
+
+	var negG1 curve.G1Affine
+	negG1.Neg(&g1Gen)
+	return curve.PairingCheck([]curve.G1Affine{a, negG1}, []curve.G2Affine{g2Gen, b})
+}
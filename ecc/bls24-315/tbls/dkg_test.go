@@ -0,0 +1,162 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package tbls
+
+import (
+	"testing"
+)
+
+// runDKG runs the distributed key generation between n participants
+func runDKG(t testing.TB, threshold, n uint32) []*KeyShare {
+	dkgs := make([]*DKG, n)
+	round1 := make([]DKGRound1, n)
+	for i := range dkgs {
+		var m *DKGRound1
+		var err error
+		if dkgs[i], m, err = NewDKG(uint32(i+1), threshold, n); err != nil {
+			t.Fatal(err)
+		}
+		round1[i] = *m
+	}
+
+	received := make([][]DKGRound2, n)
+	for i := range dkgs {
+		shares, err := dkgs[i].Round2(round1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, m := range shares {
+			received[m.To-1] = append(received[m.To-1], m)
+		}
+	}
+
+	keyShares := make([]*KeyShare, n)
+	for i := range dkgs {
+		var err error
+		if keyShares[i], err = dkgs[i].Finalize(received[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return keyShares
+}
+
+func TestDKG(t *testing.T) {
+	const threshold, n = 3, 5
+	keyShares := runDKG(t, threshold, n)
+
+	// all the participants agree on the group polynomial
+	for _, ks := range keyShares {
+		for k := range ks.CommitmentsG1 {
+			if !ks.CommitmentsG1[k].Equal(&keyShares[0].CommitmentsG1[k]) || !ks.CommitmentsG2[k].Equal(&keyShares[0].CommitmentsG2[k]) {
+				t.Fatal("participants disagree on the group commitments")
+			}
+		}
+		if !ks.CommitmentsG1.VerifyShare(&ks.Share) {
+			t.Fatal("key share doesn't match the group commitments")
+		}
+	}
+
+	shares := make([]Share, threshold)
+	for i := range shares {
+		shares[i] = keyShares[n-1-i].Share
+	}
+	secret, err := Recover(shares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := keyShares[0].CommitmentsG1.PublicKey()
+	f := Polynomial{secret}
+	if c := f.CommitG1(); !c[0].Equal(&pk) {
+		t.Fatal("recovered secret doesn't match the group public key")
+	}
+
+	// threshold signature in G2 with the generated key
+	msg := []byte("testing distributed key generation")
+	partials := make([]PartialSignatureG2, threshold)
+	for i := range partials {
+		partial, err := SignG2(&keyShares[2*i].Share, msg, dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		partials[i] = *partial
+	}
+	sig, err := AggregateG2(partials)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyG2(&sig, &pk, msg, dst); err != nil || !ok {
+		t.Fatal("aggregated signature rejected")
+	}
+}
+
+func TestDKGErrors(t *testing.T) {
+	if _, _, err := NewDKG(1, 3, 2); err != ErrInvalidThreshold {
+		t.Fatal("expected an invalid threshold error")
+	}
+	if _, _, err := NewDKG(3, 2, 2); err != ErrInvalidID {
+		t.Fatal("expected an invalid identifier error")
+	}
+
+	const threshold, n = 2, 3
+	dkgs := make([]*DKG, n)
+	round1 := make([]DKGRound1, n)
+	for i := range dkgs {
+		var m *DKGRound1
+		var err error
+		if dkgs[i], m, err = NewDKG(uint32(i+1), threshold, n); err != nil {
+			t.Fatal(err)
+		}
+		round1[i] = *m
+	}
+	if _, err := dkgs[0].Finalize(nil); err != ErrDKGState {
+		t.Fatal("expected Finalize before Round2 to fail")
+	}
+
+	// commitments in G2 to another polynomial
+	tampered := append([]DKGRound1{}, round1...)
+	tampered[1].CommitmentsG2 = round1[2].CommitmentsG2
+	if _, err := dkgs[0].Round2(tampered); err != ErrInvalidCommitments {
+		t.Fatal("expected an inconsistent commitments error")
+	}
+	if _, err := dkgs[0].Round2(round1[1:]); err != ErrNbMessages {
+		t.Fatal("expected a wrong number of messages error")
+	}
+
+	shares, err := dkgs[0].Round2(round1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = dkgs[1].Round2(round1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = dkgs[1].Round2(round1); err != ErrDKGState {
+		t.Fatal("expected Round2 to be called once")
+	}
+
+	// wrong share from participant 1 to participant 2
+	received := []DKGRound2{shares[0], {From: 3, To: 2, Share: dkgs[2].f.Evaluate(2)}}
+	received[0].Share.Double(&received[0].Share)
+	if _, err = dkgs[1].Finalize(received); err != ErrInvalidShare {
+		t.Fatal("expected an invalid share error")
+	}
+}
+
+func BenchmarkDKG(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		runDKG(b, 3, 5)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package tbls provides threshold BLS signatures on bls24-315.
+//
+// A secret key s ∈ fr is split with Shamir's secret sharing: participant i holds
+// the share f(i) of a secret polynomial f of degree t-1 with f(0) = s. Any t
+// shares recover s, fewer reveal nothing about it. The Feldman commitments
+// [aₖ]G to the coefficients of f make the sharing verifiable and give the public
+// key share [f(i)]G of each participant. The key can also be generated without
+// a trusted dealer, with the Joint-Feldman distributed key generation (see DKG).
+//
+// Participants sign a message m with their share, σᵢ = [f(i)]H(m), and any t
+// partial signatures are combined into the signature σ = ∑ λᵢ⋅σᵢ = [s]H(m) under
+// the group public key, where λᵢ are the Lagrange coefficients at 0 of the
+// signers. Signatures are either in G1 with public keys in G2 (SignG1,
+// AggregateG1, VerifyG1) or in G2 with public keys in G1 (SignG2, AggregateG2,
+// VerifyG2). Messages are hashed to the curve with the domain separation tag
+// given by the caller.
+//
+// See https://datatracker.ietf.org/doc/draft-irtf-cfrg-bls-signature/ and
+// Boldyreva, "Threshold Signatures, Multisignatures and Blind Signatures Based on
+// the Gap-Diffie-Hellman-Group Signature Scheme" (PKC 2003).
+package tbls
@@ -16,7 +16,18 @@
 
 // Package eddsa provides EdDSA signature scheme on bls24-315's twisted edwards curve.
 //
+// It also provides the MuSig2 multi-signature (all the signers of a key sign
+// together) and the FROST threshold signature (any t out of n participants
+// sign), whose aggregated signatures are EdDSA signatures.
+//
+// Keys are encoded in PKCS #8 and X.509 SubjectPublicKeyInfo (DER or PEM),
+// following RFC 8410 with an object identifier under the UUID arc of
+// gnark-crypto, and as JSON Web Keys of type "OKP".
+//
 // # See also
 //
 // https://en.wikipedia.org/wiki/EdDSA
+// https://eprint.iacr.org/2020/1261.pdf (MuSig2)
+// https://eprint.iacr.org/2020/852.pdf (FROST)
+// https://www.rfc-editor.org/rfc/rfc8410 (key encodings)
 package eddsa
@@ -0,0 +1,28 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package zeromorph provides a commitment scheme for multilinear polynomials (Zeromorph),
+// built on the univariate kzg package.
+//
+// A multilinear polynomial, given by its evaluations on the boolean hypercube as a
+// polynomial.MultiLin, is committed to as the univariate polynomial with the same
+// coefficients. Opening proofs at a point of 𝔽ⁿ contain n+2 G1 elements and are
+// checked with a single pairing check. The challenges are derived from a
+// fiatshamir.Settings, so that openings can be bound to the transcript of an outer
+// protocol such as sumcheck or gkr.
+//
+// See https://eprint.iacr.org/2023/917.pdf
+package zeromorph
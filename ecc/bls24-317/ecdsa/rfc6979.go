@@ -0,0 +1,133 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"hash"
+	"math/big"
+)
+
+// SignDeterministic performs the ECDSA signature with the deterministic nonce
+// of RFC 6979, so that the signature only depends on the private key and the
+// message.
+//
+// message is hashed with a hash function returned by newHash, which is also
+// used by the HMAC_DRBG deriving the nonce. If newHash is nil, message is the
+// hash of the message and HMAC-SHA256 is used, as in libsecp256k1.
+//
+// RFC 6979, Section 3.2
+func (privKey *PrivateKey) SignDeterministic(message []byte, newHash func() hash.Hash) ([]byte, error) {
+	_, r, s, err := privKey.signDeterministic(message, newHash)
+	if err != nil {
+		return nil, err
+	}
+	var sig Signature
+	r.FillBytes(sig.R[:sizeFr])
+	s.FillBytes(sig.S[:sizeFr])
+
+	return sig.Bytes(), nil
+}
+
+// signDeterministic returns the ECDSA signature of message with the nonce of
+// RFC 6979 and its recovery information
+func (privKey *PrivateKey) signDeterministic(message []byte, newHash func() hash.Hash) (v uint, r, s *big.Int, err error) {
+	h := message
+	if newHash == nil {
+		newHash = sha256.New
+	} else if h, err = hashMessage(message, newHash()); err != nil {
+		return 0, nil, nil, err
+	}
+	drbg := newRFC6979(privKey.scalar[:], h, nil, newHash)
+	return privKey.sign(HashToInt(h), drbg.next)
+}
+
+// rfc6979 is the HMAC_DRBG generating the nonces of RFC 6979, Section 3.2.
+type rfc6979 struct {
+	newHash func() hash.Hash
+	k, v    []byte
+}
+
+// newRFC6979 returns the nonce generator of the private key x for the hashed
+// message h (steps b. to g.), with the optional additional data of Section 3.6
+func newRFC6979(x, h, extra []byte, newHash func() hash.Hash) *rfc6979 {
+	hLen := newHash().Size()
+	g := &rfc6979{
+		newHash: newHash,
+		k:       make([]byte, hLen),
+		v:       make([]byte, hLen),
+	}
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	// int2octets(x) ∥ bits2octets(h) ∥ extra
+	rLen := (sizeFrBits + 7) / 8
+	seed := make([]byte, 2*rLen, 2*rLen+len(extra))
+	new(big.Int).SetBytes(x).FillBytes(seed[:rLen])
+	z := bits2int(h)
+	z.Mod(z, order).
+		FillBytes(seed[rLen:])
+	seed = append(seed, extra...)
+
+	g.k = g.mac(g.k, g.v, []byte{0x00}, seed)
+	g.v = g.mac(g.k, g.v)
+	g.k = g.mac(g.k, g.v, []byte{0x01}, seed)
+	g.v = g.mac(g.k, g.v)
+	return g
+}
+
+// next returns the next nonce k ∈ [1, order-1] (step h.)
+func (g *rfc6979) next() (*big.Int, error) {
+	for {
+		t := make([]byte, 0, (sizeFrBits+7)/8+len(g.v))
+		for len(t)*8 < sizeFrBits {
+			g.v = g.mac(g.k, g.v)
+			t = append(t, g.v...)
+		}
+		k := bits2int(t)
+
+		// state for the next candidate, if k is out of range or gives a zero
+		// r or s
+		g.k = g.mac(g.k, g.v, []byte{0x00})
+		g.v = g.mac(g.k, g.v)
+
+		if k.Sign() > 0 && k.Cmp(order) < 0 {
+			return k, nil
+		}
+	}
+}
+
+// mac returns HMAC_key(data[0] ∥ data[1] ∥ …)
+func (g *rfc6979) mac(key []byte, data ...[]byte) []byte {
+	m := hmac.New(g.newHash, key)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// bits2int returns the integer made of the sizeFrBits left-most bits of b
+// (RFC 6979, Section 2.3.2)
+func bits2int(b []byte) *big.Int {
+	res := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - sizeFrBits; excess > 0 {
+		res.Rsh(res, uint(excess))
+	}
+	return res
+}
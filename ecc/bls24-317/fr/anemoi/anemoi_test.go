@@ -0,0 +1,94 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package anemoi
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomElements(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestFlystel(t *testing.T) {
+	params := GetParams()
+	e := randomElements(2)
+	x, y := e[0], e[1]
+	params.flystel(&x, &y)
+
+	// invert the open Flystel step by step
+	var u, v, t0 fr.Element
+	v.Set(&y)
+	// undo x += g·y² + δ
+	t0.Square(&v).Mul(&t0, &params.G).Add(&t0, &params.Delta)
+	u.Sub(&x, &t0)
+	// undo y -= u^(1/α)
+	t0.Exp(u, &params.alphaInv)
+	v.Add(&v, &t0)
+	// undo x -= g·y²
+	t0.Square(&v).Mul(&t0, &params.G)
+	u.Add(&u, &t0)
+
+	require.True(t, u.Equal(&e[0]))
+	require.True(t, v.Equal(&e[1]))
+}
+
+func TestHash(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	inputs := randomElements(5)
+
+	h := NewAnemoi()
+	for i := range inputs {
+		b := inputs[i].Bytes()
+		_, err := h.Write(b[:])
+		assert.NoError(err)
+	}
+	expected := params.Hash(inputs)
+	bytes := expected.Bytes()
+	assert.Equal(bytes[:], h.Sum(nil))
+	assert.Equal(bytes[:], h.Sum(nil), "Sum doesn't change the state")
+
+	h.Reset()
+	h.(interface{ WriteElements(...fr.Element) }).WriteElements(inputs...)
+	assert.Equal(bytes[:], h.Sum(nil))
+
+	// trailing zeros are absorbed like any other input
+	extended := params.Hash(append(inputs, fr.Element{}))
+	assert.False(expected.Equal(&extended))
+
+	_, err := h.Write(make([]byte, BlockSize-1))
+	assert.Error(err)
+}
+
+func TestCompress(t *testing.T) {
+	params := GetParams()
+	e := randomElements(2)
+	u, v := e[0], e[1]
+	params.Permutation(&u, &v)
+	var expected fr.Element
+	expected.Add(&e[0], &e[1]).Add(&expected, &u).Add(&expected, &v)
+	actual := params.Compress(e[0], e[1])
+	require.True(t, expected.Equal(&actual))
+}
@@ -17,6 +17,7 @@
 package gkr
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
@@ -207,7 +208,7 @@ func testSingleMulGate(t *testing.T, inputAssignments ...[]fr.Element) {
 
 	c := make(Circuit, 3)
 	c[2] = Wire{
-		Gate:   Gates["mul"],
+		Gate:   GetGate("mul"),
 		Inputs: []*Wire{&c[0], &c[1]},
 	}
 
@@ -252,7 +253,7 @@ func testSingleMimcCipherGate(t *testing.T, inputAssignments ...[]fr.Element) {
 	c := make(Circuit, 3)
 
 	c[2] = Wire{
-		Gate:   mimcCipherGate{},
+		Gate:   GetGate("mimc"),
 		Inputs: []*Wire{&c[0], &c[1]},
 	}
 
@@ -300,7 +301,7 @@ func mimcCircuit(numRounds int) Circuit {
 
 	for i := 2; i < len(c); i++ {
 		c[i] = Wire{
-			Gate:   mimcCipherGate{},
+			Gate:   GetGate("mimc"),
 			Inputs: []*Wire{&c[i-1], &c[0]},
 		}
 	}
@@ -337,7 +338,7 @@ func testATimesBSquared(t *testing.T, numRounds int, inputAssignments ...[]fr.El
 
 	for i := 2; i < len(c); i++ {
 		c[i] = Wire{
-			Gate:   Gates["mul"],
+			Gate:   GetGate("mul"),
 			Inputs: []*Wire{&c[i-1], &c[0]},
 		}
 	}
@@ -458,6 +459,243 @@ func BenchmarkGkrMimc17(b *testing.B) {
 	benchmarkGkrMiMC(b, 1<<17, 91)
 }
 
+func TestBuiltinGatesDegree(t *testing.T) {
+	nbIns := map[string]int{
+		"identity":      1,
+		"add":           2,
+		"sub":           2,
+		"neg":           1,
+		"mul":           2,
+		"select":        3,
+		"poseidon-sbox": 1,
+	}
+	for name, nbIn := range nbIns {
+		assert.NoError(t, VerifyGateDegree(GetGate(name), nbIn), name)
+	}
+
+	var ark fr.Element
+	ark.SetInt64(3)
+	assert.NoError(t, VerifyGateDegree(MiMCRoundGate{Ark: ark, Exponent: 7}, 2))
+	assert.NoError(t, VerifyGateDegree(SBoxGate{Ark: ark, Exponent: 17}, 1))
+	assert.NoError(t, VerifyGateDegree(GetGate("mimc"), 2))
+	assert.NoError(t, VerifyGateDegree(MulGate(4), 4))
+}
+
+func TestFindGateDegree(t *testing.T) {
+	// x²y + z + 1
+	f := func(x ...fr.Element) (res fr.Element) {
+		res.Square(&x[0]).
+			Mul(&res, &x[1]).
+			Add(&res, &x[2]).
+			Add(&res, &one)
+		return
+	}
+	degree, err := FindGateDegree(f, 3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, degree)
+
+	_, err = FindGateDegree(f, 3, 2)
+	assert.Error(t, err)
+
+	constant := func(...fr.Element) fr.Element { return two }
+	degree, err = FindGateDegree(constant, 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, degree)
+
+	// underestimated and overestimated degrees
+	assert.Error(t, VerifyGateDegree(wrongDegreeGate{}, 1))
+	assert.Error(t, VerifyGateDegree(&functionGate{f: wrongDegreeGate{}.Evaluate, degree: 3}, 1))
+}
+
+func TestRegisterGate(t *testing.T) {
+	g, err := NewGate(func(x ...fr.Element) (res fr.Element) {
+		res.Mul(&x[0], &x[1]).Mul(&res, &x[0])
+		return
+	}, 2, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, g.Degree())
+
+	assert.NoError(t, RegisterGate("test-x²y", g, 2))
+	t.Cleanup(func() {
+		gatesLock.Lock()
+		defer gatesLock.Unlock()
+		delete(gates, "test-x²y")
+	})
+	assert.Equal(t, g, GetGate("test-x²y"))
+	name, ok := GateName(g)
+	assert.True(t, ok)
+	assert.Equal(t, "test-x²y", name)
+
+	name, ok = GateName(SelectGate{})
+	assert.True(t, ok)
+	assert.Equal(t, "select", name)
+	_, ok = GateName(MulGate(3))
+	assert.False(t, ok)
+
+	// a gate registered under several names has a deterministic name
+	assert.NoError(t, RegisterGate("test-a-x²y", g, 2))
+	t.Cleanup(func() {
+		gatesLock.Lock()
+		defer gatesLock.Unlock()
+		delete(gates, "test-a-x²y")
+	})
+	for i := 0; i < 10; i++ {
+		name, ok = GateName(g)
+		assert.True(t, ok)
+		assert.Equal(t, "test-a-x²y", name)
+	}
+
+	assert.Error(t, RegisterGate("test-x²y", g, 2), "name already taken")
+	assert.Error(t, RegisterGate("test-wrong-degree", wrongDegreeGate{}, 1))
+	assert.Nil(t, GetGate("test-wrong-degree"))
+}
+
+// wrongDegreeGate computes x² but claims to be linear
+type wrongDegreeGate struct{}
+
+func (wrongDegreeGate) Evaluate(x ...fr.Element) (res fr.Element) {
+	res.Square(&x[0])
+	return
+}
+
+func (wrongDegreeGate) Degree() int {
+	return 1
+}
+
+func TestSelectGate(t *testing.T) {
+	testManyInstances(t, 3, testSelectGate)
+}
+
+func testSelectGate(t *testing.T, inputAssignments ...[]fr.Element) {
+	c := make(Circuit, 5)
+	c[3] = Wire{
+		Gate:   GetGate("select"),
+		Inputs: []*Wire{&c[0], &c[1], &c[2]},
+	}
+	c[4] = Wire{
+		Gate:   SBoxGate{Ark: two, Exponent: 5},
+		Inputs: []*Wire{&c[3]},
+	}
+
+	assignment := WireAssignment{&c[0]: inputAssignments[0], &c[1]: inputAssignments[1], &c[2]: inputAssignments[2]}.Complete(c)
+
+	proof, err := Prove(c, assignment, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(t, err)
+
+	err = Verify(c, assignment, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(t, err, "proof rejected")
+
+	err = Verify(c, assignment, proof, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(0, 1)))
+	assert.NotNil(t, err, "bad proof accepted")
+}
+
+func TestSerialization(t *testing.T) {
+	c := make(Circuit, 6)
+	c[3] = Wire{
+		Gate:   GetGate("mul"),
+		Inputs: []*Wire{&c[0], &c[1]},
+	}
+	c[4] = Wire{
+		Gate:   GetGate("select"),
+		Inputs: []*Wire{&c[2], &c[3], &c[0]},
+	}
+	c[5] = Wire{
+		Gate:   GetGate("poseidon-sbox"),
+		Inputs: []*Wire{&c[4]},
+	}
+
+	inputs := make([][]fr.Element, 3)
+	for i := range inputs {
+		inputs[i] = make([]fr.Element, 4)
+		setRandom(inputs[i])
+	}
+	assignment := WireAssignment{&c[0]: inputs[0], &c[1]: inputs[1], &c[2]: inputs[2]}.Complete(c)
+	proof, err := Prove(c, assignment, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+	assert.NoError(t, err)
+
+	// the verifier only needs the input and output wires
+	verifierAssignment := WireAssignment{&c[0]: inputs[0], &c[1]: inputs[1], &c[2]: inputs[2], &c[5]: assignment[&c[5]]}
+
+	check := func(cBack Circuit, assignmentBack WireAssignment, proofBack Proof) {
+		assert.Equal(t, len(c), len(cBack))
+		assert.Equal(t, len(verifierAssignment), len(assignmentBack))
+		err := Verify(cBack, assignmentBack, proofBack, fiatshamir.WithHash(test_vector_utils.NewMessageCounter(1, 1)))
+		assert.NoError(t, err, "proof rejected")
+	}
+
+	// binary
+	{
+		var buf bytes.Buffer
+		_, err = c.WriteTo(&buf)
+		assert.NoError(t, err)
+		_, err = c.WriteAssignment(&buf, verifierAssignment)
+		assert.NoError(t, err)
+		_, err = proof.WriteTo(&buf)
+		assert.NoError(t, err)
+
+		var cBack Circuit
+		_, err = cBack.ReadFrom(&buf)
+		assert.NoError(t, err)
+		assignmentBack, _, err := cBack.ReadAssignment(&buf)
+		assert.NoError(t, err)
+		var proofBack Proof
+		_, err = proofBack.ReadFrom(&buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, buf.Len())
+		check(cBack, assignmentBack, proofBack)
+	}
+
+	// json
+	{
+		cBytes, err := json.Marshal(c)
+		assert.NoError(t, err)
+		aBytes, err := c.MarshalAssignmentJSON(verifierAssignment)
+		assert.NoError(t, err)
+		pBytes, err := json.Marshal(proof)
+		assert.NoError(t, err)
+
+		var cBack Circuit
+		assert.NoError(t, json.Unmarshal(cBytes, &cBack))
+		assignmentBack, err := cBack.UnmarshalAssignmentJSON(aBytes)
+		assert.NoError(t, err)
+		var proofBack Proof
+		assert.NoError(t, json.Unmarshal(pBytes, &proofBack))
+		check(cBack, assignmentBack, proofBack)
+	}
+
+	// gates must be registered
+	c[5].Gate = wrongDegreeGate{}
+	_, err = c.MarshalBinary()
+	assert.Error(t, err)
+
+	var cBack Circuit
+	assert.Error(t, json.Unmarshal([]byte(`[{"gate":null,"inputs":[]},{"gate":"unknown","inputs":[0]}]`), &cBack))
+	assert.Error(t, json.Unmarshal([]byte(`[{"gate":null,"inputs":[]},{"gate":"identity","inputs":[2]}]`), &cBack))
+	assert.Error(t, json.Unmarshal([]byte(`[{"gate":null,"inputs":[]},{"gate":null,"inputs":[0]}]`), &cBack))
+	assert.Error(t, json.Unmarshal([]byte(`[{"gate":"identity","inputs":[]}]`), &cBack))
+	assert.Error(t, cBack.UnmarshalBinary([]byte{0, 0, 0, 1, 0, 0, 0, 3, 'a', 'd', 'd', 0, 0, 0, 0}))
+
+	// forged lengths are rejected without large allocations
+	for _, data := range [][]byte{
+		{0xff, 0xff, 0xff, 0xff},                         // number of wires
+		{0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff},             // gate name length
+		{0, 0, 0, 1, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff}, // inputs of a wire without gate
+		{0, 0, 0, 1, 0, 0, 0, 3, 'a', 'd', 'd', 0xff, 0xff, 0xff, 0xff},
+	} {
+		assert.Error(t, cBack.UnmarshalBinary(data))
+	}
+	var proofBack Proof
+	for _, data := range [][]byte{
+		{0xff, 0xff, 0xff, 0xff},                         // number of wires
+		{0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff},             // number of polynomials
+		{0, 0, 0, 1, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff}, // number of coefficients
+	} {
+		assert.Error(t, proofBack.UnmarshalBinary(data))
+	}
+	_, _, err = c.ReadAssignment(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}))
+	assert.Error(t, err)
+}
+
 func TestTopSortTrivial(t *testing.T) {
 	c := make(Circuit, 2)
 	c[0].Inputs = []*Wire{&c[1]}
@@ -529,7 +767,7 @@ func getCircuit(path string) (Circuit, error) {
 func (c CircuitInfo) toCircuit() (circuit Circuit) {
 	circuit = make(Circuit, len(c))
 	for i := range c {
-		circuit[i].Gate = Gates[c[i].Gate]
+		circuit[i].Gate = GetGate(c[i].Gate)
 		circuit[i].Inputs = make([]*Wire, len(c[i].Inputs))
 		for k, inputCoord := range c[i].Inputs {
 			input := &circuit[inputCoord]
@@ -540,31 +778,9 @@ func (c CircuitInfo) toCircuit() (circuit Circuit) {
 }
 
 func init() {
-	Gates["mimc"] = mimcCipherGate{} //TODO: Add ark
-	Gates["select-input-3"] = _select(2)
-}
-
-type mimcCipherGate struct {
-	ark fr.Element
-}
-
-func (m mimcCipherGate) Evaluate(input ...fr.Element) (res fr.Element) {
-	var sum fr.Element
-
-	sum.
-		Add(&input[0], &input[1]).
-		Add(&sum, &m.ark)
-
-	res.Square(&sum)    // sum^2
-	res.Mul(&res, &sum) // sum^3
-	res.Square(&res)    //sum^6
-	res.Mul(&res, &sum) //sum^7
-
-	return
-}
-
-func (m mimcCipherGate) Degree() int {
-	return 7
+	if err := RegisterGate("select-input-3", _select(2), 3); err != nil {
+		panic(err)
+	}
 }
 
 type PrintableProof []PrintableSumcheckProof
@@ -17,7 +17,12 @@
 package plookup
 
 import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
@@ -119,6 +124,243 @@ func TestLookupTable(t *testing.T) {
 
 }
 
+func TestLogUpVector(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// several vectors of different sizes, with repeated entries
+	lookupVector := make(fr.Vector, 8)
+	for i := 0; i < 8; i++ {
+		lookupVector[i].SetUint64(uint64(2 * i))
+	}
+	fvectors := []fr.Vector{make(fr.Vector, 13), make(fr.Vector, 3), make(fr.Vector, 16)}
+	for k := range fvectors {
+		for i := range fvectors[k] {
+			fvectors[k][i].Set(&lookupVector[(3*i+k)%8])
+		}
+	}
+
+	// correct proof
+	{
+		proof, err := ProveLogUpVector(kzgSrs.Pk, fvectors, lookupVector)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyLogUp(kzgSrs.Vk, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the prover can't build a proof for entries not in the table
+	{
+		fvectors[1][2].SetUint64(1)
+		_, err := ProveLogUpVector(kzgSrs.Pk, fvectors, lookupVector)
+		if err != ErrNotInTable {
+			t.Fatal("expected ErrNotInTable")
+		}
+	}
+
+	// wrong proof
+	{
+		fvectors[1][2].SetUint64(4)
+		proof, err := ProveLogUpVector(kzgSrs.Pk, fvectors, lookupVector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof.BatchedProof.ClaimedValues[0].SetUint64(6)
+		err = VerifyLogUp(kzgSrs.Vk, proof)
+		if err == nil {
+			t.Fatal("wrong proof verified")
+		}
+	}
+
+}
+
+func TestLogUpTables(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// XOR table on 2 bits
+	lookupTable := make([]fr.Vector, 3)
+	for i := range lookupTable {
+		lookupTable[i] = make(fr.Vector, 16)
+	}
+	for a := 0; a < 4; a++ {
+		for b := 0; b < 4; b++ {
+			lookupTable[0][4*a+b].SetUint64(uint64(a))
+			lookupTable[1][4*a+b].SetUint64(uint64(b))
+			lookupTable[2][4*a+b].SetUint64(uint64(a ^ b))
+		}
+	}
+	fTables := make([][]fr.Vector, 2)
+	for k := range fTables {
+		fTables[k] = make([]fr.Vector, 3)
+		for c := range fTables[k] {
+			fTables[k][c] = make(fr.Vector, 5+4*k)
+			for j := range fTables[k][c] {
+				fTables[k][c][j].Set(&lookupTable[c][(7*j+k)%16])
+			}
+		}
+	}
+
+	// correct proof
+	{
+		proof, err := ProveLogUpTables(kzgSrs.Pk, fTables, lookupTable)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyLogUp(kzgSrs.Vk, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// all the entries are in the table, but not the rows
+	{
+		fTables[1][2][0], fTables[1][2][1] = fTables[1][2][1], fTables[1][2][0]
+		_, err := ProveLogUpTables(kzgSrs.Pk, fTables, lookupTable)
+		if err != ErrNotInTable {
+			t.Fatal("expected ErrNotInTable")
+		}
+	}
+
+	_, err = ProveLogUpTables(kzgSrs.Pk, fTables, lookupTable[:2])
+	if err != ErrIncompatibleSize {
+		t.Fatal("expected ErrIncompatibleSize")
+	}
+
+}
+
+func TestProofSerialization(t *testing.T) {
+
+	kzgSrs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lookupTable := make([]fr.Vector, 3)
+	fTable := make([]fr.Vector, 3)
+	for i := 0; i < 3; i++ {
+		lookupTable[i] = make(fr.Vector, 8)
+		fTable[i] = make(fr.Vector, 7)
+		for j := 0; j < 8; j++ {
+			lookupTable[i][j].SetUint64(uint64(2*i + j))
+		}
+		for j := 0; j < 7; j++ {
+			fTable[i][j].Set(&lookupTable[i][(4*j+1)%8])
+		}
+	}
+
+	// lookup vector
+	{
+		proof, err := ProveLookupVector(kzgSrs.Pk, fTable[0], lookupTable[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded ProofLookupVector
+		roundTrip(t, &proof, &decoded)
+		if err = VerifyLookupVector(kzgSrs.Vk, decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// lookup tables
+	{
+		proof, err := ProveLookupTables(kzgSrs.Pk, fTable, lookupTable)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded ProofLookupTables
+		roundTrip(t, &proof, &decoded)
+		if err = VerifyLookupTables(kzgSrs.Vk, decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// log-derivative lookup
+	{
+		proof, err := ProveLogUpTables(kzgSrs.Pk, [][]fr.Vector{fTable, fTable}, lookupTable)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded ProofLogUp
+		roundTrip(t, &proof, &decoded)
+		if err = VerifyLogUp(kzgSrs.Vk, decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		// a forged number of tables must not be trusted
+		data, err := proof.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		binary.BigEndian.PutUint32(data[8+fr.Bytes:], math.MaxUint32)
+		if err = decoded.UnmarshalBinary(data); err == nil {
+			t.Fatal("decoding a forged number of tables should fail")
+		}
+	}
+
+}
+
+type serializable interface {
+	WriteTo(w io.Writer) (int64, error)
+	WriteRawTo(w io.Writer) (int64, error)
+	ReadFrom(r io.Reader) (int64, error)
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary(data []byte) error
+}
+
+// roundTrip checks that proof is recovered in decoded from all its encodings.
+func roundTrip(t *testing.T, proof, decoded serializable) {
+	var compressed, raw bytes.Buffer
+	if _, err := proof.WriteTo(&compressed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := proof.WriteRawTo(&raw); err != nil {
+		t.Fatal(err)
+	}
+	if compressed.Len() >= raw.Len() {
+		t.Fatal("compressed encoding should be smaller than raw encoding")
+	}
+
+	for _, buf := range []*bytes.Buffer{&compressed, &raw} {
+		size := int64(buf.Len())
+		n, err := decoded.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != size {
+			t.Fatalf("read %d bytes, expected %d", n, size)
+		}
+		if !reflect.DeepEqual(proof, decoded) {
+			t.Fatal("decoded proof doesn't match")
+		}
+	}
+
+	data, err := proof.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = decoded.UnmarshalBinary(data[:len(data)-1]); err == nil {
+		t.Fatal("decoding truncated data should fail")
+	}
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(proof, decoded) {
+		t.Fatal("decoded proof doesn't match")
+	}
+}
+
 func BenchmarkPlookup(b *testing.B) {
 
 	srsSize := 1 << 15
@@ -0,0 +1,188 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/polynomial"
+)
+
+// SerializableFinalEvalProof is a final evaluation proof that can be encoded
+// along with a Proof, once its type is registered with RegisterFinalEvalProof.
+type SerializableFinalEvalProof interface {
+	io.WriterTo
+	io.ReaderFrom
+}
+
+// Identifiers of the final evaluation proofs that need no registration
+const (
+	FinalEvalProofNone     uint8 = iota // nil
+	FinalEvalProofElements              // []fr.Element, as used by gkr and ProductSumClaims
+	firstCustomFinalEvalProof
+)
+
+var (
+	finalEvalProofsLock sync.RWMutex
+	finalEvalProofIDs   = make(map[reflect.Type]uint8)
+	finalEvalProofNews  = make(map[uint8]func() SerializableFinalEvalProof)
+)
+
+// RegisterFinalEvalProof makes the type of the values returned by newProof
+// serializable as a final evaluation proof, under the given identifier. The
+// identifiers below firstCustomFinalEvalProof are reserved. newProof must return
+// a pointer, and the final evaluation proofs of that type given to WriteTo must
+// be pointers as well. Decoded proofs are the values returned by newProof.
+func RegisterFinalEvalProof(id uint8, newProof func() SerializableFinalEvalProof) error {
+	if id < firstCustomFinalEvalProof {
+		return fmt.Errorf("final evaluation proof identifier %d is reserved", id)
+	}
+	t := reflect.TypeOf(newProof())
+	if t == nil || t.Kind() != reflect.Ptr {
+		return errors.New("final evaluation proofs must be pointers")
+	}
+	finalEvalProofsLock.Lock()
+	defer finalEvalProofsLock.Unlock()
+	if _, ok := finalEvalProofNews[id]; ok {
+		return fmt.Errorf("final evaluation proof identifier %d already registered", id)
+	}
+	if _, ok := finalEvalProofIDs[t]; ok {
+		return fmt.Errorf("final evaluation proof type %s already registered", t)
+	}
+	finalEvalProofIDs[t] = id
+	finalEvalProofNews[id] = newProof
+	return nil
+}
+
+// WriteTo implements io.WriterTo. The number of partial sum polynomials is
+// encoded as a big endian uint32, followed by the polynomials as fr.Vector, the
+// identifier of the type of the final evaluation proof on one byte and the
+// final evaluation proof itself.
+func (p *Proof) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(len(p.PartialSumPolys)))
+	buf.Write(b[:])
+	for i := range p.PartialSumPolys {
+		v := fr.Vector(p.PartialSumPolys[i])
+		if _, err := v.WriteTo(&buf); err != nil {
+			return 0, err
+		}
+	}
+
+	switch finalEvalProof := p.FinalEvalProof.(type) {
+	case nil:
+		buf.WriteByte(FinalEvalProofNone)
+	case []fr.Element:
+		buf.WriteByte(FinalEvalProofElements)
+		v := fr.Vector(finalEvalProof)
+		if _, err := v.WriteTo(&buf); err != nil {
+			return 0, err
+		}
+	default:
+		finalEvalProofsLock.RLock()
+		id, ok := finalEvalProofIDs[reflect.TypeOf(finalEvalProof)]
+		finalEvalProofsLock.RUnlock()
+		if !ok {
+			return 0, fmt.Errorf("unregistered final evaluation proof type %T", finalEvalProof)
+		}
+		buf.WriteByte(id)
+		if _, err := finalEvalProof.(SerializableFinalEvalProof).WriteTo(&buf); err != nil {
+			return 0, err
+		}
+	}
+
+	return buf.WriteTo(w)
+}
+
+// ReadFrom implements io.ReaderFrom. The polynomials and the elements are
+// appended as they are read, so that forged lengths can't cause large
+// allocations.
+func (p *Proof) ReadFrom(r io.Reader) (int64, error) {
+	var b [4]byte
+	read, err := io.ReadFull(r, b[:])
+	n := int64(read)
+	if err != nil {
+		return n, err
+	}
+	nbPolys := binary.BigEndian.Uint32(b[:])
+	p.PartialSumPolys = []polynomial.Polynomial{}
+	for i := uint32(0); i < nbPolys; i++ {
+		var v fr.Vector
+		m, err := v.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		p.PartialSumPolys = append(p.PartialSumPolys, polynomial.Polynomial(v))
+	}
+
+	read, err = io.ReadFull(r, b[:1])
+	n += int64(read)
+	if err != nil {
+		return n, err
+	}
+	switch id := b[0]; id {
+	case FinalEvalProofNone:
+		p.FinalEvalProof = nil
+	case FinalEvalProofElements:
+		var v fr.Vector
+		m, err := v.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		p.FinalEvalProof = []fr.Element(v)
+	default:
+		finalEvalProofsLock.RLock()
+		newProof, ok := finalEvalProofNews[id]
+		finalEvalProofsLock.RUnlock()
+		if !ok {
+			return n, fmt.Errorf("unknown final evaluation proof identifier %d", id)
+		}
+		finalEvalProof := newProof()
+		m, err := finalEvalProof.ReadFrom(r)
+		n += m
+		if err != nil {
+			return n, err
+		}
+		p.FinalEvalProof = finalEvalProof
+	}
+	return n, nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	_, err := p.ReadFrom(bytes.NewReader(data))
+	return err
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sumcheck
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/stretchr/testify/require"
+)
+
+// testFinalEvalProof is a custom final evaluation proof
+type testFinalEvalProof struct {
+	value fr.Element
+}
+
+func (p *testFinalEvalProof) WriteTo(w io.Writer) (int64, error) {
+	b := p.value.Bytes()
+	n, err := w.Write(b[:])
+	return int64(n), err
+}
+
+func (p *testFinalEvalProof) ReadFrom(r io.Reader) (int64, error) {
+	var b [fr.Bytes]byte
+	n, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return int64(n), err
+	}
+	return int64(n), p.value.SetBytesCanonical(b[:])
+}
+
+const testFinalEvalProofID = 7
+
+func init() {
+	if err := RegisterFinalEvalProof(testFinalEvalProofID, func() SerializableFinalEvalProof { return new(testFinalEvalProof) }); err != nil {
+		panic(err)
+	}
+}
+
+func roundTrip(t *testing.T, proof *Proof) Proof {
+	data, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	var res Proof
+	require.NoError(t, res.UnmarshalBinary(data))
+
+	// truncated data
+	var truncated Proof
+	require.Error(t, truncated.UnmarshalBinary(data[:len(data)-1]))
+	return res
+}
+
+func TestProofSerialization(t *testing.T) {
+	assert := require.New(t)
+
+	// no final evaluation proof
+	poly := make(polynomial.MultiLin, 8)
+	for i := range poly {
+		poly[i].SetRandom()
+	}
+	claim := singleMultilinClaim{g: poly.Clone()}
+	proof, err := Prove(&claim, fiatshamir.WithHash(sha256.New()))
+	assert.NoError(err)
+	back := roundTrip(t, &proof)
+	assert.Equal(proof, back)
+	assert.NoError(Verify(singleMultilinLazyClaim{g: poly, claimedSum: poly.Sum()}, back, fiatshamir.WithHash(sha256.New())))
+
+	// final evaluation proof as a list of elements
+	tables := randomTables(3, 4)
+	claims, err := NewProductSumClaims(VirtualPolynomial{Tables: cloneTables(tables), Terms: testTerms}, nil)
+	assert.NoError(err)
+	proof, err = Prove(claims, fiatshamir.WithHash(sha256.New()))
+	assert.NoError(err)
+	back = roundTrip(t, &proof)
+	assert.Equal(proof, back)
+	lazy := NewProductSumLazyClaims(testTerms, len(tables), 4, fr.Element{})
+	lazy.Tables = tables
+	assert.Error(Verify(lazy, back, fiatshamir.WithHash(sha256.New())), "wrong sum")
+
+	// registered final evaluation proof
+	custom := &testFinalEvalProof{}
+	custom.value.SetRandom()
+	proof.FinalEvalProof = custom
+	back = roundTrip(t, &proof)
+	assert.Equal(proof, back)
+
+	// unregistered final evaluation proof
+	proof.FinalEvalProof = testFinalEvalProof{}
+	_, err = proof.MarshalBinary()
+	assert.Error(err)
+
+	// unknown identifier
+	proof.FinalEvalProof = nil
+	data, err := proof.MarshalBinary()
+	assert.NoError(err)
+	data[len(data)-1] = 200
+	assert.Error(back.UnmarshalBinary(data))
+
+	// forged lengths are rejected without large allocations
+	for _, data := range [][]byte{
+		{0xff, 0xff, 0xff, 0xff},                                     // number of polynomials
+		{0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff},                         // number of coefficients
+		{0, 0, 0, 0, FinalEvalProofElements, 0xff, 0xff, 0xff, 0xff}, // final evaluation proof
+	} {
+		assert.Error(back.UnmarshalBinary(data))
+	}
+}
+
+func TestRegisterFinalEvalProof(t *testing.T) {
+	assert := require.New(t)
+	newProof := func() SerializableFinalEvalProof { return new(testFinalEvalProof) }
+	assert.Error(RegisterFinalEvalProof(FinalEvalProofElements, newProof), "reserved")
+	assert.Error(RegisterFinalEvalProof(testFinalEvalProofID, newProof), "identifier taken")
+	assert.Error(RegisterFinalEvalProof(testFinalEvalProofID+1, newProof), "type already registered")
+
+	var buf bytes.Buffer
+	proof := Proof{PartialSumPolys: []polynomial.Polynomial{}, FinalEvalProof: []fr.Element{}}
+	_, err := proof.WriteTo(&buf)
+	assert.NoError(err)
+	var back Proof
+	_, err = back.ReadFrom(&buf)
+	assert.NoError(err)
+	assert.Equal(proof, back)
+}
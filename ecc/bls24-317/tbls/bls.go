@@ -0,0 +1,166 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package tbls
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-317"
+)
+
+// PartialSignatureG1 is the signature σᵢ = [f(ID)]H(m) ∈ G1 of a message
+// m by the participant ID.
+type PartialSignatureG1 struct {
+	ID        uint32
+	Signature curve.G1Affine
+}
+
+// SignG1 returns the partial signature of message with share, where message
+// is hashed to G1 with the domain separation tag dst.
+func SignG1(share *Share, message, dst []byte) (*PartialSignatureG1, error) {
+	if share.ID == 0 {
+		return nil, ErrInvalidID
+	}
+	h, err := curve.HashToG1(message, dst)
+	if err != nil {
+		return nil, err
+	}
+	var s big.Int
+	share.Value.BigInt(&s)
+	res := &PartialSignatureG1{ID: share.ID}
+	res.Signature.ScalarMultiplication(&h, &s)
+	return res, nil
+}
+
+// VerifyPartialG1 checks a partial signature against the public key share of
+// its signer (see CommitmentsG2.PublicShare).
+func VerifyPartialG1(partial *PartialSignatureG1, publicShare *curve.G2Affine, message, dst []byte) (bool, error) {
+	return VerifyG1(&partial.Signature, publicShare, message, dst)
+}
+
+// AggregateG1 returns the signature σ = ∑ λᵢ⋅σᵢ recovered from the partial
+// signatures, where λᵢ are the Lagrange coefficients at 0 of their signers. It
+// is the signature of the message under the group public key if at least
+// threshold valid partial signatures of that message are given.
+func AggregateG1(partials []PartialSignatureG1) (curve.G1Affine, error) {
+	var res curve.G1Affine
+	ids := make([]uint32, len(partials))
+	points := make([]curve.G1Affine, len(partials))
+	for i := range partials {
+		ids[i] = partials[i].ID
+		points[i] = partials[i].Signature
+	}
+	lambda, err := LagrangeCoefficients(ids)
+	if err != nil {
+		return res, err
+	}
+	_, err = res.MultiExp(points, lambda, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// VerifyG1 checks the BLS signature of message under publicKey,
+// e(σ, g2) = e(H(m), pk), where message is hashed to G1 with the domain
+// separation tag dst.
+func VerifyG1(signature *curve.G1Affine, publicKey *curve.G2Affine, message, dst []byte) (bool, error) {
+	if signature.IsInfinity() || publicKey.IsInfinity() {
+		return false, nil
+	}
+	if !signature.IsInSubGroup() || !publicKey.IsInSubGroup() {
+		return false, nil
+	}
+	h, err := curve.HashToG1(message, dst)
+	if err != nil {
+		return false, err
+	}
+
+	// e(σ, -g2)⋅e(H(m), pk) ?= 1
+	var negGen curve.G2Affine
+	negGen.Neg(&g2Gen)
+	return curve.PairingCheck([]curve.G1Affine{*signature, h}, []curve.G2Affine{negGen, *publicKey})
+}
+
+// PartialSignatureG2 is the signature σᵢ = [f(ID)]H(m) ∈ G2 of a message
+// m by the participant ID.
+type PartialSignatureG2 struct {
+	ID        uint32
+	Signature curve.G2Affine
+}
+
+// SignG2 returns the partial signature of message with share, where message
+// is hashed to G2 with the domain separation tag dst.
+func SignG2(share *Share, message, dst []byte) (*PartialSignatureG2, error) {
+	if share.ID == 0 {
+		return nil, ErrInvalidID
+	}
+	h, err := curve.HashToG2(message, dst)
+	if err != nil {
+		return nil, err
+	}
+	var s big.Int
+	share.Value.BigInt(&s)
+	res := &PartialSignatureG2{ID: share.ID}
+	res.Signature.ScalarMultiplication(&h, &s)
+	return res, nil
+}
+
+// VerifyPartialG2 checks a partial signature against the public key share of
+// its signer (see CommitmentsG1.PublicShare).
+func VerifyPartialG2(partial *PartialSignatureG2, publicShare *curve.G1Affine, message, dst []byte) (bool, error) {
+	return VerifyG2(&partial.Signature, publicShare, message, dst)
+}
+
+// AggregateG2 returns the signature σ = ∑ λᵢ⋅σᵢ recovered from the partial
+// signatures, where λᵢ are the Lagrange coefficients at 0 of their signers. It
+// is the signature of the message under the group public key if at least
+// threshold valid partial signatures of that message are given.
+func AggregateG2(partials []PartialSignatureG2) (curve.G2Affine, error) {
+	var res curve.G2Affine
+	ids := make([]uint32, len(partials))
+	points := make([]curve.G2Affine, len(partials))
+	for i := range partials {
+		ids[i] = partials[i].ID
+		points[i] = partials[i].Signature
+	}
+	lambda, err := LagrangeCoefficients(ids)
+	if err != nil {
+		return res, err
+	}
+	_, err = res.MultiExp(points, lambda, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// VerifyG2 checks the BLS signature of message under publicKey,
+// e(σ, g1) = e(H(m), pk), where message is hashed to G2 with the domain
+// separation tag dst.
+func VerifyG2(signature *curve.G2Affine, publicKey *curve.G1Affine, message, dst []byte) (bool, error) {
+	if signature.IsInfinity() || publicKey.IsInfinity() {
+		return false, nil
+	}
+	if !signature.IsInSubGroup() || !publicKey.IsInSubGroup() {
+		return false, nil
+	}
+	h, err := curve.HashToG2(message, dst)
+	if err != nil {
+		return false, err
+	}
+
+	// e(σ, -g1)⋅e(H(m), pk) ?= 1
+	var negGen curve.G1Affine
+	negGen.Neg(&g1Gen)
+	return curve.PairingCheck([]curve.G1Affine{negGen, *publicKey}, []curve.G2Affine{*signature, h})
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package tbls
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be in [1, number of participants]")
+	ErrInvalidID        = errors.New("participant identifier must be in [1, number of participants]")
+	ErrDuplicateID      = errors.New("duplicate participant identifier")
+	ErrNoShare          = errors.New("at least one share is needed")
+)
+
+// Share is the value f(ID) of a secret polynomial f at the non-zero identifier
+// of a participant.
+type Share struct {
+	ID    uint32
+	Value fr.Element
+}
+
+// Polynomial is the secret polynomial f of a threshold sharing, lowest degree
+// coefficient first. The shared secret is f(0) and the threshold is len(f).
+type Polynomial []fr.Element
+
+// NewPolynomial returns a random polynomial f of degree threshold-1 such that
+// f(0) = secret.
+func NewPolynomial(secret *fr.Element, threshold int) (Polynomial, error) {
+	if threshold < 1 {
+		return nil, ErrInvalidThreshold
+	}
+	f := make(Polynomial, threshold)
+	f[0].Set(secret)
+	for i := 1; i < threshold; i++ {
+		if _, err := f[i].SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// Evaluate returns f(id).
+func (f Polynomial) Evaluate(id uint32) fr.Element {
+	var x, res fr.Element
+	x.SetUint64(uint64(id))
+	for i := len(f) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &f[i])
+	}
+	return res
+}
+
+// Split returns the shares f(1), …, f(n) of the participants 1, …, n.
+func (f Polynomial) Split(n int) ([]Share, error) {
+	if len(f) == 0 || len(f) > n {
+		return nil, ErrInvalidThreshold
+	}
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i].ID = uint32(i + 1)
+		shares[i].Value = f.Evaluate(shares[i].ID)
+	}
+	return shares, nil
+}
+
+// LagrangeCoefficients returns the coefficients λᵢ = ∏_{j≠i} idⱼ/(idⱼ-idᵢ)
+// interpolating a polynomial at 0 from its values at the given identifiers,
+// which must be distinct and non-zero.
+func LagrangeCoefficients(ids []uint32) ([]fr.Element, error) {
+	if len(ids) == 0 {
+		return nil, ErrNoShare
+	}
+	x := make([]fr.Element, len(ids))
+	seen := make(map[uint32]struct{}, len(ids))
+	for i, id := range ids {
+		if id == 0 {
+			return nil, ErrInvalidID
+		}
+		if _, ok := seen[id]; ok {
+			return nil, ErrDuplicateID
+		}
+		seen[id] = struct{}{}
+		x[i].SetUint64(uint64(id))
+	}
+
+	num := make([]fr.Element, len(ids))
+	den := make([]fr.Element, len(ids))
+	var diff fr.Element
+	for i := range x {
+		num[i].SetOne()
+		den[i].SetOne()
+		for j := range x {
+			if j == i {
+				continue
+			}
+			num[i].Mul(&num[i], &x[j])
+			diff.Sub(&x[j], &x[i])
+			den[i].Mul(&den[i], &diff)
+		}
+	}
+	den = fr.BatchInvert(den)
+	for i := range num {
+		num[i].Mul(&num[i], &den[i])
+	}
+	return num, nil
+}
+
+// Recover returns the value at 0 of the polynomial interpolated from the
+// shares. It is the shared secret if at least threshold valid shares are given.
+func Recover(shares []Share) (fr.Element, error) {
+	var res fr.Element
+	ids := make([]uint32, len(shares))
+	for i := range shares {
+		ids[i] = shares[i].ID
+	}
+	lambda, err := LagrangeCoefficients(ids)
+	if err != nil {
+		return res, err
+	}
+	var tmp fr.Element
+	for i := range shares {
+		tmp.Mul(&lambda[i], &shares[i].Value)
+		res.Add(&res, &tmp)
+	}
+	return res, nil
+}
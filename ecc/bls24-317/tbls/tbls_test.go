@@ -0,0 +1,201 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package tbls
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+var dst = []byte("TBLS_TEST_bls24-317")
+
+func TestShamir(t *testing.T) {
+	const threshold, n = 3, 5
+
+	var secret fr.Element
+	secret.SetRandom()
+	f, err := NewPolynomial(&secret, threshold)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shares, err := f.Split(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, signers := range [][]int{{0, 1, 2}, {4, 2, 0}, {0, 1, 2, 3, 4}} {
+		subset := make([]Share, len(signers))
+		for i, j := range signers {
+			subset[i] = shares[j]
+		}
+		recovered, err := Recover(subset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !recovered.Equal(&secret) {
+			t.Fatal("wrong secret recovered from", signers)
+		}
+	}
+
+	recovered, err := Recover(shares[:threshold-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered.Equal(&secret) {
+		t.Fatal("secret recovered below the threshold")
+	}
+
+	if _, err = Recover([]Share{shares[0], shares[0]}); err != ErrDuplicateID {
+		t.Fatal("expected a duplicate identifier error")
+	}
+	if _, err = Recover(nil); err != ErrNoShare {
+		t.Fatal("expected an error without share")
+	}
+	if _, err = f.Split(threshold - 1); err != ErrInvalidThreshold {
+		t.Fatal("expected an invalid threshold error")
+	}
+}
+
+func TestFeldman(t *testing.T) {
+	const threshold, n = 3, 4
+
+	var secret fr.Element
+	secret.SetRandom()
+	f, _ := NewPolynomial(&secret, threshold)
+	shares, _ := f.Split(n)
+	c1, c2 := f.CommitG1(), f.CommitG2()
+
+	for i := range shares {
+		if !c1.VerifyShare(&shares[i]) || !c2.VerifyShare(&shares[i]) {
+			t.Fatal("valid share rejected")
+		}
+	}
+	shares[1].Value.Add(&shares[1].Value, &secret)
+	if c1.VerifyShare(&shares[1]) || c2.VerifyShare(&shares[1]) {
+		t.Fatal("invalid share accepted")
+	}
+
+	if ok, err := consistent(c1, c2); err != nil || !ok {
+		t.Fatal("commitments to the same polynomial should be consistent")
+	}
+	c2[1], c2[2] = c2[2], c2[1]
+	if ok, _ := consistent(c1, c2); ok {
+		t.Fatal("commitments to different polynomials should be inconsistent")
+	}
+}
+
+func TestThresholdSignature(t *testing.T) {
+	const threshold, n = 3, 5
+
+	var secret fr.Element
+	secret.SetRandom()
+	f, _ := NewPolynomial(&secret, threshold)
+	shares, _ := f.Split(n)
+	c1, c2 := f.CommitG1(), f.CommitG2()
+	msg := []byte("testing threshold BLS")
+
+	t.Run("G1", func(t *testing.T) {
+		pk := c2.PublicKey()
+		partials := make([]PartialSignatureG1, n)
+		for i := range shares {
+			partial, err := SignG1(&shares[i], msg, dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			partials[i] = *partial
+			publicShare, err := c2.PublicShare(partial.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := VerifyPartialG1(partial, &publicShare, msg, dst); err != nil || !ok {
+				t.Fatal("valid partial signature rejected")
+			}
+		}
+
+		sig, err := AggregateG1([]PartialSignatureG1{partials[4], partials[1], partials[2]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := VerifyG1(&sig, &pk, msg, dst); err != nil || !ok {
+			t.Fatal("aggregated signature rejected")
+		}
+		if ok, _ := VerifyG1(&sig, &pk, []byte("wrong message"), dst); ok {
+			t.Fatal("aggregated signature of another message accepted")
+		}
+		if sig, err = AggregateG1(partials[:threshold-1]); err != nil {
+			t.Fatal(err)
+		}
+		if ok, _ := VerifyG1(&sig, &pk, msg, dst); ok {
+			t.Fatal("signature aggregated below the threshold accepted")
+		}
+	})
+
+	t.Run("G2", func(t *testing.T) {
+		pk := c1.PublicKey()
+		partials := make([]PartialSignatureG2, n)
+		for i := range shares {
+			partial, err := SignG2(&shares[i], msg, dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			partials[i] = *partial
+			publicShare, err := c1.PublicShare(partial.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := VerifyPartialG2(partial, &publicShare, msg, dst); err != nil || !ok {
+				t.Fatal("valid partial signature rejected")
+			}
+		}
+
+		sig, err := AggregateG2(partials[1:4])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := VerifyG2(&sig, &pk, msg, dst); err != nil || !ok {
+			t.Fatal("aggregated signature rejected")
+		}
+		if ok, _ := VerifyG2(&sig, &pk, []byte("wrong message"), dst); ok {
+			t.Fatal("aggregated signature of another message accepted")
+		}
+		if _, err = AggregateG2([]PartialSignatureG2{partials[0], partials[0]}); err != ErrDuplicateID {
+			t.Fatal("expected a duplicate identifier error")
+		}
+	})
+}
+
+// benchmarks
+
+func BenchmarkAggregateG1(b *testing.B) {
+	const threshold = 64
+
+	var secret fr.Element
+	secret.SetRandom()
+	f, _ := NewPolynomial(&secret, threshold)
+	shares, _ := f.Split(threshold)
+	partials := make([]PartialSignatureG1, threshold)
+	for i := range shares {
+		partial, _ := SignG1(&shares[i], []byte("benchmarking threshold BLS"), dst)
+		partials[i] = *partial
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		AggregateG1(partials)
+	}
+}
@@ -0,0 +1,534 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package zeromorph
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/polynomial"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrInvalidPolynomialSize = errors.New("the size of the polynomial must be a power of two")
+	ErrPolynomialTooLarge    = errors.New("the polynomial is too large for the SRS")
+	ErrInvalidNbVars         = errors.New("the number of variables doesn't match the size of the point")
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidProof          = errors.New("the number of quotients doesn't match the number of variables")
+)
+
+// Digest commitment of a multilinear polynomial.
+type Digest = kzg.Digest
+
+// ProvingKey used to create or open commitments
+type ProvingKey struct {
+	kzg.ProvingKey
+}
+
+// VerifyingKey used to verify opening proofs
+type VerifyingKey struct {
+	kzg.VerifyingKey
+
+	// Size of the SRS the ProvingKey comes from. The degrees of the quotients
+	// are checked against it.
+	Size uint64
+}
+
+// SRS comprises the ProvingKey and the VerifyingKey
+type SRS struct {
+	Pk ProvingKey
+	Vk VerifyingKey
+}
+
+// NewSRS returns a new SRS for multilinear polynomials of up to nbVars variables
+// using alpha as randomness source. It must be used for tests only, see kzg.NewSRS.
+func NewSRS(nbVars int, bAlpha *big.Int) (*SRS, error) {
+	srs, err := kzg.NewSRS(uint64(1)<<nbVars, bAlpha)
+	if err != nil {
+		return nil, err
+	}
+	return FromKZG(srs), nil
+}
+
+// FromKZG returns the SRS of the multilinear scheme built on a kzg SRS, for
+// polynomials of up to log₂(len(srs.Pk.G1)) variables.
+func FromKZG(srs *kzg.SRS) *SRS {
+	return &SRS{
+		Pk: ProvingKey{srs.Pk},
+		Vk: VerifyingKey{VerifyingKey: srs.Vk, Size: uint64(len(srs.Pk.G1))},
+	}
+}
+
+// OpeningProof Zeromorph proof for opening at a single point.
+type OpeningProof struct {
+	// Quotients are the commitments to the multilinear quotients qₖ of
+	// f - f(u) = ∑ₖ(Xₖ-uₖ)qₖ, where qₖ has k variables.
+	Quotients []Digest
+
+	// QHat is the commitment to ∑ₖyᵏX^{N-2ᵏ}qₖ, which bounds the degrees of the qₖ.
+	QHat Digest
+
+	// W is a KZG proof that the combined identity vanishes at the challenge x.
+	W Digest
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// BatchOpeningProof opening proof for many polynomials at the same point
+type BatchOpeningProof struct {
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+
+	// Proof opens the random linear combination of the polynomials
+	Proof OpeningProof
+}
+
+// Commit commits to a multilinear polynomial, given by its evaluations on the
+// boolean hypercube.
+func Commit(f polynomial.MultiLin, pk ProvingKey, nbTasks ...int) (Digest, error) {
+	if len(f) == 0 || bits.OnesCount(uint(len(f))) != 1 {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+	if len(f) > len(pk.G1) {
+		return Digest{}, ErrPolynomialTooLarge
+	}
+	return kzg.Commit(f, pk.ProvingKey, nbTasks...)
+}
+
+// Open computes an opening proof of f at point, f being the polynomial
+// committed to in digest. The coordinates of point are in the order of
+// polynomial.MultiLin.Evaluate.
+//
+// The names of the challenges are Prefix+"y", Prefix+"x" and Prefix+"z" and
+// must have been declared if a Transcript is provided in transcriptSettings.
+func Open(f polynomial.MultiLin, digest *Digest, point []fr.Element, pk ProvingKey, transcriptSettings fiatshamir.Settings) (OpeningProof, error) {
+	names, err := setupTranscript(false, &transcriptSettings)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	return open(f, digest, point, pk, transcriptSettings.Transcript, names)
+}
+
+// Verify verifies a Zeromorph opening proof at point.
+func Verify(digest *Digest, proof *OpeningProof, point []fr.Element, vk VerifyingKey, transcriptSettings fiatshamir.Settings) error {
+	names, err := setupTranscript(false, &transcriptSettings)
+	if err != nil {
+		return err
+	}
+	folded, x, err := foldProof(digest, proof, point, vk, transcriptSettings.Transcript, names)
+	if err != nil {
+		return err
+	}
+	return kzg.Verify(&folded, &kzg.OpeningProof{H: proof.W}, x, vk.VerifyingKey)
+}
+
+// BatchOpen creates a batch opening proof at point of a list of polynomials.
+// The polynomials are folded with a random challenge and the folded
+// polynomial is opened.
+//
+// The names of the challenges are Prefix+"rho", Prefix+"y", Prefix+"x" and
+// Prefix+"z" and must have been declared if a Transcript is provided in
+// transcriptSettings.
+func BatchOpen(polynomials []polynomial.MultiLin, digests []Digest, point []fr.Element, pk ProvingKey, transcriptSettings fiatshamir.Settings) (BatchOpeningProof, error) {
+	if len(polynomials) != len(digests) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	if len(polynomials) == 0 {
+		return BatchOpeningProof{}, ErrZeroNbDigests
+	}
+	for i := range polynomials {
+		if len(polynomials[i]) != 1<<len(point) {
+			return BatchOpeningProof{}, ErrInvalidNbVars
+		}
+	}
+	names, err := setupTranscript(true, &transcriptSettings)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	var res BatchOpeningProof
+	res.ClaimedValues = make([]fr.Element, len(polynomials))
+	for i := range polynomials {
+		res.ClaimedValues[i] = polynomials[i].Evaluate(point, nil)
+	}
+
+	rho, foldedDigest, err := deriveFolding(digests, point, res.ClaimedValues, transcriptSettings.Transcript, names[0])
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// ∑ᵢρⁱfᵢ
+	folded := polynomials[len(polynomials)-1].Clone()
+	for i := len(polynomials) - 2; i >= 0; i-- {
+		for j := range folded {
+			folded[j].Mul(&folded[j], &rho).Add(&folded[j], &polynomials[i][j])
+		}
+	}
+
+	res.Proof, err = open(folded, &foldedDigest, point, pk, transcriptSettings.Transcript, names[1:])
+	return res, err
+}
+
+// BatchVerify verifies a batch opening proof at point of a list of polynomials.
+func BatchVerify(digests []Digest, proof *BatchOpeningProof, point []fr.Element, vk VerifyingKey, transcriptSettings fiatshamir.Settings) error {
+	if len(digests) != len(proof.ClaimedValues) {
+		return ErrInvalidNbDigests
+	}
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+	names, err := setupTranscript(true, &transcriptSettings)
+	if err != nil {
+		return err
+	}
+
+	rho, foldedDigest, err := deriveFolding(digests, point, proof.ClaimedValues, transcriptSettings.Transcript, names[0])
+	if err != nil {
+		return err
+	}
+
+	// the folded proof must open to ∑ᵢρⁱvᵢ
+	var v fr.Element
+	for i := len(proof.ClaimedValues) - 1; i >= 0; i-- {
+		v.Mul(&v, &rho).Add(&v, &proof.ClaimedValues[i])
+	}
+	if !v.Equal(&proof.Proof.ClaimedValue) {
+		return kzg.ErrVerifyOpeningProof
+	}
+
+	folded, x, err := foldProof(&foldedDigest, &proof.Proof, point, vk, transcriptSettings.Transcript, names[1:])
+	if err != nil {
+		return err
+	}
+	return kzg.Verify(&folded, &kzg.OpeningProof{H: proof.Proof.W}, x, vk.VerifyingKey)
+}
+
+// BatchVerifyMultiPoints verifies opening proofs at different points with a
+// single pairing check. Each proof has its own transcript settings.
+func BatchVerifyMultiPoints(digests []Digest, proofs []OpeningProof, points [][]fr.Element, vk VerifyingKey, transcriptSettings []fiatshamir.Settings) error {
+	if len(digests) != len(proofs) || len(digests) != len(points) || len(digests) != len(transcriptSettings) {
+		return ErrInvalidNbDigests
+	}
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+
+	// each proof reduces to a KZG opening proof at x, with claimed value 0
+	folded := make([]Digest, len(digests))
+	xs := make([]fr.Element, len(digests))
+	kzgProofs := make([]kzg.OpeningProof, len(digests))
+	for i := range digests {
+		names, err := setupTranscript(false, &transcriptSettings[i])
+		if err != nil {
+			return err
+		}
+		if folded[i], xs[i], err = foldProof(&digests[i], &proofs[i], points[i], vk, transcriptSettings[i].Transcript, names); err != nil {
+			return err
+		}
+		kzgProofs[i].H = proofs[i].W
+	}
+	return kzg.BatchVerifyMultiPoints(folded, kzgProofs, xs, vk.VerifyingKey)
+}
+
+// open computes the opening proof of f at point, names being the challenges y, x, z.
+func open(f polynomial.MultiLin, digest *Digest, point []fr.Element, pk ProvingKey, transcript *fiatshamir.Transcript, names []string) (OpeningProof, error) {
+	n := len(point)
+	if len(f) != 1<<n {
+		return OpeningProof{}, ErrInvalidNbVars
+	}
+	N := len(pk.G1)
+	if len(f) > N {
+		return OpeningProof{}, ErrPolynomialTooLarge
+	}
+
+	// qₖ = f⁽ᵏ⁺¹⁾(X₀, ..., Xₖ₋₁, 1) - f⁽ᵏ⁺¹⁾(X₀, ..., Xₖ₋₁, 0) and f⁽ᵏ⁾ = f⁽ᵏ⁺¹⁾(X₀, ..., Xₖ₋₁, uₖ),
+	// where Xₖ is the k-th bit of the index in f, that is the variable point[n-1-k]
+	var proof OpeningProof
+	var err error
+	quotients := make([][]fr.Element, n)
+	proof.Quotients = make([]Digest, n)
+	g := f.Clone()
+	var tmp fr.Element
+	for k := n - 1; k >= 0; k-- {
+		half := 1 << k
+		q := make([]fr.Element, half)
+		for i := range q {
+			q[i].Sub(&g[i+half], &g[i])
+			tmp.Mul(&q[i], &point[n-1-k])
+			g[i].Add(&g[i], &tmp)
+		}
+		g = g[:half]
+		quotients[k] = q
+		if proof.Quotients[k], err = kzg.Commit(q, pk.ProvingKey); err != nil {
+			return OpeningProof{}, err
+		}
+	}
+	proof.ClaimedValue = g[0]
+
+	if err = bindStatement(transcript, names[0], digest, point, &proof); err != nil {
+		return OpeningProof{}, err
+	}
+	y, err := deriveChallenge(transcript, names[0])
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	// q̂ = ∑ₖyᵏX^{N-2ᵏ}qₖ
+	p := make([]fr.Element, N)
+	var yk fr.Element
+	yk.SetOne()
+	for k := range quotients {
+		offset := N - 1<<k
+		for i := range quotients[k] {
+			tmp.Mul(&quotients[k][i], &yk)
+			p[offset+i].Add(&p[offset+i], &tmp)
+		}
+		yk.Mul(&yk, &y)
+	}
+	if proof.QHat, err = kzg.Commit(p, pk.ProvingKey); err != nil {
+		return OpeningProof{}, err
+	}
+
+	bQHat := proof.QHat.RawBytes()
+	if err = transcript.Bind(names[1], bQHat[:]); err != nil {
+		return OpeningProof{}, err
+	}
+	x, err := deriveChallenge(transcript, names[1])
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	z, err := deriveChallenge(transcript, names[2])
+	if err != nil {
+		return OpeningProof{}, err
+	}
+
+	// ζₓ + z·Zₓ, where
+	// ζₓ = q̂ - ∑ₖyᵏx^{N-2ᵏ}qₖ
+	// Zₓ = f - v·Φₙ(x) - ∑ₖcₖ(x)qₖ
+	// both vanish at x.
+	scalars, phi := quotientScalars(x, y, z, point, uint64(N))
+	for i := range f {
+		tmp.Mul(&f[i], &z)
+		p[i].Add(&p[i], &tmp)
+	}
+	tmp.Mul(&proof.ClaimedValue, &phi).Mul(&tmp, &z)
+	p[0].Sub(&p[0], &tmp)
+	for k := range quotients {
+		for i := range quotients[k] {
+			tmp.Mul(&quotients[k][i], &scalars[k])
+			p[i].Sub(&p[i], &tmp)
+		}
+	}
+
+	w, err := kzg.Open(p, x, pk.ProvingKey)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	proof.W = w.H
+
+	return proof, nil
+}
+
+// foldProof returns the commitment to ζₓ + z·Zₓ and the point x, at which it
+// must vanish.
+func foldProof(digest *Digest, proof *OpeningProof, point []fr.Element, vk VerifyingKey, transcript *fiatshamir.Transcript, names []string) (Digest, fr.Element, error) {
+	n := len(point)
+	if len(proof.Quotients) != n {
+		return Digest{}, fr.Element{}, ErrInvalidProof
+	}
+	if uint64(1)<<n > vk.Size {
+		return Digest{}, fr.Element{}, ErrPolynomialTooLarge
+	}
+
+	if err := bindStatement(transcript, names[0], digest, point, proof); err != nil {
+		return Digest{}, fr.Element{}, err
+	}
+	y, err := deriveChallenge(transcript, names[0])
+	if err != nil {
+		return Digest{}, fr.Element{}, err
+	}
+	bQHat := proof.QHat.RawBytes()
+	if err = transcript.Bind(names[1], bQHat[:]); err != nil {
+		return Digest{}, fr.Element{}, err
+	}
+	x, err := deriveChallenge(transcript, names[1])
+	if err != nil {
+		return Digest{}, fr.Element{}, err
+	}
+	z, err := deriveChallenge(transcript, names[2])
+	if err != nil {
+		return Digest{}, fr.Element{}, err
+	}
+
+	// Q̂ + z·C - z·v·Φₙ(x)·G₁ - ∑ₖ(yᵏx^{N-2ᵏ} + z·cₖ(x))·Qₖ
+	scalars, phi := quotientScalars(x, y, z, point, vk.Size)
+	points := make([]Digest, 0, n+3)
+	points = append(points, proof.QHat, *digest, vk.G1)
+	points = append(points, proof.Quotients...)
+	coeffs := make([]fr.Element, 3, n+3)
+	coeffs[0].SetOne()
+	coeffs[1] = z
+	coeffs[2].Mul(&proof.ClaimedValue, &phi).Mul(&coeffs[2], &z).Neg(&coeffs[2])
+	for k := range scalars {
+		scalars[k].Neg(&scalars[k])
+	}
+	coeffs = append(coeffs, scalars...)
+
+	var folded Digest
+	if _, err = folded.MultiExp(points, coeffs, ecc.MultiExpConfig{}); err != nil {
+		return Digest{}, fr.Element{}, err
+	}
+	return folded, x, nil
+}
+
+// quotientScalars returns the coefficients yᵏx^{N-2ᵏ} + z·cₖ(x) of the quotients
+// in ζₓ + z·Zₓ, where cₖ(x) = x^{2ᵏ}Φₙ₋ₖ₋₁(x^{2ᵏ⁺¹}) - uₖΦₙ₋ₖ(x^{2ᵏ}), and Φₙ(x).
+// Φₘ(X) = ∑_{i<2ᵐ}Xⁱ = ∏_{i<m}(1+X^{2ⁱ}).
+func quotientScalars(x, y, z fr.Element, point []fr.Element, N uint64) ([]fr.Element, fr.Element) {
+	n := len(point)
+
+	// x^{2ᵏ} for k < n and Sₖ = ∏_{k≤i<n}(1+x^{2ⁱ}) = Φₙ₋ₖ(x^{2ᵏ})
+	xPow := make([]fr.Element, n)
+	s := make([]fr.Element, n+1)
+	if n > 0 {
+		xPow[0] = x
+	}
+	for k := 1; k < n; k++ {
+		xPow[k].Square(&xPow[k-1])
+	}
+	s[n].SetOne()
+	var one fr.Element
+	one.SetOne()
+	for k := n - 1; k >= 0; k-- {
+		s[k].Add(&xPow[k], &one).Mul(&s[k], &s[k+1])
+	}
+
+	// x^{N-2ᵏ}
+	var xN big.Int
+	xN.SetUint64(N)
+	var xToN fr.Element
+	xToN.Exp(x, &xN)
+	xPowInv := fr.BatchInvert(xPow)
+
+	res := make([]fr.Element, n)
+	var yk, c, tmp fr.Element
+	yk.SetOne()
+	for k := range res {
+		c.Mul(&xPow[k], &s[k+1])
+		tmp.Mul(&point[n-1-k], &s[k])
+		c.Sub(&c, &tmp).Mul(&c, &z)
+		res[k].Mul(&xToN, &xPowInv[k]).Mul(&res[k], &yk).Add(&res[k], &c)
+		yk.Mul(&yk, &y)
+	}
+
+	return res, s[0]
+}
+
+// deriveFolding binds the digests, the point and the claimed values to the
+// challenge name and returns the folding challenge ρ and ∑ᵢρⁱdigests[i].
+func deriveFolding(digests []Digest, point, claimedValues []fr.Element, transcript *fiatshamir.Transcript, name string) (fr.Element, Digest, error) {
+	for i := range digests {
+		b := digests[i].RawBytes()
+		if err := transcript.Bind(name, b[:]); err != nil {
+			return fr.Element{}, Digest{}, err
+		}
+	}
+	for _, s := range [][]fr.Element{point, claimedValues} {
+		for i := range s {
+			b := s[i].Bytes()
+			if err := transcript.Bind(name, b[:]); err != nil {
+				return fr.Element{}, Digest{}, err
+			}
+		}
+	}
+	rho, err := deriveChallenge(transcript, name)
+	if err != nil {
+		return fr.Element{}, Digest{}, err
+	}
+
+	rhos := make([]fr.Element, len(digests))
+	rhos[0].SetOne()
+	for i := 1; i < len(rhos); i++ {
+		rhos[i].Mul(&rhos[i-1], &rho)
+	}
+	var folded Digest
+	if _, err = folded.MultiExp(digests, rhos, ecc.MultiExpConfig{}); err != nil {
+		return fr.Element{}, Digest{}, err
+	}
+	return rho, folded, nil
+}
+
+// bindStatement binds the digest, the point, the claimed value and the
+// quotients of the proof to the challenge name.
+func bindStatement(transcript *fiatshamir.Transcript, name string, digest *Digest, point []fr.Element, proof *OpeningProof) error {
+	b := digest.RawBytes()
+	if err := transcript.Bind(name, b[:]); err != nil {
+		return err
+	}
+	for i := range point {
+		b := point[i].Bytes()
+		if err := transcript.Bind(name, b[:]); err != nil {
+			return err
+		}
+	}
+	bValue := proof.ClaimedValue.Bytes()
+	if err := transcript.Bind(name, bValue[:]); err != nil {
+		return err
+	}
+	for i := range proof.Quotients {
+		b := proof.Quotients[i].RawBytes()
+		if err := transcript.Bind(name, b[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setupTranscript(batch bool, settings *fiatshamir.Settings) (challengeNames []string, err error) {
+	challengeNames = []string{settings.Prefix + "y", settings.Prefix + "x", settings.Prefix + "z"}
+	if batch {
+		challengeNames = append([]string{settings.Prefix + "rho"}, challengeNames...)
+	}
+	if settings.Transcript == nil {
+		transcript := fiatshamir.NewTranscript(settings.Hash, challengeNames...)
+		settings.Transcript = &transcript
+	}
+
+	for i := range settings.BaseChallenges {
+		if err = settings.Transcript.Bind(challengeNames[0], settings.BaseChallenges[i]); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func deriveChallenge(transcript *fiatshamir.Transcript, name string) (fr.Element, error) {
+	var res fr.Element
+	b, err := transcript.ComputeChallenge(name)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(b)
+	return res, nil
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package accumulator provides a pairing-based dynamic accumulator (Nguyen, CT-RSA 2005).
+//
+// A set {x₁,...,xₙ} ⊂ fr is accumulated in [∏ᵢ(α+xᵢ)]G₁, where α is the trapdoor of a KZG SRS.
+// The package supports addition and deletion of elements, membership and non-membership
+// witnesses, witness updates from the published accumulator updates and verification
+// with a single pairing check.
+//
+// The holder of α (see Manager) updates the accumulator and creates witnesses in constant time.
+// Without α, the accumulator and the witnesses can be computed from a KZG proving key, which
+// must be at least one element larger than the accumulated set.
+//
+// See https://eprint.iacr.org/2005/123.pdf and https://eprint.iacr.org/2008/538.pdf
+package accumulator
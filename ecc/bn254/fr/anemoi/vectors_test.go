@@ -0,0 +1,54 @@
+package anemoi
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func elementOf(s string) (res fr.Element) {
+	if _, err := res.SetString(s); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// known-answer vectors of the instance with ℓ = 1 of ePrint 2022/840, computed with
+// a transliteration of the authors' anemoi.py (permutation, jive and sponge_hash)
+func TestVectors(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	assert.Equal(5, params.Alpha)
+	assert.Equal(21, params.NbRounds)
+	assert.Equal("37", params.C[0].String())
+	assert.Equal("8755297148735710088898562298102910035419345760166413737479281674630323398284", params.D[0].String())
+	assert.Equal("1306629433785941949794412902219355154331596139829361586308504673397223571836", params.C[1].String())
+	assert.Equal("13385643141323440917248000174090120923732656564948191712695450586517966912182", params.D[1].String())
+
+	var x, y fr.Element
+	params.Permutation(&x, &y)
+	assert.Equal("1332968686644143296255837701756922566066300289841138130764091490130068921177", x.String())
+	assert.Equal("9806374700793745216055880276019693008733304529292161563371802205670280925390", y.String())
+
+	x, y = elementOf("1"), elementOf("2")
+	params.Permutation(&x, &y)
+	assert.Equal("2478704670152296924216305350486540792839401653248424005294780903329165806559", x.String())
+	assert.Equal("8806228415918607747891323680248680190155286281630929597547776716634301186690", y.String())
+
+	c := params.Compress(elementOf("1"), elementOf("2"))
+	assert.Equal("11284933086070904672107629030735220982994687934879353602842557619963466993252", c.String())
+
+	for _, v := range []struct {
+		in  []fr.Element
+		out string
+	}{
+		{nil, "0"},
+		{[]fr.Element{elementOf("1")}, "682211014270779191672950753601173132976227519111036343839321697297230438028"},
+		{[]fr.Element{elementOf("1"), elementOf("2")}, "16739416800667620809834925320209176487988767182098142249077240873094197027497"},
+		{[]fr.Element{elementOf("1"), elementOf("2"), elementOf("3")}, "8686575230489837569199560664137848694685729905321481000845127909723203401286"},
+	} {
+		h := params.Hash(v.in)
+		assert.Equal(v.out, h.String())
+	}
+}
@@ -0,0 +1,49 @@
+package griffin
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func elementOf(s string) (res fr.Element) {
+	if _, err := res.SetString(s); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// known-answer vectors computed with a transliteration of the reference
+// implementation of the authors of ePrint 2022/403
+func TestVectors(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	assert.Equal(5, params.D)
+	assert.Equal(12, params.NbRounds)
+	assert.Equal("9242045582776035982243706926516204235817048582477991018040169113011339176522", params.Alpha.String())
+	assert.Equal("19602292250548824693018549751754462955740127433771860547414036906211039243804", params.Beta.String())
+	assert.Equal("21575057070032013575607370249422922168572843616054088010296822695840749775561", params.RoundConstants[0].String())
+	assert.Equal("10664341432766012784920284332819108541459655048225303977605704215204171730007", params.RoundConstants[len(params.RoundConstants)-1].String())
+
+	state := [Width]fr.Element{elementOf("0"), elementOf("1"), elementOf("2")}
+	params.Permutation(&state)
+	assert.Equal("15862405785128810275837435502653224425290071258167230490599117376332100235254", state[0].String())
+	assert.Equal("13220756517509979517684528785753328587257706928708746278499548208567338458968", state[1].String())
+	assert.Equal("15550532036911446928426039913328049280239190234626561457568755196858003615133", state[2].String())
+
+	for _, v := range []struct {
+		in  []fr.Element
+		out [Rate]string
+	}{
+		{nil, [Rate]string{"17303855132809064927836378212507246367716290348721427700310210100118049254435", "10531886394741842225652201912597735131729478680913277098481444841927344303884"}},
+		{[]fr.Element{elementOf("1")}, [Rate]string{"11845863591594197305787596900544038800445364172028721748795549428411075826293", "9575075246911060937515117965035258275166087985577914444744083713343985977367"}},
+		{[]fr.Element{elementOf("1"), elementOf("2")}, [Rate]string{"1682703890525691693599431962882949335275733785677155905569164634591258756176", "13836919184417009343936960201056491308094688392344795682243355685177159823665"}},
+		{[]fr.Element{elementOf("1"), elementOf("2"), elementOf("3")}, [Rate]string{"8697097258336040701309098488304217797596820843879888587743095856557913240869", "3470769517799738282574324514880420407649634960738501564469431873199458506835"}},
+	} {
+		h := params.Hash(v.in)
+		for i := range h {
+			assert.Equal(v.out[i], h[i].String())
+		}
+	}
+}
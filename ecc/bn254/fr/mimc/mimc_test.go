@@ -1,10 +1,16 @@
 package mimc_test
 
 import (
+	"encoding/json"
+	"hash"
+	"os"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
 	"github.com/stretchr/testify/assert"
-	"testing"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMiMCFiatShamir(t *testing.T) {
@@ -15,3 +21,189 @@ func TestMiMCFiatShamir(t *testing.T) {
 	_, err = fs.ComputeChallenge("c0")
 	assert.NoError(t, err)
 }
+
+func elementsToBytes(elems ...fr.Element) []byte {
+	res := make([]byte, 0, len(elems)*fr.Bytes)
+	for i := range elems {
+		b := elems[i].Bytes()
+		res = append(res, b[:]...)
+	}
+	return res
+}
+
+func elementOf(s string) fr.Element {
+	var x fr.Element
+	if _, err := x.SetString(s); err != nil {
+		panic(err)
+	}
+	return x
+}
+
+func TestMiMCVectors(t *testing.T) {
+	bytes, err := os.ReadFile("./test_vectors/vectors.json")
+	require.NoError(t, err)
+	var vectors []struct {
+		In  []string `json:"in"`
+		Out string   `json:"out"`
+	}
+	require.NoError(t, json.Unmarshal(bytes, &vectors))
+
+	h := mimc.NewMiMC()
+	for _, v := range vectors {
+		h.Reset()
+		for _, in := range v.In {
+			_, err = h.Write(elementsToBytes(elementOf(in)))
+			require.NoError(t, err)
+		}
+		out := elementOf(v.Out)
+		assert.Equal(t, elementsToBytes(out), h.Sum(nil))
+	}
+}
+
+func TestMiMCOptions(t *testing.T) {
+	assert := require.New(t)
+
+	// the default parameters are those of NewMiMC
+	params, err := mimc.NewParams()
+	assert.NoError(err)
+	x := elementsToBytes(elementOf("1007"), elementOf("2007"))
+	h := mimc.NewMiMC()
+	_, err = h.Write(x)
+	assert.NoError(err)
+	withParams := mimc.NewMiMCWithParams(params)
+	_, err = withParams.Write(x)
+	assert.NoError(err)
+	assert.Equal(h.Sum(nil), withParams.Sum(nil))
+
+	// customized parameters
+	params, err = mimc.NewParams(mimc.WithSeed("other seed"), mimc.WithNbRounds(91), mimc.WithExponent(7))
+	assert.NoError(err)
+	assert.Equal(91, len(params.Constants))
+	assert.Equal(7, params.Exponent)
+	custom := mimc.NewMiMC(mimc.WithSeed("other seed"), mimc.WithNbRounds(91), mimc.WithExponent(7))
+	_, err = custom.Write(x)
+	assert.NoError(err)
+	assert.NotEqual(h.Sum(nil), custom.Sum(nil))
+
+	// Miyaguchi–Preneel from the raw encryption
+	var expected fr.Element
+	for _, m := range []fr.Element{elementOf("1007"), elementOf("2007")} {
+		r := params.Encrypt(m, expected)
+		expected.Add(&expected, &r).Add(&expected, &m)
+	}
+	assert.Equal(elementsToBytes(expected), custom.Sum(nil))
+
+	// x ↦ x³ is not a permutation of the bn254 scalar field
+	_, err = mimc.NewParams(mimc.WithExponent(3))
+	assert.Error(err)
+	_, err = mimc.NewParams(mimc.WithExponent(4))
+	assert.Error(err)
+	_, err = mimc.NewSpongeParams(mimc.WithNbRounds(0))
+	assert.Error(err)
+	assert.Panics(func() { mimc.NewMiMC(mimc.WithExponent(3)) })
+}
+
+func TestMiMCSponge(t *testing.T) {
+	assert := require.New(t)
+
+	params, err := mimc.NewSpongeParams()
+	assert.NoError(err)
+
+	// constants of circomlib's mimcsponge.circom
+	assert.Equal(220, len(params.Constants))
+	assert.True(params.Constants[0].IsZero())
+	assert.Equal("7120861356467848435263064379192047478074060781135320967663101236819528304084", params.Constants[1].String())
+	assert.Equal("5024705281721889198577876690145313457398658950011302225525409148828000436681", params.Constants[2].String())
+	assert.True(params.Constants[219].IsZero())
+
+	// regression vectors
+	xL, xR := params.Permute(elementOf("1"), elementOf("2"), elementOf("3"))
+	assert.Equal("18444058245820418255538785847032978363886102372504864086197416499869253008979", xL.String())
+	assert.Equal("2646733164649743153031645792459389637917704265581895142760676293265176296759", xR.String())
+
+	out := params.SpongeHash([]fr.Element{elementOf("1"), elementOf("2"), elementOf("3")}, fr.Element{}, 3)
+	assert.Equal("13347232259103605288126215296295968657023270572136673486116911774162409637522", out[0].String())
+	assert.Equal("21631365138607353745907388069625267508930592880820057533356376809857973361392", out[1].String())
+	assert.Equal("20873567787080299535990585760555761221525906582034981122227302874458019883150", out[2].String())
+
+	h := mimc.NewMiMCSponge()
+	_, err = h.Write(elementsToBytes(elementOf("1"), elementOf("2")))
+	assert.NoError(err)
+	assert.Equal(elementsToBytes(elementOf("19814528709687996974327303300007262407299502847885145507292406548098437687919")), h.Sum(nil))
+	h.Reset()
+	assert.Equal(make([]byte, fr.Bytes), h.Sum(nil))
+	_, err = h.Write(make([]byte, fr.Bytes+1))
+	assert.Error(err)
+}
+
+func TestMiMCWriteElements(t *testing.T) {
+	assert := require.New(t)
+	elems := []fr.Element{elementOf("1007"), elementOf("2007"), elementOf("3007")}
+
+	for _, h := range []hash.Hash{mimc.NewMiMC(), mimc.NewMiMCSponge()} {
+		_, err := h.Write(elementsToBytes(elems...))
+		assert.NoError(err)
+		expected := h.Sum(nil)
+
+		h.Reset()
+		h.(mimc.FieldHasher).WriteElements(elems[0])
+		h.(mimc.FieldHasher).WriteElements(elems[1:]...)
+		assert.Equal(expected, h.Sum(nil))
+	}
+}
+
+func TestMiMCChunkPadding(t *testing.T) {
+	assert := require.New(t)
+	assert.Equal(31, mimc.ChunkSize)
+
+	msg := make([]byte, 2*mimc.ChunkSize+5)
+	for i := range msg {
+		msg[i] = 0xff
+	}
+
+	// the chunks, and the padded last one, as field elements
+	var chunks [3]fr.Element
+	chunks[0].SetBytes(msg[:mimc.ChunkSize])
+	chunks[1].SetBytes(msg[mimc.ChunkSize : 2*mimc.ChunkSize])
+	last := make([]byte, mimc.ChunkSize)
+	copy(last, msg[2*mimc.ChunkSize:])
+	last[5] = 0x80
+	chunks[2].SetBytes(last)
+
+	for _, newHash := range []func(...mimc.Option) hash.Hash{mimc.NewMiMC, mimc.NewMiMCSponge} {
+		h := newHash()
+		_, err := h.Write(elementsToBytes(chunks[:]...))
+		assert.NoError(err)
+		expected := h.Sum(nil)
+
+		// any split of the input
+		h = newHash(mimc.WithPadding(mimc.ChunkPadding))
+		for _, part := range [][]byte{msg[:3], msg[3:40], msg[40:40], msg[40:]} {
+			n, err := h.Write(part)
+			assert.NoError(err)
+			assert.Equal(len(part), n)
+		}
+		assert.Equal(expected, h.Sum(nil))
+
+		// the padding is added even to full chunks
+		h.Reset()
+		_, err = h.Write(msg[:2*mimc.ChunkSize])
+		assert.NoError(err)
+		full := h.Sum(nil)
+		h.Reset()
+		h.(mimc.FieldHasher).WriteElements(chunks[:2]...)
+		assert.NotEqual(full, h.Sum(nil))
+
+		// bytes written before field elements are padded first
+		h.Reset()
+		_, err = h.Write(msg[2*mimc.ChunkSize:])
+		assert.NoError(err)
+		h.(mimc.FieldHasher).WriteElements(chunks[0])
+		mixed := h.Sum(nil)
+		h = newHash()
+		h.(mimc.FieldHasher).WriteElements(chunks[2], chunks[0])
+		assert.Equal(mixed, h.Sum(nil))
+	}
+
+	assert.Panics(func() { mimc.NewMiMC(mimc.WithPadding(2)) })
+}
@@ -0,0 +1,49 @@
+package rescue
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func elementOf(s string) (res fr.Element) {
+	if _, err := res.SetString(s); err != nil {
+		panic(err)
+	}
+	return
+}
+
+// known-answer vectors computed with a transliteration of rescue_prime.py, the
+// reference implementation of the Rescue-Prime specification
+func TestVectors(t *testing.T) {
+	assert := require.New(t)
+	params := GetParams()
+	assert.Equal(5, params.Alpha)
+	assert.Equal(14, params.NbRounds)
+	assert.Equal("125", params.MDS[0][0].String())
+	assert.Equal("21888242871839275222246405745257275088548364400416034343698204186575808374562", params.MDS[2][1].String())
+	assert.Equal("16315208746038078395621556119853320273013100435293928429550050637277758017174", params.RoundConstants[0].String())
+	assert.Equal("4576175540841587341526490874361404231244363959202502577862525676232237092106", params.RoundConstants[len(params.RoundConstants)-1].String())
+
+	state := [Width]fr.Element{elementOf("0"), elementOf("1"), elementOf("2")}
+	params.Permutation(&state)
+	assert.Equal("6224690566795026170272976986384432621080028281436539532889157379570648910802", state[0].String())
+	assert.Equal("11125085147280074555337181371265636082619440214910773293161304065299707718600", state[1].String())
+	assert.Equal("12118779605307541175395572293313884052054477690855880723785138715937774904848", state[2].String())
+
+	for _, v := range []struct {
+		in  []fr.Element
+		out [Rate]string
+	}{
+		{nil, [Rate]string{"11859570646544414528448865934361814928682472944063369147923859205431563103349", "21375695955579975596538309438706857741777746532051819135338595035329530298717"}},
+		{[]fr.Element{elementOf("1")}, [Rate]string{"16403682255832549582587630948744912855543018533662319865511500553377230621437", "20881990266901692897288830888261826638387676245678319571032221169918588697455"}},
+		{[]fr.Element{elementOf("1"), elementOf("2")}, [Rate]string{"19955277490808493510831169602631407111104744046414437667271324145367080531545", "649740822031455595330432760014348331074228589165010691290466708483664201035"}},
+		{[]fr.Element{elementOf("1"), elementOf("2"), elementOf("3")}, [Rate]string{"10426312538076513787842576207928300055993667092218520332880144400670314798844", "4184464354440461145180986148039895060330502056651856767983333175045473850499"}},
+	} {
+		h := params.Hash(v.in)
+		for i := range h {
+			assert.Equal(v.out[i], h[i].String())
+		}
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package anemoi provides a hash function built on the Anemoi permutation.
+//
+// The permutation acts on a state (x, y) of 2 field elements (ℓ = 1) and is used in a
+// sponge of rate 1 and capacity 1, and in the Jive 2-to-1 compression mode. Its
+// structure is taken from "New Design Techniques for Efficient Arithmetization-Oriented
+// Hash Functions: Anemoi Permutations and Jive Compression Mode" (ePrint 2022/840): the
+// exponent α is the smallest integer ≥ 3 coprime with r-1, β = g is the smallest
+// generator of fr*, γ = 0, δ = g⁻¹ and the number of rounds targets 128 bits of security.
+//
+// As in the reference implementation of the paper's authors, the round constants
+// Cᵣ = g·(π₀ʳ)² + (π₀ʳ + 1)^α and Dᵣ = g + (π₀ʳ + 1)^α + δ are derived from the digits
+// π₀ and π₁ of π (π₁⁰ = 1 for ℓ = 1), and the sponge pads its inputs only when their
+// number is not a multiple of the rate.
+package anemoi
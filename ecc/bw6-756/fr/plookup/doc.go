@@ -14,5 +14,8 @@
 
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
-// Package plookup provides an API to build plookup proofs.
+// Package plookup provides an API to build plookup proofs, and log-derivative
+// (LogUp) lookup proofs with multiplicities.
+//
+// See https://eprint.iacr.org/2020/315.pdf and https://eprint.iacr.org/2022/1530.pdf
 package plookup
@@ -0,0 +1,30 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package rescue provides a hash function built on the Rescue-Prime permutation.
+//
+// The permutation acts on a state of 3 field elements and is used in a sponge of
+// rate 2 and capacity 1, targeting 128 bits of security. Its structure is taken from
+// "Rescue-Prime: a Standard Specification (SoK)" (ePrint 2020/1143): the exponent is
+// the smallest α ≥ 3 coprime with r-1, the number of rounds is bound by the Gröbner
+// basis attack with a 50% margin, the round constants are drawn from SHAKE256 and the
+// MDS matrix is derived from a Vandermonde matrix.
+//
+// The round constants, read from SHAKE256("Rescue-XLIX(r,3,1,128)"), the MDS matrix,
+// built from the smallest generator of fr*, and the padding of the sponge, with a one
+// and zeros up to a multiple of the rate, follow the reference implementation of the
+// specification.
+package rescue
@@ -0,0 +1,194 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"runtime"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// MultiExp sets p to ∑ scalars[i]·points[i] and returns it.
+//
+// See PointExtended.MultiExp.
+func (p *PointAffine) MultiExp(points []PointAffine, scalars []big.Int, config ecc.MultiExpConfig) (*PointAffine, error) {
+	var res PointExtended
+	if _, err := res.MultiExp(points, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromExtended(&res)
+	return p, nil
+}
+
+// MultiExp sets p to ∑ scalars[i]·points[i] and returns it. It implements
+// section 4 of https://eprint.iacr.org/2012/549.pdf:
+//
+//   - the scalars, reduced modulo the order of the curve, are split in c-bit
+//     windows of signed digits in [-2^{c-1}, 2^{c-1}], so that only 2^{c-1}
+//     buckets are needed per window (negating an Edwards point is cheap)
+//   - the windows are processed in parallel; for each of them, the points are
+//     accumulated in the buckets of their digits, in extended coordinates, and
+//     the buckets are reduced to their weighted sum
+//   - the weighted sums of the windows are combined with doublings
+//
+// The scalars are reduced modulo the order of the prime subgroup, so that the
+// result is ∑ scalars[i]·points[i] only up to a small-order point when some of
+// the points are not in that subgroup.
+//
+// This call returns an error if len(scalars) != len(points) or if the provided
+// config is invalid.
+func (p *PointExtended) MultiExp(points []PointAffine, scalars []big.Int, config ecc.MultiExpConfig) (*PointExtended, error) {
+	nbPoints := len(points)
+	if nbPoints != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+
+	// if nbTasks is not set, use all available CPUs
+	if config.NbTasks <= 0 {
+		config.NbTasks = runtime.NumCPU() * 2
+	} else if config.NbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	if nbPoints == 0 {
+		p.setInfinity()
+		return p, nil
+	}
+
+	initOnce.Do(initCurveParams)
+	c := bestC(nbPoints)
+	// an extra window absorbs the carry of the last signed digit
+	nbChunks := (curveParams.Order.BitLen()+c-1)/c + 1
+
+	digits := partitionScalars(scalars, c, nbChunks, config.NbTasks)
+
+	extended := make([]PointExtended, nbPoints)
+	parallel.Execute(nbPoints, func(start, end int) {
+		for i := start; i < end; i++ {
+			extended[i].FromAffine(&points[i])
+		}
+	}, config.NbTasks)
+
+	chunks := make([]PointExtended, nbChunks)
+	parallel.Execute(nbChunks, func(start, end int) {
+		for j := start; j < end; j++ {
+			processChunk(&chunks[j], c, extended, digits[j*nbPoints:(j+1)*nbPoints])
+		}
+	}, config.NbTasks)
+
+	// p = ∑ⱼ 2^{jc}·chunks[j]
+	var res PointExtended
+	res.Set(&chunks[nbChunks-1])
+	for j := nbChunks - 2; j >= 0; j-- {
+		for l := 0; l < c; l++ {
+			res.Double(&res)
+		}
+		res.Add(&res, &chunks[j])
+	}
+	p.Set(&res)
+	return p, nil
+}
+
+// bestC returns the window size minimizing the number of additions,
+// (number of windows)·(nbPoints + 2^c)
+func bestC(nbPoints int) int {
+	nbBits := curveParams.Order.BitLen()
+	best, bestCost := 2, -1
+	for c := 2; c <= 16; c++ {
+		cost := ((nbBits+c-1)/c + 1) * (nbPoints + (1 << c))
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// partitionScalars returns the signed c-bit digits of the scalars reduced modulo
+// the order, the j-th digits of all the scalars being stored contiguously.
+func partitionScalars(scalars []big.Int, c, nbChunks, nbTasks int) []int32 {
+	nbPoints := len(scalars)
+	digits := make([]int32, nbChunks*nbPoints)
+	half := int32(1) << (c - 1)
+	parallel.Execute(nbPoints, func(start, end int) {
+		var reduced big.Int
+		for i := start; i < end; i++ {
+			s := &scalars[i]
+			if s.Sign() < 0 || s.Cmp(&curveParams.Order) >= 0 {
+				s = reduced.Mod(s, &curveParams.Order)
+			}
+			words := s.Bits()
+			var carry int32
+			for j := 0; j < nbChunks; j++ {
+				d := int32(window(words, j*c, c)) + carry
+				carry = 0
+				if d > half {
+					d -= 1 << c
+					carry = 1
+				}
+				digits[j*nbPoints+i] = d
+			}
+		}
+	}, nbTasks)
+	return digits
+}
+
+// window returns the c bits of words starting at offset
+func window(words []big.Word, offset, c int) uint {
+	const wordSize = bits.UintSize
+	i, shift := offset/wordSize, offset%wordSize
+	if i >= len(words) {
+		return 0
+	}
+	w := uint(words[i]) >> shift
+	if shift+c > wordSize && i+1 < len(words) {
+		w |= uint(words[i+1]) << (wordSize - shift)
+	}
+	return w & (1<<c - 1)
+}
+
+// processChunk sets res to ∑ᵢ digits[i]·points[i], using 2^{c-1} buckets
+func processChunk(res *PointExtended, c int, points []PointExtended, digits []int32) {
+	buckets := make([]PointExtended, 1<<(c-1))
+	for i := range buckets {
+		buckets[i].setInfinity()
+	}
+
+	var neg PointExtended
+	for i, d := range digits {
+		switch {
+		case d > 0:
+			buckets[d-1].Add(&buckets[d-1], &points[i])
+		case d < 0:
+			neg.Neg(&points[i])
+			buckets[-d-1].Add(&buckets[-d-1], &neg)
+		}
+	}
+
+	// ∑ₖ (k+1)·buckets[k]
+	var runningSum, total PointExtended
+	runningSum.setInfinity()
+	total.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		runningSum.Add(&runningSum, &buckets[k])
+		total.Add(&total, &runningSum)
+	}
+	res.Set(&total)
+}
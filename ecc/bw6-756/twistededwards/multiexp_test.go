@@ -0,0 +1,121 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// randomPoints returns n random multiples of the base point
+func randomPoints(n int) []PointAffine {
+	params := GetEdwardsCurve()
+	points := make([]PointAffine, n)
+	for i := range points {
+		s, _ := rand.Int(rand.Reader, &params.Order)
+		points[i].ScalarMultiplication(&params.Base, s)
+	}
+	return points
+}
+
+func TestMultiExp(t *testing.T) {
+	t.Parallel()
+	params := GetEdwardsCurve()
+
+	sizes := []int{0, 1, 2, 5, 33, 128}
+	if !testing.Short() {
+		sizes = append(sizes, 1000)
+	}
+
+	for _, n := range sizes {
+		points := randomPoints(n)
+		scalars := make([]big.Int, n)
+		for i := range scalars {
+			switch i % 5 {
+			case 0:
+				// scalars larger than the order are reduced
+				scalars[i].Lsh(&params.Order, 3)
+				scalars[i].Add(&scalars[i], big.NewInt(int64(i)))
+			case 1:
+				scalars[i].Sub(&params.Order, big.NewInt(1))
+			case 2:
+				scalars[i].Neg(big.NewInt(int64(i)))
+			default:
+				s, _ := rand.Int(rand.Reader, &params.Order)
+				scalars[i].Set(s)
+			}
+		}
+
+		var expected, tmp PointExtended
+		expected.setInfinity()
+		for i := range points {
+			var p PointExtended
+			p.FromAffine(&points[i])
+			tmp.ScalarMultiplication(&p, &scalars[i])
+			expected.Add(&expected, &tmp)
+		}
+
+		for _, nbTasks := range []int{0, 1, 7} {
+			var actual PointExtended
+			if _, err := actual.MultiExp(points, scalars, ecc.MultiExpConfig{NbTasks: nbTasks}); err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equal(&expected) {
+				t.Fatalf("MultiExp of %d points with %d tasks doesn't match the sum of scalar multiplications", n, nbTasks)
+			}
+		}
+
+		var actual, e PointAffine
+		if _, err := actual.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		e.FromExtended(&expected)
+		if !actual.Equal(&e) {
+			t.Fatalf("affine MultiExp of %d points doesn't match", n)
+		}
+	}
+}
+
+func TestMultiExpInvalidInput(t *testing.T) {
+	t.Parallel()
+	var p PointExtended
+	if _, err := p.MultiExp(randomPoints(2), make([]big.Int, 3), ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("expected an error on mismatched lengths")
+	}
+	if _, err := p.MultiExp(nil, nil, ecc.MultiExpConfig{NbTasks: 2048}); err == nil {
+		t.Fatal("expected an error on invalid config")
+	}
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	const n = 1 << 10
+	params := GetEdwardsCurve()
+	points := randomPoints(n)
+	scalars := make([]big.Int, n)
+	for i := range scalars {
+		s, _ := rand.Int(rand.Reader, &params.Order)
+		scalars[i].Set(s)
+	}
+	var p PointExtended
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+)
+
+var (
+	errInfinity        = errors.New("point at infinity")
+	errAdaptorMismatch = errors.New("secret doesn't match the adaptor point")
+	errNotAdapted      = errors.New("signature is not adapted from the pre-signature")
+)
+
+// domain separation of the challenge of the discrete logarithm equality proof
+const dleqDomain = "gnark-crypto ECDSA adaptor DLEQ"
+
+const (
+	sizePoint        = secp256k1.SizeOfG1AffineUncompressed
+	sizePreSignature = 2*sizePoint + 3*sizeFr
+)
+
+// PreSignature is an ECDSA adaptor signature for an adaptor point T = t⋅G
+//
+// R = k⋅T
+// R' = k⋅G
+// s = k⁻¹ ⋅ (m + r ⋅ sk), where r = x_R (mod order)
+//
+// with a proof (e, z) that R and R' have the same discrete logarithm in base T
+// and G. Adapting it with t gives the signature {r, s ⋅ t⁻¹}, and t can be
+// extracted from the pre-signature and the adapted signature.
+//
+// https://github.com/LLFourn/one-time-VES/blob/master/main.pdf
+type PreSignature struct {
+	R, RPrime secp256k1.G1Affine
+	S         [sizeFr]byte
+	E, Z      [sizeFr]byte
+}
+
+// NewAdaptor returns a random adaptor secret t ∈ [1, n-1], as a big endian
+// integer of size sizeFr, and the uncompressed adaptor point T = t⋅G.
+func NewAdaptor(rand io.Reader) (secret, adaptor []byte, err error) {
+	t, err := randFieldElement(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	var T secp256k1.G1Affine
+	T.ScalarMultiplicationBase(t)
+	secret = make([]byte, sizeFr)
+	t.FillBytes(secret)
+	raw := T.RawBytes()
+	return secret, raw[:], nil
+}
+
+// PreSign performs the ECDSA pre-signature of the message, hashed with hFunc if
+// it is provided, for the uncompressed adaptor point T
+//
+// k, a ← 𝔽r (random)
+// R = k ⋅ T, R' = k ⋅ g1Gen
+// r = x_R (mod order)
+// s = k⁻¹ . (m + sk ⋅ r)
+// e = H(T, R, R', a ⋅ g1Gen, a ⋅ T)
+// pre-signature = {R, R', s, e, a + e ⋅ k}
+func (privKey *PrivateKey) PreSign(message, adaptor []byte, hFunc hash.Hash) ([]byte, error) {
+	var T secp256k1.G1Affine
+	if err := setPoint(&T, adaptor); err != nil {
+		return nil, err
+	}
+	h, err := hashMessage(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	m := HashToInt(h)
+	csprng, err := nonce(privKey, h)
+	if err != nil {
+		return nil, err
+	}
+
+	scalar := new(big.Int).SetBytes(privKey.scalar[:sizeFr])
+	var preSig PreSignature
+	r, s, kInv := new(big.Int), new(big.Int), new(big.Int)
+	var k *big.Int
+	for {
+		if k, err = randFieldElement(csprng); err != nil {
+			return nil, err
+		}
+		preSig.R.ScalarMultiplication(&T, k)
+		preSig.R.X.BigInt(r)
+		r.Mod(r, order)
+		if r.Sign() == 0 {
+			continue
+		}
+		kInv.ModInverse(k, order)
+		s.Mul(r, scalar)
+		s.Add(m, s).
+			Mul(kInv, s).
+			Mod(s, order)
+		if s.Sign() != 0 {
+			break
+		}
+	}
+	preSig.RPrime.ScalarMultiplicationBase(k)
+	s.FillBytes(preSig.S[:])
+
+	a, err := randFieldElement(csprng)
+	if err != nil {
+		return nil, err
+	}
+	var A1, A2 secp256k1.G1Affine
+	A1.ScalarMultiplicationBase(a)
+	A2.ScalarMultiplication(&T, a)
+	e := dleqChallenge(&T, &preSig.R, &preSig.RPrime, &A1, &A2)
+	z := new(big.Int).Mul(e, k)
+	z.Add(z, a).Mod(z, order)
+	e.FillBytes(preSig.E[:])
+	z.FillBytes(preSig.Z[:])
+
+	return preSig.Bytes(), nil
+}
+
+// PreVerify validates the ECDSA pre-signature of the message, hashed with hFunc
+// if it is provided, for the uncompressed adaptor point T
+//
+// e ?= H(T, R, R', z ⋅ g1Gen - e ⋅ R', z ⋅ T - e ⋅ R)
+// R' ?= s⁻¹ ⋅ m ⋅ Base + s⁻¹ ⋅ r ⋅ publiKey, where r = x_R (mod order)
+func (publicKey *PublicKey) PreVerify(preSigBin, message, adaptor []byte, hFunc hash.Hash) (bool, error) {
+	var preSig PreSignature
+	if _, err := preSig.SetBytes(preSigBin); err != nil {
+		return false, err
+	}
+	var T secp256k1.G1Affine
+	if err := setPoint(&T, adaptor); err != nil {
+		return false, err
+	}
+	h, err := hashMessage(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+
+	// discrete logarithm equality proof
+	e := new(big.Int).SetBytes(preSig.E[:])
+	z := new(big.Int).SetBytes(preSig.Z[:])
+	negE := new(big.Int).Neg(e)
+	var jac, tmp secp256k1.G1Jac
+	var A1, A2 secp256k1.G1Affine
+	jac.JointScalarMultiplicationBase(&preSig.RPrime, z, negE)
+	A1.FromJacobian(&jac)
+	jac.ScalarMultiplicationAffine(&T, z)
+	tmp.ScalarMultiplicationAffine(&preSig.R, negE)
+	jac.AddAssign(&tmp)
+	A2.FromJacobian(&jac)
+	if dleqChallenge(&T, &preSig.R, &preSig.RPrime, &A1, &A2).Cmp(e) != 0 {
+		return false, nil
+	}
+
+	r, s := new(big.Int), new(big.Int)
+	preSig.R.X.BigInt(r)
+	r.Mod(r, order)
+	if r.Sign() == 0 {
+		return false, nil
+	}
+	s.SetBytes(preSig.S[:])
+	sInv := new(big.Int).ModInverse(s, order)
+	u1 := new(big.Int).Mul(HashToInt(h), sInv)
+	u1.Mod(u1, order)
+	u2 := new(big.Int).Mul(r, sInv)
+	u2.Mod(u2, order)
+	jac.JointScalarMultiplicationBase(&publicKey.A, u1, u2)
+	tmp.FromAffine(&preSig.RPrime)
+
+	return jac.Equal(&tmp), nil
+}
+
+// Adapt returns the low-S signature {r, s ⋅ t⁻¹} adapted from the
+// pre-signature with the adaptor secret t, a big endian integer of size
+// sizeFr. The signature is valid if the pre-signature is, for the adaptor point
+// t⋅G.
+func Adapt(preSigBin, secret []byte) ([]byte, error) {
+	var preSig PreSignature
+	if _, err := preSig.SetBytes(preSigBin); err != nil {
+		return nil, err
+	}
+	if len(secret) != sizeFr {
+		return nil, errWrongSize
+	}
+	t := new(big.Int).SetBytes(secret)
+	if t.Sign() == 0 {
+		return nil, errZero
+	}
+	if t.Cmp(order) >= 0 {
+		return nil, errSBiggerThanRMod
+	}
+
+	r, s := new(big.Int), new(big.Int)
+	preSig.R.X.BigInt(r)
+	r.Mod(r, order)
+	s.SetBytes(preSig.S[:])
+	s.Mul(s, t.ModInverse(t, order)).
+		Mod(s, order)
+
+	var sig Signature
+	r.FillBytes(sig.R[:])
+	s.FillBytes(sig.S[:])
+	sig.NormalizeS()
+	return sig.Bytes(), nil
+}
+
+// Extract returns the adaptor secret t, as a big endian integer of size sizeFr,
+// from the pre-signature and the signature adapted from it with t. It returns
+// an error if t⋅G is not the uncompressed adaptor point T.
+//
+// t = ±s⁻¹ ⋅ s', the sign being given by t⋅G ?= T, since {r, -s} is the same
+// signature as {r, s}.
+func Extract(sigBin, preSigBin, adaptor []byte) ([]byte, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return nil, err
+	}
+	var preSig PreSignature
+	if _, err := preSig.SetBytes(preSigBin); err != nil {
+		return nil, err
+	}
+	var T secp256k1.G1Affine
+	if err := setPoint(&T, adaptor); err != nil {
+		return nil, err
+	}
+	r := new(big.Int)
+	preSig.R.X.BigInt(r)
+	r.Mod(r, order)
+	if r.Cmp(new(big.Int).SetBytes(sig.R[:])) != 0 {
+		return nil, errNotAdapted
+	}
+
+	t := new(big.Int).SetBytes(sig.S[:])
+	t.ModInverse(t, order).
+		Mul(t, new(big.Int).SetBytes(preSig.S[:])).
+		Mod(t, order)
+
+	var tG secp256k1.G1Affine
+	tG.ScalarMultiplicationBase(t)
+	if !tG.Equal(&T) {
+		tG.Neg(&tG)
+		if !tG.Equal(&T) {
+			return nil, errAdaptorMismatch
+		}
+		t.Sub(order, t)
+	}
+	secret := make([]byte, sizeFr)
+	t.FillBytes(secret)
+	return secret, nil
+}
+
+// dleqChallenge returns the challenge of the proof that R and R' have the same
+// discrete logarithm in base T and g1Gen, with the commitments A₁ and A₂
+func dleqChallenge(T, R, RPrime, A1, A2 *secp256k1.G1Affine) *big.Int {
+	h := sha256.New()
+	h.Write([]byte(dleqDomain))
+	for _, p := range []*secp256k1.G1Affine{T, R, RPrime, A1, A2} {
+		b := p.RawBytes()
+		h.Write(b[:])
+	}
+	e := new(big.Int).SetBytes(h.Sum(nil))
+	return e.Mod(e, order)
+}
+
+// setPoint sets p from its uncompressed binary representation, which must be
+// a point of the curve other than the point at infinity.
+func setPoint(p *secp256k1.G1Affine, buf []byte) error {
+	if len(buf) != sizePoint {
+		return errWrongSize
+	}
+	if _, err := p.SetBytes(buf); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return errInfinity
+	}
+	return nil
+}
+
+// Bytes returns the binary representation of the pre-signature, as
+// R||R'||s||e||z where R and R' are uncompressed points and s, e, z big endian
+// integers of size sizeFr
+func (preSig *PreSignature) Bytes() []byte {
+	var res [sizePreSignature]byte
+	r := preSig.R.RawBytes()
+	rPrime := preSig.RPrime.RawBytes()
+	copy(res[:sizePoint], r[:])
+	copy(res[sizePoint:2*sizePoint], rPrime[:])
+	copy(res[2*sizePoint:], preSig.S[:])
+	copy(res[2*sizePoint+sizeFr:], preSig.E[:])
+	copy(res[2*sizePoint+2*sizeFr:], preSig.Z[:])
+	return res[:]
+}
+
+// SetBytes sets the pre-signature from buf, interpreted as R||R'||s||e||z.
+// It returns the number of bytes read from buf.
+func (preSig *PreSignature) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePreSignature {
+		return 0, io.ErrShortBuffer
+	}
+	if err := setPoint(&preSig.R, buf[:sizePoint]); err != nil {
+		return 0, err
+	}
+	if err := setPoint(&preSig.RPrime, buf[sizePoint:2*sizePoint]); err != nil {
+		return 0, err
+	}
+	var v big.Int
+	for i, dst := range []*[sizeFr]byte{&preSig.S, &preSig.E, &preSig.Z} {
+		offset := 2*sizePoint + i*sizeFr
+		v.SetBytes(buf[offset : offset+sizeFr])
+		if i == 0 && v.Sign() == 0 {
+			return 0, errZero
+		}
+		if v.Cmp(order) >= 0 {
+			return 0, errSBiggerThanRMod
+		}
+		copy(dst[:], buf[offset:offset+sizeFr])
+	}
+	return sizePreSignature, nil
+}
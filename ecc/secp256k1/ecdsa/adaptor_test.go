@@ -0,0 +1,162 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+func TestAdaptor(t *testing.T) {
+
+	hFunc := sha256.New()
+	msg := []byte("testing ECDSA adaptor signatures")
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signer signature.AdaptorSigner = privKey
+	var publicKey signature.AdaptorPublicKey = &privKey.PublicKey
+
+	for i := 0; i < 8; i++ {
+		secret, adaptor, err := NewAdaptor(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		preSig, err := signer.PreSign(msg, adaptor, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the pre-signature goes through its binary representation
+		var p PreSignature
+		if _, err = p.SetBytes(preSig); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(p.Bytes(), preSig) {
+			t.Fatal("Error serialize(deserialize(.))")
+		}
+
+		if valid, err := publicKey.PreVerify(preSig, msg, adaptor, hFunc); err != nil || !valid {
+			t.Fatal("valid pre-signature should pass verification")
+		}
+
+		sig, err := Adapt(preSig, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if valid, err := publicKey.Verify(sig, msg, hFunc); err != nil || !valid {
+			t.Fatal("adapted signature should be valid")
+		}
+		extracted, err := Extract(sig, preSig, adaptor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(extracted, secret) {
+			t.Fatal("extracted secret should be the adaptor secret")
+		}
+	}
+}
+
+func TestAdaptorFailures(t *testing.T) {
+
+	hFunc := sha256.New()
+	msg := []byte("testing ECDSA adaptor signatures")
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey := privKey.PublicKey
+	secret, adaptor, err := NewAdaptor(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSecret, otherAdaptor, err := NewAdaptor(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	preSig, err := privKey.PreSign(msg, adaptor, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if valid, _ := publicKey.PreVerify(preSig, []byte("wrong message"), adaptor, hFunc); valid {
+		t.Fatal("pre-signature of another message should be invalid")
+	}
+	if valid, _ := publicKey.PreVerify(preSig, msg, otherAdaptor, hFunc); valid {
+		t.Fatal("pre-signature for another adaptor point should be invalid")
+	}
+
+	// the proof binds R to R'
+	var p PreSignature
+	if _, err = p.SetBytes(preSig); err != nil {
+		t.Fatal(err)
+	}
+	p.R.Neg(&p.R)
+	if valid, _ := publicKey.PreVerify(p.Bytes(), msg, adaptor, hFunc); valid {
+		t.Fatal("pre-signature with a wrong R should be invalid")
+	}
+
+	// adapting with a wrong secret gives an invalid signature
+	sig, err := Adapt(preSig, otherSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if valid, _ := publicKey.Verify(sig, msg, hFunc); valid {
+		t.Fatal("signature adapted with a wrong secret should be invalid")
+	}
+	if _, err = Extract(sig, preSig, adaptor); err != errAdaptorMismatch {
+		t.Fatal("expected an adaptor mismatch error")
+	}
+
+	// unrelated signature
+	if sig, err = privKey.Sign(msg, hFunc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Extract(sig, preSig, adaptor); err != errNotAdapted {
+		t.Fatal("expected an error for a signature not adapted from the pre-signature")
+	}
+
+	if _, err = privKey.PreSign(msg, make([]byte, sizePoint), hFunc); err != errInfinity {
+		t.Fatal("expected an error for the point at infinity as adaptor")
+	}
+	if _, err = Adapt(preSig, secret[1:]); err != errWrongSize {
+		t.Fatal("expected an error for a short secret")
+	}
+	if _, err = p.SetBytes(preSig[1:]); err == nil {
+		t.Fatal("expected an error for a short pre-signature")
+	}
+}
+
+func BenchmarkPreVerify(b *testing.B) {
+	hFunc := sha256.New()
+	msg := []byte("benchmarking ECDSA adaptor signatures")
+	privKey, _ := GenerateKey(rand.Reader)
+	_, adaptor, _ := NewAdaptor(rand.Reader)
+	preSig, _ := privKey.PreSign(msg, adaptor, hFunc)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.PublicKey.PreVerify(preSig, msg, adaptor, hFunc)
+	}
+}
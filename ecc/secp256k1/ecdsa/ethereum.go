@@ -0,0 +1,205 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// SizeEthereumSignature is the size of the signatures r ∥ s ∥ v used by
+	// Ethereum, where v ∈ {0, 1} is the recovery id.
+	SizeEthereumSignature = sizeSignature + 1
+	// SizeUncompressedPublicKey is the size of the SEC 1 uncompressed public
+	// keys 0x04 ∥ x ∥ y.
+	SizeUncompressedPublicKey = 1 + sizePublicKey
+)
+
+var (
+	errInvalidRecoveryID = errors.New("invalid recovery id")
+	errInvalidV          = errors.New("invalid v for the chain id")
+	errRecoveryFailed    = errors.New("public key recovery failed")
+	errNbInputs          = errors.New("number of hashes and signatures differ")
+	errInvalidPrefix     = errors.New("uncompressed public key must start with 0x04")
+)
+
+var (
+	big27 = big.NewInt(27)
+	big35 = big.NewInt(35)
+)
+
+// SignEthereum returns the signature r ∥ s ∥ v of the 32-byte hash, as
+// produced by libsecp256k1 for Ethereum: the nonce is derived with RFC 6979,
+// s is low-S and v ∈ {0, 1} is the recovery id.
+func (privKey *PrivateKey) SignEthereum(hash [32]byte) ([SizeEthereumSignature]byte, error) {
+	var res [SizeEthereumSignature]byte
+	v, r, s, err := privKey.signDeterministic(hash[:], nil)
+	if err != nil {
+		return res, err
+	}
+	if v > 1 {
+		// x_P ≥ order, which happens with probability < 2⁻¹²⁷
+		return res, errInvalidRecoveryID
+	}
+
+	var sig Signature
+	r.FillBytes(sig.R[:])
+	s.FillBytes(sig.S[:])
+	if sig.NormalizeS() {
+		v ^= 1
+	}
+	copy(res[:sizeSignature], sig.Bytes())
+	res[sizeSignature] = byte(v)
+	return res, nil
+}
+
+// Ecrecover returns the uncompressed public key 0x04 ∥ x ∥ y that produced the
+// signature sig = r ∥ s ∥ v of the 32-byte hash, as go-ethereum's
+// crypto.Ecrecover. v is the recovery id, in {0, 1} for Ethereum signatures
+// (see RecoveryID for the other conventions). High-S signatures are accepted,
+// as by the ecrecover precompile.
+func Ecrecover(hash [32]byte, sig [SizeEthereumSignature]byte) ([SizeUncompressedPublicKey]byte, error) {
+	var res [SizeUncompressedPublicKey]byte
+	var pk PublicKey
+	if err := pk.ecrecover(hash[:], sig[:]); err != nil {
+		return res, err
+	}
+	res[0] = 0x04
+	raw := pk.A.RawBytes()
+	copy(res[1:], raw[:])
+	return res, nil
+}
+
+// BatchEcrecover recovers the public keys of the signatures sigs[i] of
+// hashes[i] in parallel, as Ecrecover. It returns the keys and the sorted
+// indices of the signatures from which no key could be recovered, whose keys
+// are left zero.
+func BatchEcrecover(hashes [][32]byte, sigs [][SizeEthereumSignature]byte) ([][SizeUncompressedPublicKey]byte, []int, error) {
+	if len(hashes) != len(sigs) {
+		return nil, nil, errNbInputs
+	}
+	res := make([][SizeUncompressedPublicKey]byte, len(sigs))
+	ok := make([]bool, len(sigs))
+	parallel.Execute(len(sigs), func(start, end int) {
+		for i := start; i < end; i++ {
+			var err error
+			res[i], err = Ecrecover(hashes[i], sigs[i])
+			ok[i] = err == nil
+		}
+	})
+
+	var failed []int
+	for i := range ok {
+		if !ok[i] {
+			failed = append(failed, i)
+		}
+	}
+	return res, failed, nil
+}
+
+// ecrecover sets pk to the public key recovered from the hash and the
+// signature r ∥ s ∥ v
+func (pk *PublicKey) ecrecover(hash, sig []byte) error {
+	v := sig[sizeSignature]
+	if v > 3 {
+		return errInvalidRecoveryID
+	}
+	var s Signature
+	if _, err := s.SetBytes(sig[:sizeSignature]); err != nil {
+		return err
+	}
+	r := new(big.Int).SetBytes(s.R[:])
+	if err := pk.RecoverFrom(hash, uint(v), r, new(big.Int).SetBytes(s.S[:])); err != nil {
+		return err
+	}
+	if pk.A.IsInfinity() {
+		return errRecoveryFailed
+	}
+	return nil
+}
+
+// RecoveryID returns the recovery id encoded in the v value of an Ethereum
+// signature. If chainID is nil, v is in {0, 1} or in {27, 28} (legacy
+// transactions and signed messages). Otherwise v = 35 + 2⋅chainID + id, as
+// specified by EIP-155.
+func RecoveryID(v, chainID *big.Int) (byte, error) {
+	id := new(big.Int)
+	if chainID == nil {
+		id.Set(v)
+		if id.Cmp(big27) >= 0 {
+			id.Sub(id, big27)
+		}
+	} else {
+		id.Lsh(chainID, 1).
+			Add(id, big35).
+			Sub(v, id)
+	}
+	if id.Sign() < 0 || id.Cmp(one) > 0 {
+		if chainID != nil {
+			return 0, errInvalidV
+		}
+		return 0, errInvalidRecoveryID
+	}
+	return byte(id.Uint64()), nil
+}
+
+// EthereumV returns the v value encoding the recovery id in an Ethereum
+// signature: 27 + id if chainID is nil, 35 + 2⋅chainID + id (EIP-155)
+// otherwise.
+func EthereumV(recoveryID byte, chainID *big.Int) *big.Int {
+	v := big.NewInt(int64(recoveryID))
+	if chainID == nil {
+		return v.Add(v, big27)
+	}
+	v.Add(v, big35)
+	return v.Add(v, new(big.Int).Lsh(chainID, 1))
+}
+
+// EthereumAddress returns the Ethereum address of the public key, the last 20
+// bytes of the Keccak-256 hash of x ∥ y.
+func (pk *PublicKey) EthereumAddress() [20]byte {
+	var res [20]byte
+	raw := pk.A.RawBytes()
+	h := sha3.NewLegacyKeccak256()
+	h.Write(raw[:])
+	copy(res[:], h.Sum(nil)[12:])
+	return res
+}
+
+// SetBytesUncompressed sets pk from its SEC 1 uncompressed encoding
+// 0x04 ∥ x ∥ y, as returned by Ecrecover. It returns the number of bytes read
+// from buf.
+func (pk *PublicKey) SetBytesUncompressed(buf []byte) (int, error) {
+	if len(buf) < SizeUncompressedPublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if buf[0] != 0x04 {
+		return 0, errInvalidPrefix
+	}
+	var A secp256k1.G1Affine
+	if _, err := A.SetBytes(buf[1:SizeUncompressedPublicKey]); err != nil {
+		return 0, err
+	}
+	pk.A = A
+	return SizeUncompressedPublicKey, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+)
+
+func TestEcrecover(t *testing.T) {
+
+	// libsecp256k1 signatures of sha256("Satoshi Nakamoto")
+	for _, v := range []struct {
+		key, sig, pub string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d82442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e501",
+			"0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		},
+		{
+			"45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8",
+			"aa13bf2aa0c713f8ca2f48ef773605cabb9efdc12f96b6927ad7b6664131c3c426c60ca50f5080dd7eb4d04a7a1675416dc6ba7d1b30f67aeaf5db28e30c3cce00",
+			"043a514176466fa815ed481ffad09110a2d344f6c9b78c1d14afc351c3a51be33d8072e77939dc03ba44790779b7a1025baf3003f6732430e20cd9b76d953391b3",
+		},
+		{
+			"c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+			"032a850249b329e2a03c4d2c7eb6d5053054902f6b2aad3555097c5ea058ff94452fae0efcd58effefac198404d55dfb8eda447cd01041eca4fe7f6e2f97b48a00",
+			"042c8c31fc9f990c6b55e3865a184a4ce50e09481f2eaeb3e60ec1cea13a6ae64564b95e4fdb6948c0386e189b006a29f686769b011704275e4459822dc3328085",
+		},
+	} {
+		var hash [32]byte
+		hex.Decode(hash[:], []byte("a0dc65ffca799873cbea0ac274015b9526505daaaed385155425f7337704883e"))
+		var privKey PrivateKey
+		hex.Decode(privKey.scalar[:], []byte(v.key))
+
+		sig, err := privKey.SignEthereum(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(sig[:]) != v.sig {
+			t.Fatal("wrong signature with key", v.key)
+		}
+		pub, err := Ecrecover(hash, sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(pub[:]) != v.pub {
+			t.Fatal("wrong public key recovered for key", v.key)
+		}
+	}
+
+	// first test vector of the ecrecover precompile of go-ethereum
+	var hash [32]byte
+	var sig [SizeEthereumSignature]byte
+	hex.Decode(hash[:], []byte("18c547e4f7b0f325ad1e56f57e26c745b09a3e503d86e00e5255ff7f715d3d1c"))
+	hex.Decode(sig[:sizeSignature], []byte("73b1693892219d736caba55bdb67216e485557ea6b6af75f37096c9aa6a5a75feeb940b1d03b21e36b0e47e79769f095fe2ab855bd91e3a38756b7d75a9c4549"))
+	id, err := RecoveryID(big.NewInt(28), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[sizeSignature] = id
+	pub, err := Ecrecover(hash, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pk PublicKey
+	if _, err = pk.SetBytesUncompressed(pub[:]); err != nil {
+		t.Fatal(err)
+	}
+	if address := pk.EthereumAddress(); hex.EncodeToString(address[:]) != "a94f5374fce5edbc8e2a8697c15331677e6ebf0b" {
+		t.Fatal("wrong address recovered")
+	}
+
+	// the opposite high-S signature recovers the same key
+	s := new(big.Int).SetBytes(sig[sizeFr:sizeSignature])
+	s.Sub(fr.Modulus(), s).FillBytes(sig[sizeFr:sizeSignature])
+	sig[sizeSignature] ^= 1
+	if highS, err := Ecrecover(hash, sig); err != nil || highS != pub {
+		t.Fatal("high-S signature should recover the same key")
+	}
+
+	sig[sizeSignature] = 4
+	if _, err = Ecrecover(hash, sig); err != errInvalidRecoveryID {
+		t.Fatal("expected an invalid recovery id error")
+	}
+}
+
+func TestBatchEcrecover(t *testing.T) {
+	const n = 16
+	hashes := make([][32]byte, n)
+	sigs := make([][SizeEthereumSignature]byte, n)
+	pubs := make([]PublicKey, n)
+	for i := range sigs {
+		privKey, err := GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pubs[i] = privKey.PublicKey
+		rand.Read(hashes[i][:])
+		if sigs[i], err = privKey.SignEthereum(hashes[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sigs[5][sizeSignature] = 27
+	hashes[9][0] ^= 1
+
+	keys, failed, err := BatchEcrecover(hashes, sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(failed) != 1 || failed[0] != 5 {
+		t.Fatal("expected the recovery of signature 5 to fail, got", failed)
+	}
+	for i := range keys {
+		if i == 5 {
+			continue
+		}
+		var pk PublicKey
+		if _, err = pk.SetBytesUncompressed(keys[i][:]); err != nil {
+			t.Fatal(err)
+		}
+		if pk.Equal(&pubs[i]) != (i != 9) {
+			t.Fatal("wrong key recovered from signature", i)
+		}
+	}
+
+	if _, _, err = BatchEcrecover(hashes[1:], sigs); err != errNbInputs {
+		t.Fatal("expected an error for inputs of different lengths")
+	}
+}
+
+func TestRecoveryID(t *testing.T) {
+	chainID := big.NewInt(1)
+	for _, v := range []struct {
+		v       int64
+		chainID *big.Int
+		id      byte
+		err     error
+	}{
+		{0, nil, 0, nil},
+		{1, nil, 1, nil},
+		{27, nil, 0, nil},
+		{28, nil, 1, nil},
+		{37, chainID, 0, nil},
+		{38, chainID, 1, nil},
+		{2, nil, 0, errInvalidRecoveryID},
+		{29, nil, 0, errInvalidRecoveryID},
+		{27, chainID, 0, errInvalidV},
+		{39, chainID, 0, errInvalidV},
+	} {
+		id, err := RecoveryID(big.NewInt(v.v), v.chainID)
+		if err != v.err || id != v.id {
+			t.Fatal("wrong recovery id for v =", v.v)
+		}
+		if err == nil && v.v >= 27 && EthereumV(id, v.chainID).Int64() != v.v {
+			t.Fatal("EthereumV is not the inverse of RecoveryID for v =", v.v)
+		}
+	}
+}
+
+func BenchmarkEcrecover(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	var hash [32]byte
+	rand.Read(hash[:])
+	sig, _ := privKey.SignEthereum(hash)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Ecrecover(hash, sig)
+	}
+}
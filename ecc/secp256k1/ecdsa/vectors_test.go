@@ -0,0 +1,164 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// wycheproofTestVectors is the subset of the Wycheproof ECDSA verification test
+// vectors used by the tests. The files in test_vectors are the secp256k1 SHA-256
+// vectors of https://github.com/C2SP/wycheproof (testvectors_v1), without the
+// DER, PEM and JWK encodings of the public keys.
+type wycheproofTestVectors struct {
+	TestGroups []struct {
+		PublicKey struct {
+			Uncompressed string `json:"uncompressed"`
+		} `json:"publicKey"`
+		Tests []struct {
+			TcID    int      `json:"tcId"`
+			Comment string   `json:"comment"`
+			Flags   []string `json:"flags"`
+			Msg     string   `json:"msg"`
+			Sig     string   `json:"sig"`
+			Result  string   `json:"result"`
+		} `json:"tests"`
+	} `json:"testGroups"`
+}
+
+func TestWycheproof(t *testing.T) {
+	for _, tc := range []struct {
+		file string
+		der  bool // strict DER encoding or compact r||s
+		lowS bool // only low-S signatures are valid
+	}{
+		{"ecdsa_secp256k1_sha256_test.json", true, false},
+		{"ecdsa_secp256k1_sha256_p1363_test.json", false, false},
+		{"ecdsa_secp256k1_sha256_bitcoin_test.json", true, true},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			data, err := os.ReadFile("./test_vectors/" + tc.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var vectors wycheproofTestVectors
+			if err = json.Unmarshal(data, &vectors); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, g := range vectors.TestGroups {
+				bPub, _ := hex.DecodeString(g.PublicKey.Uncompressed)
+				var pub PublicKey
+				if _, err = pub.SetBytes(bPub[1:]); err != nil {
+					t.Fatal(err)
+				}
+				for _, v := range g.Tests {
+					msg, _ := hex.DecodeString(v.Msg)
+					bSig, _ := hex.DecodeString(v.Sig)
+
+					var sig Signature
+					if tc.der {
+						_, err = sig.SetBytesDER(bSig)
+					} else {
+						_, err = sig.SetBytes(bSig)
+					}
+					valid := err == nil && (!tc.lowS || sig.IsLowS())
+					if valid {
+						valid, err = pub.Verify(sig.Bytes(), msg, sha256.New())
+						if err != nil {
+							t.Fatal(err)
+						}
+					}
+
+					if valid != (v.Result == "valid") {
+						t.Errorf("test %d (%s): expected %s, got valid=%t", v.TcID, v.Comment, v.Result, valid)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRFC6979Vectors(t *testing.T) {
+	// low-S signatures of libsecp256k1 and btcec, which derive the nonces with
+	// RFC 6979 and HMAC-SHA256
+	for _, v := range []struct {
+		key, msg, r, s string
+	}{
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"Satoshi Nakamoto",
+			"934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+			"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000001",
+			"All those moments will be lost in time, like tears in rain. Time to die...",
+			"8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b",
+			"547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21",
+		},
+		{
+			"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+			"Satoshi Nakamoto",
+			"fd567d121db66e382991534ada77a6bd3106f0a1098c231e47993447cd6af2d0",
+			"6b39cd0eb1bc8603e159ef5c20a5c8ad685a45b06ce9bebed3f153d10d93bed5",
+		},
+		{
+			"f8b8af8ce3c7cca5e300d33939540c10d45ce001b8f252bfbc57ba0342904181",
+			"Alan Turing",
+			"7063ae83e7f62bbb171798131b4a0564b956930092b33b07b395615d9ec7e15c",
+			"58dfcc1e00a35e1572f366ffe34ba0fc47db1e7189759b9fb233c5b05ab388ea",
+		},
+		{
+			"c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721",
+			"sample",
+			"432310e32cb80eb6503a26ce83cc165c783b870845fb8aad6d970889fcd7a6c8",
+			"530128b6b81c548874a6305d93ed071ca6e05074d85863d4056ce89b02bfab69",
+		},
+	} {
+		var privKey PrivateKey
+		if _, err := hex.Decode(privKey.scalar[:], []byte(v.key)); err != nil {
+			t.Fatal(err)
+		}
+
+		// the message is hashed by SignDeterministic, or beforehand
+		h := sha256.Sum256([]byte(v.msg))
+		sigs := make([][]byte, 2)
+		var err error
+		if sigs[0], err = privKey.SignDeterministic([]byte(v.msg), sha256.New); err != nil {
+			t.Fatal(err)
+		}
+		if sigs[1], err = privKey.SignDeterministic(h[:], nil); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, bSig := range sigs {
+			var sig Signature
+			if _, err = sig.SetBytes(bSig); err != nil {
+				t.Fatal(err)
+			}
+			sig.NormalizeS()
+			if hex.EncodeToString(sig.R[:]) != v.r || hex.EncodeToString(sig.S[:]) != v.s {
+				t.Fatalf("wrong signature of %q with key %s", v.msg, v.key)
+			}
+		}
+	}
+}
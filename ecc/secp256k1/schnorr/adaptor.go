@@ -0,0 +1,265 @@
+// Copyright 2023 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+)
+
+var (
+	errAdaptorMismatch = errors.New("secret doesn't match the adaptor point")
+	errNotAdapted      = errors.New("signature is not adapted from the pre-signature")
+)
+
+// tagAdaptorNonce is the tag of the hash deriving the nonce of pre-signatures
+const tagAdaptorNonce = "BIP0340/adaptor/nonce"
+
+const sizePreSignature = sizePoint + sizeFr
+
+// PreSignature is a BIP-340 adaptor signature for an adaptor point T = t⋅G.
+// R = k⋅G + T is the nonce of the signature it adapts to, and
+// S = ±k + e⋅d mod n, the sign making the adapted signature use the nonce
+// point of R with an even y. Adapting it with t gives a valid signature, and t
+// can be extracted from the pre-signature and the adapted signature.
+type PreSignature struct {
+	R secp256k1.G1Affine
+	S [sizeFr]byte
+}
+
+// NewAdaptor returns a random adaptor secret t ∈ [1, n-1], as a 32-byte big
+// endian integer, and the uncompressed adaptor point T = t⋅G.
+func NewAdaptor(rand io.Reader) (secret, adaptor []byte, err error) {
+	t, err := randFieldElement(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	var T secp256k1.G1Affine
+	T.ScalarMultiplicationBase(t)
+	secret = make([]byte, sizeFr)
+	t.FillBytes(secret)
+	raw := T.RawBytes()
+	return secret, raw[:], nil
+}
+
+// PreSign returns the pre-signature of the message, hashed with hFunc if it
+// is provided, for the uncompressed adaptor point T.
+//
+// t' = d ⊕ hash_BIP0340/aux(rand)
+// k = int(hash_BIP0340/adaptor/nonce(t' ∥ x_P ∥ T ∥ m)) mod n
+// R = k⋅G + T
+// e = int(hash_BIP0340/challenge(x_R ∥ x_P ∥ m)) mod n
+// pre-signature = R ∥ (±k + e⋅d mod n), with -k if y_R is odd
+func (privKey *PrivateKey) PreSign(message, adaptor []byte, hFunc hash.Hash) ([]byte, error) {
+	var T secp256k1.G1Affine
+	if err := setPoint(&T, adaptor); err != nil {
+		return nil, err
+	}
+	m, err := digest(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	var auxRand [sizeAuxRand]byte
+	if _, err := io.ReadFull(rand.Reader, auxRand[:]); err != nil {
+		return nil, err
+	}
+
+	pk := privKey.PublicKey.Bytes()
+	t := taggedHash(tagAux, auxRand[:])
+	for i := range t {
+		t[i] ^= privKey.scalar[i]
+	}
+	h := taggedHash(tagAdaptorNonce, t[:], pk, adaptor, m)
+	k := new(big.Int).SetBytes(h[:])
+	k.Mod(k, order)
+	if k.Sign() == 0 {
+		return nil, errZeroNonce
+	}
+
+	var preSig PreSignature
+	var R secp256k1.G1Jac
+	R.ScalarMultiplicationAffine(&generator, k).AddMixed(&T)
+	preSig.R.FromJacobian(&R)
+	if preSig.R.IsInfinity() {
+		return nil, errInfinity
+	}
+	if !hasEvenY(&preSig.R) {
+		k.Sub(order, k)
+	}
+
+	x := preSig.R.X.Bytes()
+	e := challenge(x[:], pk, m)
+	s := new(big.Int).SetBytes(privKey.scalar[:])
+	s.Mul(s, e).
+		Add(s, k).
+		Mod(s, order)
+	s.FillBytes(preSig.S[:])
+
+	return preSig.Bytes(), nil
+}
+
+// PreVerify validates the pre-signature of the message, hashed with hFunc if it
+// is provided, for the uncompressed adaptor point T
+//
+// e = int(hash_BIP0340/challenge(x_R ∥ x_P ∥ m)) mod n
+// s⋅G - e⋅P ?= R - T if y_R is even, T - R otherwise
+func (publicKey *PublicKey) PreVerify(preSigBin, message, adaptor []byte, hFunc hash.Hash) (bool, error) {
+	var preSig PreSignature
+	if _, err := preSig.SetBytes(preSigBin); err != nil {
+		return false, err
+	}
+	var T secp256k1.G1Affine
+	if err := setPoint(&T, adaptor); err != nil {
+		return false, err
+	}
+	m, err := digest(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+
+	x := preSig.R.X.Bytes()
+	e := challenge(x[:], publicKey.Bytes(), m)
+	e.Neg(e)
+	s := new(big.Int).SetBytes(preSig.S[:])
+
+	var lhs, rhs secp256k1.G1Jac
+	lhs.JointScalarMultiplicationBase(&publicKey.A, s, e)
+	if hasEvenY(&preSig.R) {
+		T.Neg(&T)
+		rhs.FromAffine(&preSig.R)
+	} else {
+		var R secp256k1.G1Affine
+		R.Neg(&preSig.R)
+		rhs.FromAffine(&R)
+	}
+	rhs.AddMixed(&T)
+
+	return lhs.Equal(&rhs), nil
+}
+
+// Adapt returns the BIP-340 signature x_R ∥ (s ± t mod n) adapted from the
+// pre-signature with the 32-byte big endian adaptor secret t. The signature is
+// valid if the pre-signature is, for the adaptor point t⋅G.
+func Adapt(preSigBin, secret []byte) ([]byte, error) {
+	var preSig PreSignature
+	if _, err := preSig.SetBytes(preSigBin); err != nil {
+		return nil, err
+	}
+	if len(secret) != sizeFr {
+		return nil, errInvalidSecret
+	}
+	if err := checkScalar(secret); err != nil {
+		return nil, err
+	}
+
+	s := new(big.Int).SetBytes(preSig.S[:])
+	t := new(big.Int).SetBytes(secret)
+	if hasEvenY(&preSig.R) {
+		s.Add(s, t)
+	} else {
+		s.Sub(s, t)
+	}
+	s.Mod(s, order)
+
+	var sig Signature
+	sig.R = preSig.R.X.Bytes()
+	s.FillBytes(sig.S[:])
+	return sig.Bytes(), nil
+}
+
+// Extract returns the adaptor secret t, as a 32-byte big endian integer, from
+// the pre-signature and the signature adapted from it with t. It returns an
+// error if t⋅G is not the uncompressed adaptor point T.
+func Extract(sigBin, preSigBin, adaptor []byte) ([]byte, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return nil, err
+	}
+	var preSig PreSignature
+	if _, err := preSig.SetBytes(preSigBin); err != nil {
+		return nil, err
+	}
+	var T secp256k1.G1Affine
+	if err := setPoint(&T, adaptor); err != nil {
+		return nil, err
+	}
+	x := preSig.R.X.Bytes()
+	if subtle.ConstantTimeCompare(x[:], sig.R[:]) != 1 {
+		return nil, errNotAdapted
+	}
+
+	t := new(big.Int).SetBytes(sig.S[:])
+	t.Sub(t, new(big.Int).SetBytes(preSig.S[:]))
+	if !hasEvenY(&preSig.R) {
+		t.Neg(t)
+	}
+	t.Mod(t, order)
+
+	var tG secp256k1.G1Affine
+	tG.ScalarMultiplicationBase(t)
+	if !tG.Equal(&T) {
+		return nil, errAdaptorMismatch
+	}
+	secret := make([]byte, sizeFr)
+	t.FillBytes(secret)
+	return secret, nil
+}
+
+// setPoint sets p from its uncompressed binary representation, which must be
+// a point of the curve other than the point at infinity.
+func setPoint(p *secp256k1.G1Affine, buf []byte) error {
+	if len(buf) != sizePoint {
+		return errWrongSize
+	}
+	if _, err := p.SetBytes(buf); err != nil {
+		return err
+	}
+	if p.IsInfinity() {
+		return errInfinity
+	}
+	return nil
+}
+
+// Bytes returns the binary representation of the pre-signature, as R||s where
+// R is an uncompressed point and s a big endian integer of size sizeFr
+func (preSig *PreSignature) Bytes() []byte {
+	var res [sizePreSignature]byte
+	r := preSig.R.RawBytes()
+	copy(res[:sizePoint], r[:])
+	copy(res[sizePoint:], preSig.S[:])
+	return res[:]
+}
+
+// SetBytes sets the pre-signature from buf, interpreted as R||s.
+// It returns the number of bytes read from buf.
+func (preSig *PreSignature) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePreSignature {
+		return 0, io.ErrShortBuffer
+	}
+	if err := setPoint(&preSig.R, buf[:sizePoint]); err != nil {
+		return 0, err
+	}
+	if err := checkScalar(buf[sizePoint:sizePreSignature]); err != nil {
+		return 0, err
+	}
+	copy(preSig.S[:], buf[sizePoint:sizePreSignature])
+	return sizePreSignature, nil
+}
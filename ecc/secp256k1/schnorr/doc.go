@@ -0,0 +1,38 @@
+// Copyright 2023 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schnorr provides BIP-340 Schnorr signatures on the secp256k1 curve.
+//
+// Public keys are x-only: they are encoded as the 32-byte x coordinate of the
+// point with an even y coordinate. Signatures are the 32-byte x coordinate of
+// the nonce point R followed by the 32-byte scalar s. Nonces and challenges
+// are derived with the tagged hashes "BIP0340/aux", "BIP0340/nonce" and
+// "BIP0340/challenge".
+//
+// It also provides the MuSig2 multi-signature (all the signers of a key sign
+// together) and the FROST threshold signature (any t out of n participants
+// sign), whose aggregated signatures are BIP-340 signatures. The MuSig2 key
+// aggregation and nonce derivation are not those of BIP-327, with which they
+// don't interoperate.
+//
+// Adaptor signatures (PrivateKey.PreSign) are pre-signatures for an adaptor
+// point T = t⋅G: Adapt turns them into BIP-340 signatures with the secret t,
+// and Extract recovers t from a pre-signature and the adapted signature, as
+// needed by atomic swaps and payment channels.
+//
+// Documentation:
+// - BIP-340: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+// - MuSig2: https://eprint.iacr.org/2020/1261.pdf
+// - FROST: https://eprint.iacr.org/2020/852.pdf
+package schnorr
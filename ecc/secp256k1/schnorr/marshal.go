@@ -0,0 +1,111 @@
+// Copyright 2023 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schnorr
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"math/big"
+)
+
+var errWrongSize = errors.New("wrong size buffer")
+var errRBiggerThanPMod = errors.New("r >= p_mod")
+var errSBiggerThanRMod = errors.New("s >= r_mod")
+var errPublicKeyMismatch = errors.New("secret scalar doesn't match the public key")
+
+// Bytes returns the x-only binary representation of the public key, the x
+// coordinate of the point as a 32-byte big endian integer.
+func (pk *PublicKey) Bytes() []byte {
+	res := pk.A.X.Bytes()
+	return res[:]
+}
+
+// SetBytes sets p from its x-only binary representation in buf, choosing the
+// point with an even y coordinate.
+// It returns the number of bytes read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePublicKey {
+		return 0, io.ErrShortBuffer
+	}
+	if err := liftX(&pk.A, buf[:sizePublicKey]); err != nil {
+		return 0, err
+	}
+	return sizePublicKey, nil
+}
+
+// Bytes returns the binary representation of pk,
+// as byte array publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+func (privKey *PrivateKey) Bytes() []byte {
+	var res [sizePrivateKey]byte
+	pubkBin := privKey.PublicKey.Bytes()
+	subtle.ConstantTimeCopy(1, res[:sizePublicKey], pubkBin)
+	subtle.ConstantTimeCopy(1, res[sizePublicKey:sizePrivateKey], privKey.scalar[:])
+	return res[:]
+}
+
+// SetBytes sets pk from buf, where buf is interpreted
+// as  publicKey||scalar
+// where publicKey is as publicKey.Bytes(), and
+// scalar is in big endian, of size sizeFr.
+// It returns the number byte read.
+func (privKey *PrivateKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePrivateKey {
+		return 0, io.ErrShortBuffer
+	}
+	res, err := NewPrivateKey(buf[sizePublicKey:sizePrivateKey])
+	if err != nil {
+		return 0, err
+	}
+	if subtle.ConstantTimeCompare(res.PublicKey.Bytes(), buf[:sizePublicKey]) != 1 {
+		return 0, errPublicKeyMismatch
+	}
+	*privKey = *res
+	return sizePrivateKey, nil
+}
+
+// Bytes returns the binary representation of sig
+// as a byte array of size sizeFp+sizeFr r||s
+func (sig *Signature) Bytes() []byte {
+	var res [sizeSignature]byte
+	subtle.ConstantTimeCopy(1, res[:sizeFp], sig.R[:])
+	subtle.ConstantTimeCopy(1, res[sizeFp:], sig.S[:])
+	return res[:]
+}
+
+// SetBytes sets sig from a buffer in binary.
+// buf is read interpreted as r||s, with r < p and s < n
+// It returns the number of bytes read from buf.
+func (sig *Signature) SetBytes(buf []byte) (int, error) {
+	if len(buf) != sizeSignature {
+		return 0, errWrongSize
+	}
+
+	var r, s big.Int
+	r.SetBytes(buf[:sizeFp])
+	if r.Cmp(fpModulus) != -1 {
+		return 0, errRBiggerThanPMod
+	}
+	s.SetBytes(buf[sizeFp:])
+	if s.Cmp(order) != -1 {
+		return 0, errSBiggerThanRMod
+	}
+
+	subtle.ConstantTimeCopy(1, sig.R[:], buf[:sizeFp])
+	subtle.ConstantTimeCopy(1, sig.S[:], buf[sizeFp:])
+	return sizeSignature, nil
+}
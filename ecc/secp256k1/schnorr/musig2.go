@@ -0,0 +1,382 @@
+// Copyright 2023 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schnorr
+
+import (
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/internal/multisig"
+)
+
+var errInfinity = errors.New("point at infinity")
+
+// Tags of the MuSig2 tagged hashes
+const (
+	tagKeyAggList  = "MuSig2/keyagg list"
+	tagKeyAggCoeff = "MuSig2/keyagg coefficient"
+	tagMuSig2Aux   = "MuSig2/aux"
+	tagMuSig2Nonce = "MuSig2/nonce"
+	tagNonceCoeff  = "MuSig2/noncecoef"
+)
+
+const sizePoint = secp256k1.SizeOfG1AffineUncompressed
+
+// MuSig2AggregateKey is the result of the key aggregation of MuSig2
+// (https://eprint.iacr.org/2020/1261.pdf) of x-only individual keys. The
+// aggregate key Q = ∑ aᵢ⋅Pᵢ, where aᵢ = H(L, Pᵢ) and L is the list of the public
+// keys, is negated if needed to get an even y: the aggregated signatures are
+// BIP-340 signatures under PublicKey.
+//
+// This is not BIP-327: the individual keys are x-only, all of them get a hashed
+// coefficient and the public nonces are hashed as uncompressed points, so the
+// signers can't take part in a BIP-327 session.
+type MuSig2AggregateKey struct {
+	PublicKey    PublicKey
+	keys         []PublicKey
+	coefficients []big.Int
+	negated      bool // Q = -∑ aᵢ⋅Pᵢ
+}
+
+// MuSig2SecretNonce is the secret part of the nonce of a MuSig2 signer. It is
+// bound to the key of the signer and zeroed by MuSig2Session.Sign, so that it
+// cannot be used for two signatures. Copies of a MuSig2SecretNonce share the
+// same secret, so that signing with one of them invalidates all of them.
+type MuSig2SecretNonce struct {
+	secret *musig2SecretNonce
+}
+
+// musig2SecretNonce holds the secret scalars of a MuSig2SecretNonce
+type musig2SecretNonce struct {
+	k1, k2 [sizeFr]byte
+	pub    [sizePublicKey]byte
+}
+
+// MuSig2PublicNonce is the first round message of a MuSig2 signer,
+// R₁ = k₁⋅G and R₂ = k₂⋅G. MuSig2AggregateNonces sums them.
+type MuSig2PublicNonce struct {
+	R1, R2 secp256k1.G1Affine
+}
+
+// MuSig2PartialSignature is the second round message of a MuSig2 signer,
+// sᵢ = k₁ + b⋅k₂ + e⋅aᵢ⋅dᵢ mod n, up to the signs making R and Q have even y
+type MuSig2PartialSignature struct {
+	S [sizeFr]byte
+}
+
+// MuSig2Session holds the values shared by the signers of a message once the
+// public nonces are aggregated.
+type MuSig2Session struct {
+	key      *MuSig2AggregateKey
+	b        big.Int // nonce coefficient H(R₁, R₂, Q, m)
+	e        big.Int // challenge H(R, Q, m)
+	R        secp256k1.G1Affine
+	negatedR bool // R = -(R₁ + b⋅R₂)
+}
+
+// MuSig2AggregateKeys returns the aggregate key of pubs, which must be given in
+// the same order to all signers.
+func MuSig2AggregateKeys(pubs []PublicKey) (*MuSig2AggregateKey, error) {
+	if len(pubs) == 0 {
+		return nil, multisig.ErrNoSigner
+	}
+
+	list := make([]byte, 0, len(pubs)*sizePublicKey)
+	for i := range pubs {
+		list = append(list, pubs[i].Bytes()...)
+	}
+	l := taggedHash(tagKeyAggList, list)
+
+	res := &MuSig2AggregateKey{
+		keys:         make([]PublicKey, len(pubs)),
+		coefficients: make([]big.Int, len(pubs)),
+	}
+	copy(res.keys, pubs)
+
+	var Q, tmp secp256k1.G1Jac
+	for i := range pubs {
+		h := taggedHash(tagKeyAggCoeff, l[:], pubs[i].Bytes())
+		res.coefficients[i].SetBytes(h[:]).Mod(&res.coefficients[i], order)
+		tmp.ScalarMultiplicationAffine(&pubs[i].A, &res.coefficients[i])
+		if i == 0 {
+			Q.Set(&tmp)
+		} else {
+			Q.AddAssign(&tmp)
+		}
+	}
+	res.PublicKey.A.FromJacobian(&Q)
+	if res.PublicKey.A.IsInfinity() {
+		return nil, errInfinity
+	}
+	if !hasEvenY(&res.PublicKey.A) {
+		res.PublicKey.A.Neg(&res.PublicKey.A)
+		res.negated = true
+	}
+
+	return res, nil
+}
+
+// coefficient returns the sum of the coefficients of pub in the aggregate key,
+// negated if the aggregate key is
+func (key *MuSig2AggregateKey) coefficient(pub *PublicKey) (*big.Int, error) {
+	var res big.Int
+	found := false
+	for i := range key.keys {
+		if key.keys[i].A.Equal(&pub.A) {
+			res.Add(&res, &key.coefficients[i])
+			found = true
+		}
+	}
+	if !found {
+		return nil, multisig.ErrUnknownSigner
+	}
+	if key.negated {
+		res.Neg(&res)
+	}
+	return res.Mod(&res, order), nil
+}
+
+// MuSig2Nonce generates the nonce of privKey for a MuSig2 signature. The secret
+// nonce derives from privKey and 32 bytes read from rand, and must be used
+// for a single signature.
+//
+// t = d ⊕ hash_MuSig/aux(rand)
+// kⱼ = int(hash_MuSig/nonce(t ∥ x_P ∥ j)) mod n
+func (privKey *PrivateKey) MuSig2Nonce(rand io.Reader) (*MuSig2SecretNonce, *MuSig2PublicNonce, error) {
+	var seed [sizeAuxRand]byte
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+
+	secret := new(musig2SecretNonce)
+	public := new(MuSig2PublicNonce)
+	copy(secret.pub[:], privKey.PublicKey.Bytes())
+
+	t := taggedHash(tagMuSig2Aux, seed[:])
+	for i := range t {
+		t[i] ^= privKey.scalar[i]
+	}
+	var k big.Int
+	for j, dst := range []*[sizeFr]byte{&secret.k1, &secret.k2} {
+		h := taggedHash(tagMuSig2Nonce, t[:], secret.pub[:], []byte{byte(j + 1)})
+		k.SetBytes(h[:]).Mod(&k, order)
+		if k.Sign() == 0 {
+			return nil, nil, errZeroNonce
+		}
+		k.FillBytes(dst[:])
+	}
+	k.SetBytes(secret.k1[:])
+	public.R1.ScalarMultiplicationBase(&k)
+	k.SetBytes(secret.k2[:])
+	public.R2.ScalarMultiplicationBase(&k)
+
+	return &MuSig2SecretNonce{secret}, public, nil
+}
+
+// MuSig2AggregateNonces returns the sum of the public nonces of the signers
+func MuSig2AggregateNonces(nonces []MuSig2PublicNonce) (*MuSig2PublicNonce, error) {
+	if len(nonces) == 0 {
+		return nil, multisig.ErrNoSigner
+	}
+	var R1, R2 secp256k1.G1Jac
+	R1.FromAffine(&nonces[0].R1)
+	R2.FromAffine(&nonces[0].R2)
+	for i := 1; i < len(nonces); i++ {
+		R1.AddMixed(&nonces[i].R1)
+		R2.AddMixed(&nonces[i].R2)
+	}
+	res := new(MuSig2PublicNonce)
+	res.R1.FromJacobian(&R1)
+	res.R2.FromJacobian(&R2)
+	return res, nil
+}
+
+// NewMuSig2Session returns the signing session of message, hashed with hFunc
+// if it is provided, under key, once the public nonces of all the signers are
+// aggregated in nonce.
+//
+// b = int(hash_MuSig/noncecoef(R₁ ∥ R₂ ∥ x_Q ∥ m)) mod n
+// R = R₁ + b⋅R₂, or G if R₁ + b⋅R₂ = ∞, negated if it has an odd y
+// e = int(hash_BIP0340/challenge(x_R ∥ x_Q ∥ m)) mod n
+func NewMuSig2Session(key *MuSig2AggregateKey, nonce *MuSig2PublicNonce, message []byte, hFunc hash.Hash) (*MuSig2Session, error) {
+	m, err := digest(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &MuSig2Session{key: key}
+	h := taggedHash(tagNonceCoeff, nonce.Bytes(), key.PublicKey.Bytes(), m)
+	s.b.SetBytes(h[:]).Mod(&s.b, order)
+
+	var R secp256k1.G1Jac
+	R.ScalarMultiplicationAffine(&nonce.R2, &s.b).
+		AddMixed(&nonce.R1)
+	s.R.FromJacobian(&R)
+	if s.R.IsInfinity() {
+		_, s.R = secp256k1.Generators()
+	}
+	if !hasEvenY(&s.R) {
+		s.R.Neg(&s.R)
+		s.negatedR = true
+	}
+
+	x := s.R.X.Bytes()
+	s.e.Set(challenge(x[:], key.PublicKey.Bytes(), m))
+
+	return s, nil
+}
+
+// Sign returns the partial signature of privKey with the secret nonce, which
+// is zeroed, even if an error is returned.
+//
+// sᵢ = ±(k₁ + b⋅k₂) + e⋅(±aᵢ)⋅dᵢ mod n
+func (s *MuSig2Session) Sign(privKey *PrivateKey, nonce *MuSig2SecretNonce) (*MuSig2PartialSignature, error) {
+	if nonce.secret == nil {
+		return nil, multisig.ErrNonceUsed
+	}
+	var k1, k2 big.Int
+	k1.SetBytes(nonce.secret.k1[:])
+	k2.SetBytes(nonce.secret.k2[:])
+	pub := nonce.secret.pub
+	*nonce.secret = musig2SecretNonce{}
+	if k1.Sign() == 0 || k2.Sign() == 0 {
+		return nil, multisig.ErrNonceUsed
+	}
+	if subtle.ConstantTimeCompare(pub[:], privKey.PublicKey.Bytes()) != 1 {
+		return nil, multisig.ErrNonceMismatch
+	}
+	a, err := s.key.coefficient(&privKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var x big.Int
+	x.SetBytes(privKey.scalar[:])
+	res := multisig.Response(&k1, &k2, &s.b, &s.e, a, &x, s.negatedR, order)
+
+	partial := new(MuSig2PartialSignature)
+	res.FillBytes(partial.S[:])
+	return partial, nil
+}
+
+// VerifyPartial checks the partial signature of the signer with public key pub
+// and public nonce nonce
+//
+// sᵢ⋅G ?= ±(R₁ᵢ + b⋅R₂ᵢ) + e⋅(±aᵢ)⋅Pᵢ
+func (s *MuSig2Session) VerifyPartial(partial *MuSig2PartialSignature, nonce *MuSig2PublicNonce, pub *PublicKey) bool {
+	a, err := s.key.coefficient(pub)
+	if err != nil {
+		return false
+	}
+
+	var Ri, rhs, tmp secp256k1.G1Jac
+	Ri.ScalarMultiplicationAffine(&nonce.R2, &s.b).
+		AddMixed(&nonce.R1)
+	if s.negatedR {
+		Ri.Neg(&Ri)
+	}
+	var ea big.Int
+	ea.Mul(&s.e, a)
+	rhs.ScalarMultiplicationAffine(&pub.A, &ea).
+		AddAssign(&Ri)
+
+	var si big.Int
+	si.SetBytes(partial.S[:])
+	tmp.FromAffine(&generator)
+	tmp.ScalarMultiplication(&tmp, &si)
+
+	return tmp.Equal(&rhs)
+}
+
+// Aggregate returns the BIP-340 signature x_R ∥ (∑ sᵢ mod n) made of the
+// partial signatures of all the signers.
+func (s *MuSig2Session) Aggregate(partials []MuSig2PartialSignature) ([]byte, error) {
+	if len(partials) != len(s.key.keys) {
+		return nil, multisig.ErrNbPartials
+	}
+
+	ss := make([][]byte, len(partials))
+	for i := range partials {
+		ss[i] = partials[i].S[:]
+	}
+	sum := multisig.Sum(ss, order)
+
+	var sig Signature
+	sig.R = s.R.X.Bytes()
+	sum.FillBytes(sig.S[:])
+	return sig.Bytes(), nil
+}
+
+// Bytes returns the binary representation of the public nonce, as R₁||R₂
+// where R₁ and R₂ are uncompressed points
+func (nonce *MuSig2PublicNonce) Bytes() []byte {
+	var res [2 * sizePoint]byte
+	r1 := nonce.R1.RawBytes()
+	r2 := nonce.R2.RawBytes()
+	copy(res[:sizePoint], r1[:])
+	copy(res[sizePoint:], r2[:])
+	return res[:]
+}
+
+// SetBytes sets the public nonce from buf, interpreted as R₁||R₂.
+// It returns the number of bytes read from buf.
+func (nonce *MuSig2PublicNonce) SetBytes(buf []byte) (int, error) {
+	if len(buf) < 2*sizePoint {
+		return 0, io.ErrShortBuffer
+	}
+	if _, err := nonce.R1.SetBytes(buf[:sizePoint]); err != nil {
+		return 0, err
+	}
+	if _, err := nonce.R2.SetBytes(buf[sizePoint : 2*sizePoint]); err != nil {
+		return 0, err
+	}
+	return 2 * sizePoint, nil
+}
+
+// Bytes returns the binary representation of the partial signature, as a big
+// endian integer of size sizeFr
+func (partial *MuSig2PartialSignature) Bytes() []byte {
+	var res [sizeFr]byte
+	copy(res[:], partial.S[:])
+	return res[:]
+}
+
+// SetBytes sets the partial signature from buf, which must hold a big endian
+// integer smaller than the order of the curve.
+// It returns the number of bytes read from buf.
+func (partial *MuSig2PartialSignature) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeFr {
+		return 0, io.ErrShortBuffer
+	}
+	if err := checkScalar(buf[:sizeFr]); err != nil {
+		return 0, err
+	}
+	copy(partial.S[:], buf[:sizeFr])
+	return sizeFr, nil
+}
+
+// checkScalar returns an error if the big endian integer in buf is not smaller
+// than the order of the curve
+func checkScalar(buf []byte) error {
+	var s big.Int
+	if s.SetBytes(buf).Cmp(order) != -1 {
+		return errSBiggerThanRMod
+	}
+	return nil
+}
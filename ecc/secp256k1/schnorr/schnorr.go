@@ -0,0 +1,381 @@
+// Copyright 2023 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizeFp         = fp.Bytes
+	sizePublicKey  = sizeFp
+	sizePrivateKey = sizePublicKey + sizeFr
+	sizeSignature  = sizeFp + sizeFr
+	sizeAuxRand    = 32
+)
+
+var (
+	order        = fr.Modulus()
+	fpModulus    = fp.Modulus()
+	_, generator = secp256k1.Generators()
+)
+
+var (
+	errNotOnCurve    = errors.New("x is not the abscissa of a point on the curve")
+	errInvalidSecret = errors.New("secret key must be in [1, n-1]")
+	errInvalidAux    = errors.New("auxiliary random data must be 32 bytes long")
+	errZeroNonce     = errors.New("nonce is zero")
+	errInvalidBatch  = errors.New("public keys, signatures and messages must have the same length")
+)
+
+// Tags of the BIP-340 tagged hashes
+const (
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+// PublicKey represents a BIP-340 public key. A is the point with an even y
+// coordinate whose x coordinate is the encoded key.
+type PublicKey struct {
+	A secp256k1.G1Affine
+}
+
+// PrivateKey represents a BIP-340 private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar d such that d⋅G has an even y, in big endian
+}
+
+// Signature represents a BIP-340 signature
+type Signature struct {
+	R [sizeFp]byte // x coordinate of the nonce point, in big endian
+	S [sizeFr]byte // in big endian
+}
+
+var one = new(big.Int).SetInt64(1)
+
+// randFieldElement returns a random element of the order of the given
+// curve using the procedure given in FIPS 186-4, Appendix B.5.1.
+func randFieldElement(rand io.Reader) (k *big.Int, err error) {
+	b := make([]byte, fr.Bits/8+8)
+	_, err = io.ReadFull(rand, b)
+	if err != nil {
+		return
+	}
+
+	k = new(big.Int).SetBytes(b)
+	n := new(big.Int).Sub(order, one)
+	k.Mod(k, n)
+	k.Add(k, one)
+	return
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	k, err := randFieldElement(rand)
+	if err != nil {
+		return nil, err
+	}
+	return newPrivateKey(k), nil
+}
+
+// NewPrivateKey returns the key pair of the 32-byte big endian secret key, as
+// specified by BIP-340. The secret must be in [1, n-1].
+func NewPrivateKey(secret []byte) (*PrivateKey, error) {
+	if len(secret) != sizeFr {
+		return nil, errInvalidSecret
+	}
+	d := new(big.Int).SetBytes(secret)
+	if d.Sign() == 0 || d.Cmp(order) >= 0 {
+		return nil, errInvalidSecret
+	}
+	return newPrivateKey(d), nil
+}
+
+// newPrivateKey returns the key pair of d ∈ [1, n-1], negating d if d⋅G has an
+// odd y coordinate.
+func newPrivateKey(d *big.Int) *PrivateKey {
+	privateKey := new(PrivateKey)
+	privateKey.PublicKey.A.ScalarMultiplicationBase(d)
+	if !hasEvenY(&privateKey.PublicKey.A) {
+		d = new(big.Int).Sub(order, d)
+		privateKey.PublicKey.A.Neg(&privateKey.PublicKey.A)
+	}
+	d.FillBytes(privateKey.scalar[:])
+	return privateKey
+}
+
+// hasEvenY returns true if the y coordinate of p, as an integer, is even
+func hasEvenY(p *secp256k1.G1Affine) bool {
+	y := p.Y.Bytes()
+	return y[len(y)-1]&1 == 0
+}
+
+// liftX sets p to the point with x coordinate x and an even y coordinate.
+// x is a 32-byte big endian integer that must be smaller than the modulus.
+func liftX(p *secp256k1.G1Affine, x []byte) error {
+	var px, y fp.Element
+	if err := px.SetBytesCanonical(x); err != nil {
+		return err
+	}
+	// y² = x³ + b
+	_, b := secp256k1.CurveCoefficients()
+	y.Square(&px).Mul(&y, &px).Add(&y, &b)
+	if y.Sqrt(&y) == nil {
+		return errNotOnCurve
+	}
+	p.X = px
+	p.Y = y
+	if !hasEvenY(p) {
+		p.Y.Neg(&p.Y)
+	}
+	return nil
+}
+
+// taggedHash returns SHA256(SHA256(tag) ∥ SHA256(tag) ∥ data[0] ∥ data[1] ∥ ...)
+func taggedHash(tag string, data ...[]byte) [sha256.Size]byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for i := range data {
+		h.Write(data[i])
+	}
+	var res [sha256.Size]byte
+	h.Sum(res[:0])
+	return res
+}
+
+// challenge returns e = int(hash_BIP0340/challenge(r ∥ pk ∥ message)) mod n
+func challenge(r, pk, message []byte) *big.Int {
+	h := taggedHash(tagChallenge, r, pk, message)
+	e := new(big.Int).SetBytes(h[:])
+	return e.Mod(e, order)
+}
+
+// digest returns the message hashed with hFunc, or the message itself if hFunc
+// is nil
+func digest(message []byte, hFunc hash.Hash) ([]byte, error) {
+	if hFunc == nil {
+		return message, nil
+	}
+	hFunc.Reset()
+	if _, err := hFunc.Write(message); err != nil {
+		return nil, err
+	}
+	return hFunc.Sum(nil), nil
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	bpk := pub.Bytes()
+	bxx := xx.Bytes()
+	return subtle.ConstantTimeCompare(bpk, bxx) == 1
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Sign performs the BIP-340 signature of the message, hashed with hFunc if it
+// is provided, with 32 bytes of fresh randomness as auxiliary data.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	m, err := digest(message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+	var auxRand [sizeAuxRand]byte
+	if _, err := io.ReadFull(rand.Reader, auxRand[:]); err != nil {
+		return nil, err
+	}
+	return privKey.SignWithAuxRand(m, auxRand[:])
+}
+
+// SignWithAuxRand performs the BIP-340 signature of the message with the
+// 32-byte auxiliary random data auxRand
+//
+// t = d ⊕ hash_BIP0340/aux(auxRand)
+// k = int(hash_BIP0340/nonce(t ∥ x_P ∥ m)) mod n, negated if k⋅G has an odd y
+// R = k⋅G
+// e = int(hash_BIP0340/challenge(x_R ∥ x_P ∥ m)) mod n
+// signature = x_R ∥ (k + e⋅d mod n)
+func (privKey *PrivateKey) SignWithAuxRand(message, auxRand []byte) ([]byte, error) {
+	if len(auxRand) != sizeAuxRand {
+		return nil, errInvalidAux
+	}
+
+	pk := privKey.PublicKey.Bytes()
+	t := taggedHash(tagAux, auxRand)
+	for i := range t {
+		t[i] ^= privKey.scalar[i]
+	}
+	h := taggedHash(tagNonce, t[:], pk, message)
+	k := new(big.Int).SetBytes(h[:])
+	k.Mod(k, order)
+	if k.Sign() == 0 {
+		return nil, errZeroNonce
+	}
+
+	var R secp256k1.G1Affine
+	R.ScalarMultiplicationBase(k)
+	if !hasEvenY(&R) {
+		k.Sub(order, k)
+	}
+
+	var sig Signature
+	sig.R = R.X.Bytes()
+	e := challenge(sig.R[:], pk, message)
+	s := new(big.Int).SetBytes(privKey.scalar[:])
+	s.Mul(s, e).
+		Add(s, k).
+		Mod(s, order)
+	s.FillBytes(sig.S[:])
+
+	return sig.Bytes(), nil
+}
+
+// Verify validates the BIP-340 signature of the message, hashed with hFunc if
+// it is provided
+//
+// e = int(hash_BIP0340/challenge(r ∥ x_P ∥ m)) mod n
+// R = s⋅G - e⋅P
+// R ≠ ∞, y_R is even and x_R ?= r
+func (publicKey *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	m, err := digest(message, hFunc)
+	if err != nil {
+		return false, err
+	}
+
+	e := challenge(sig.R[:], publicKey.Bytes(), m)
+	e.Neg(e)
+	s := new(big.Int).SetBytes(sig.S[:])
+
+	var jac secp256k1.G1Jac
+	jac.JointScalarMultiplicationBase(&publicKey.A, s, e)
+	var R secp256k1.G1Affine
+	R.FromJacobian(&jac)
+	if R.IsInfinity() || !hasEvenY(&R) {
+		return false, nil
+	}
+	x := R.X.Bytes()
+	return subtle.ConstantTimeCompare(x[:], sig.R[:]) == 1, nil
+}
+
+// BatchVerify validates the BIP-340 signatures sigs[i] of the messages msgs[i],
+// hashed with hFunc if it is provided, under the public keys pubs[i]. It
+// returns the indices of the invalid signatures, which is empty if they are all
+// valid.
+//
+// The signatures are checked at once with a single multi-scalar multiplication:
+//
+// (∑ aᵢsᵢ)⋅G - ∑ aᵢ⋅Rᵢ - ∑ aᵢeᵢ⋅Pᵢ ?= ∞
+//
+// where a₀ = 1 and the other aᵢ are random. If this check fails, the signatures
+// are verified one by one to find the invalid ones.
+func BatchVerify(pubs []PublicKey, sigs, msgs [][]byte, hFunc hash.Hash) ([]int, error) {
+	if len(pubs) != len(sigs) || len(pubs) != len(msgs) {
+		return nil, errInvalidBatch
+	}
+	if len(pubs) == 0 {
+		return nil, nil
+	}
+
+	// base, then Rᵢ and Pᵢ
+	n := len(pubs)
+	points := make([]secp256k1.G1Affine, 1+2*n)
+	scalars := make([]fr.Element, len(points))
+	_, points[0] = secp256k1.Generators()
+
+	var sig Signature
+	var a, s, e fr.Element
+	for i := range pubs {
+		if _, err := sig.SetBytes(sigs[i]); err != nil {
+			return fallbackVerify(pubs, sigs, msgs, hFunc)
+		}
+		if err := liftX(&points[1+i], sig.R[:]); err != nil {
+			return fallbackVerify(pubs, sigs, msgs, hFunc)
+		}
+		m, err := digest(msgs[i], hFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			a.SetOne()
+		} else if _, err := a.SetRandom(); err != nil {
+			return nil, err
+		}
+
+		// ∑ aᵢsᵢ
+		s.SetBytes(sig.S[:])
+		s.Mul(&s, &a)
+		scalars[0].Add(&scalars[0], &s)
+
+		// -aᵢRᵢ
+		scalars[1+i].Neg(&a)
+
+		// -aᵢeᵢPᵢ
+		points[1+n+i] = pubs[i].A
+		e.SetBigInt(challenge(sig.R[:], pubs[i].Bytes(), m))
+		scalars[1+n+i].Mul(&e, &a).Neg(&scalars[1+n+i])
+	}
+
+	var res secp256k1.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	if res.Z.IsZero() {
+		return nil, nil
+	}
+
+	return fallbackVerify(pubs, sigs, msgs, hFunc)
+}
+
+// fallbackVerify verifies the signatures one by one and returns the indices of
+// the invalid ones, including those that can't be decoded or hashed.
+func fallbackVerify(pubs []PublicKey, sigs, msgs [][]byte, hFunc hash.Hash) ([]int, error) {
+	var failed []int
+	for i := range pubs {
+		if valid, err := pubs[i].Verify(sigs[i], msgs[i], hFunc); err != nil || !valid {
+			failed = append(failed, i)
+		}
+	}
+	return failed, nil
+}
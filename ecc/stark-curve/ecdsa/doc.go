@@ -21,8 +21,26 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 //
+// Signatures use random nonces, or the deterministic nonces of RFC 6979 with
+// PrivateKey.SignDeterministic. They are encoded as r||s (Signature.Bytes) or
+// in DER (Signature.BytesDER), and can be normalized to low-S.
+//
+// Keys are encoded in PKCS #8, SEC 1 and X.509 SubjectPublicKeyInfo (DER or
+// PEM), and as JSON Web Keys.
+// The curve has no standard object identifier, so its identifier is under the
+// UUID arc of gnark-crypto.
+//
+// StarkNet signatures of message hashes (PrivateKey.SignStarknet) follow
+// cairo-lang and starknet.js: the nonce is derived with RFC 6979 and r, s⁻¹
+// and the message hash are smaller than 2²⁵¹. They are verified under full or
+// x-only public keys.
+//
 // Documentation:
 // - Wikipedia: https://en.wikipedia.org/wiki/Elliptic_Curve_Digital_Signature_Algorithm
 // - FIPS 186-4: https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.186-4.pdf
 // - SEC 1, v-2: https://www.secg.org/sec1-v2.pdf
+// - RFC 6979: https://www.rfc-editor.org/rfc/rfc6979
+// - RFC 5915 (SEC 1 private keys): https://www.rfc-editor.org/rfc/rfc5915
+// - RFC 7518 (JSON Web Keys): https://www.rfc-editor.org/rfc/rfc7518#section-6.2
+// - StarkNet signatures: https://docs.starknet.io/documentation/architecture_and_concepts/Cryptography/stark-curve/
 package ecdsa
@@ -0,0 +1,180 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve"
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	errInvalidMessageHash = errors.New("message hash must be smaller than 2²⁵¹")
+	errInvalidKey         = errors.New("x is not the abscissa of a point on the curve")
+)
+
+// starknetBound bounds the message hashes, r and s⁻¹ of StarkNet signatures
+var starknetBound = new(big.Int).Lsh(one, 251)
+
+// starknetMessage is the short string "StarkNet Message" prefixing the typed
+// messages
+var starknetMessage = new(fp.Element).SetBytes([]byte("StarkNet Message"))
+
+// SignStarknet returns the StarkNet signature r||s of the message hash, as
+// cairo-lang and starknet.js compute it. The message hash must be smaller than
+// 2²⁵¹.
+//
+// k is derived with RFC 6979 (HMAC-SHA256), from the message hash shifted by a
+// nibble when it is one nibble short of a whole number of bytes, as in
+// elliptic.js. If the signature is out of range, k is derived again with the
+// additional data 1, 2, …
+//
+// r = x_P, with P = k ⋅ g1Gen and 1 ≤ r < 2²⁵¹
+// w = k ⋅ (m + sk ⋅ r)⁻¹, with 1 ≤ w < 2²⁵¹
+// s = w⁻¹
+//
+// https://github.com/starkware-libs/cairo-lang/blob/master/src/starkware/crypto/signature/signature.py
+func (privKey *PrivateKey) SignStarknet(msgHash *fp.Element) ([]byte, error) {
+	m := msgHash.BigInt(new(big.Int))
+	if m.Cmp(starknetBound) >= 0 {
+		return nil, errInvalidMessageHash
+	}
+	h := new(big.Int).Set(m)
+	if l := h.BitLen(); l >= 248 && l%8 >= 1 && l%8 <= 4 {
+		h.Lsh(h, 4)
+	}
+
+	scalar := new(big.Int).SetBytes(privKey.scalar[:sizeFr])
+	r, w := new(big.Int), new(big.Int)
+	var P starkcurve.G1Affine
+	for seed := int64(0); ; seed++ {
+		k, err := newRFC6979(privKey.scalar[:], h.Bytes(), big.NewInt(seed).Bytes(), sha256.New).next()
+		if err != nil {
+			return nil, err
+		}
+		P.ScalarMultiplicationBase(k)
+		P.X.BigInt(r)
+		if r.Sign() == 0 || r.Cmp(starknetBound) >= 0 {
+			continue
+		}
+		w.Mul(r, scalar).
+			Add(w, m).
+			Mod(w, order)
+		if w.Sign() == 0 {
+			continue
+		}
+		w.ModInverse(w, order).
+			Mul(w, k).
+			Mod(w, order)
+		if w.Sign() == 0 || w.Cmp(starknetBound) >= 0 {
+			continue
+		}
+
+		var sig Signature
+		r.FillBytes(sig.R[:sizeFr])
+		w.ModInverse(w, order).
+			FillBytes(sig.S[:sizeFr])
+		return sig.Bytes(), nil
+	}
+}
+
+// VerifyStarknet validates the StarkNet signature r||s of the message hash.
+// Unlike Verify, r is not reduced modulo the order, and r, s⁻¹ and the message
+// hash must be smaller than 2²⁵¹.
+//
+// r ?= (s⁻¹ ⋅ m ⋅ Base + s⁻¹ ⋅ r ⋅ publiKey)_x
+func (publicKey *PublicKey) VerifyStarknet(sigBin []byte, msgHash *fp.Element) (bool, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	m := msgHash.BigInt(new(big.Int))
+	if m.Cmp(starknetBound) >= 0 {
+		return false, errInvalidMessageHash
+	}
+
+	r, w := new(big.Int), new(big.Int)
+	r.SetBytes(sig.R[:sizeFr])
+	w.SetBytes(sig.S[:sizeFr])
+	w.ModInverse(w, order)
+	if r.Cmp(starknetBound) >= 0 || w.Cmp(starknetBound) >= 0 {
+		return false, nil
+	}
+
+	u1 := new(big.Int).Mul(m, w)
+	u1.Mod(u1, order)
+	u2 := new(big.Int).Mul(r, w)
+	u2.Mod(u2, order)
+	var U starkcurve.G1Jac
+	U.JointScalarMultiplicationBase(&publicKey.A, u1, u2)
+	var P starkcurve.G1Affine
+	P.FromJacobian(&U)
+	if P.IsInfinity() {
+		return false, nil
+	}
+
+	return P.X.BigInt(new(big.Int)).Cmp(r) == 0, nil
+}
+
+// VerifyStarknetKey validates the StarkNet signature r||s of the message hash
+// under the public key given by its x coordinate only, as stored by StarkNet
+// accounts. The signature is valid if it is valid for one of the two points of
+// abscissa x.
+func VerifyStarknetKey(x *fp.Element, sigBin []byte, msgHash *fp.Element) (bool, error) {
+	// y² = x³ + a⋅x + b
+	a, b := starkcurve.CurveCoefficients()
+	var y fp.Element
+	a.Mul(&a, x)
+	y.Square(x).Mul(&y, x).Add(&y, &a).Add(&y, &b)
+	if y.Sqrt(&y) == nil {
+		return false, errInvalidKey
+	}
+
+	publicKey := PublicKey{A: starkcurve.G1Affine{X: *x, Y: y}}
+	if valid, err := publicKey.VerifyStarknet(sigBin, msgHash); err != nil || valid {
+		return valid, err
+	}
+	publicKey.A.Y.Neg(&publicKey.A.Y)
+	return publicKey.VerifyStarknet(sigBin, msgHash)
+}
+
+// StarknetMessageHash returns the hash of a typed message signed by an account,
+// as computed by starknet.js getMessageHash: the Pedersen array hash of the
+// short string "StarkNet Message", the hash of the domain, the address of the
+// account and the hash of the message.
+//
+// https://github.com/starknet-io/SNIPs/blob/main/SNIPS/snip-12.md
+func StarknetMessageHash(domainHash, account, messageHash *fp.Element) fp.Element {
+	return pedersenhash.PedersenArray(starknetMessage, domainHash, account, messageHash)
+}
+
+// StarknetKeccak returns the 250 low-order bits of the Keccak-256 hash of data,
+// which StarkNet uses for selectors and the type hashes of typed messages.
+func StarknetKeccak(data []byte) fp.Element {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	digest := h.Sum(nil)
+	digest[0] &= 0x03
+	var res fp.Element
+	res.SetBytes(digest)
+	return res
+}
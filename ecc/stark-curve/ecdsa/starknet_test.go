@@ -0,0 +1,211 @@
+// Copyright 2020 Consensys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdsa
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+	pedersenhash "github.com/consensys/gnark-crypto/ecc/stark-curve/pedersen-hash"
+)
+
+func TestSignStarknet(t *testing.T) {
+
+	// cairo-lang and starknet.js signatures with the same key
+	var privKey PrivateKey
+	d, _ := new(big.Int).SetString("104397037759416840641267745129360920341912682966983343798870479003077644689", 10)
+	d.FillBytes(privKey.scalar[:])
+	privKey.PublicKey.A.ScalarMultiplicationBase(d)
+	var x, y fp.Element
+	x.SetString("1913222325711601599563860015182907040361852177892954047964358042507353067365")
+	y.SetString("798905265292544287704154888908626830160713383708400542998012716235575472365")
+	if !privKey.PublicKey.A.X.Equal(&x) || !privKey.PublicKey.A.Y.Equal(&y) {
+		t.Fatal("wrong public key")
+	}
+
+	for _, v := range []struct {
+		hash, r, s string
+	}{
+		{
+			"2680576269831035412725132645807649347045997097070150916157159360688041452746",
+			"607684330780324271206686790958794501662789535258258105407533051445036595885",
+			"453590782387078613313238308551260565642934039343903827708036287031471258875",
+		},
+		{
+			"1",
+			"2563957710933622088785488842994189069913192454130320217865707149896187776987",
+			"2160866010037392270807261591243665616489743383143517079919241115857107637686",
+		},
+		{
+			"12345678901234567890",
+			"387327028869401048792753766974301196106600694444775587317512986911977620942",
+			"3597740499057950111758801838487087348973126882353615567843909925469213535699",
+		},
+		{
+			// 2²⁵¹ - 1
+			"3618502788666131106986593281521497120414687020801267626233049500247285301247",
+			"509977353750389967403221551442972437240371511192242203595427749778185219009",
+			"2104098357727521680451011549069997483858288243073177051007858522439484468855",
+		},
+	} {
+		var msgHash fp.Element
+		msgHash.SetString(v.hash)
+		sigBin, err := privKey.SignStarknet(&msgHash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sig Signature
+		sig.SetBytes(sigBin)
+		r := new(big.Int).SetBytes(sig.R[:])
+		s := new(big.Int).SetBytes(sig.S[:])
+		if r.String() != v.r || s.String() != v.s {
+			t.Fatal("wrong signature of", v.hash)
+		}
+
+		if valid, err := privKey.PublicKey.VerifyStarknet(sigBin, &msgHash); err != nil || !valid {
+			t.Fatal("StarkNet signature should be valid")
+		}
+		if valid, err := VerifyStarknetKey(&x, sigBin, &msgHash); err != nil || !valid {
+			t.Fatal("StarkNet signature should be valid under the x-only key")
+		}
+		if valid, err := privKey.PublicKey.Verify(sigBin, msgHash.Marshal(), nil); err != nil || !valid {
+			t.Fatal("StarkNet signature should be a valid ECDSA signature")
+		}
+	}
+
+	var tooLarge fp.Element
+	tooLarge.SetBigInt(starknetBound)
+	if _, err := privKey.SignStarknet(&tooLarge); err != errInvalidMessageHash {
+		t.Fatal("expected an error for a message hash larger than 2²⁵¹")
+	}
+}
+
+func TestVerifyStarknet(t *testing.T) {
+
+	// starknet.js signatures under x-only keys
+	for _, v := range []struct {
+		key, hash, r, s string
+	}{
+		{
+			"0x33f45f07e1bd1a51b45fc24ec8c8c9908db9e42191be9e169bfcac0c0d99745",
+			"0x7f15c38ea577a26f4f553282fcfe4f1feeb8ecfaad8f221ae41abf8224cbddd",
+			"2458502865976494910213617956670505342647705497324144349552978333078363662855",
+			"3439514492576562277095748549117516048613512930236865921315982886313695689433",
+		},
+		{
+			"0x4e52f2f40700e9cdd0f386c31a1f160d0f310504fc508a1051b747a26070d10",
+			"0x324df642fcc7d98b1d9941250840704f35b9ac2e3e2b58b6a034cc09adac54c",
+			"2849277527182985104629156126825776904262411756563556603659114084811678482647",
+			"3156340738553451171391693475354397094160428600037567299774561739201502791079",
+		},
+	} {
+		var key, msgHash fp.Element
+		key.SetString(v.key)
+		msgHash.SetString(v.hash)
+		var sig Signature
+		r, _ := new(big.Int).SetString(v.r, 10)
+		s, _ := new(big.Int).SetString(v.s, 10)
+		r.FillBytes(sig.R[:])
+		s.FillBytes(sig.S[:])
+
+		if valid, err := VerifyStarknetKey(&key, sig.Bytes(), &msgHash); err != nil || !valid {
+			t.Fatal("StarkNet signature should be valid")
+		}
+		var wrongHash fp.Element
+		wrongHash.SetOne().Add(&wrongHash, &msgHash)
+		if valid, _ := VerifyStarknetKey(&key, sig.Bytes(), &wrongHash); valid {
+			t.Fatal("StarkNet signature of another message should be invalid")
+		}
+		s.Add(s, big.NewInt(1)).FillBytes(sig.S[:])
+		if valid, _ := VerifyStarknetKey(&key, sig.Bytes(), &msgHash); valid {
+			t.Fatal("wrong StarkNet signature should be invalid")
+		}
+	}
+
+	// 5 is not the abscissa of a point of the curve
+	var five fp.Element
+	five.SetUint64(5)
+	if _, err := VerifyStarknetKey(&five, make([]byte, sizeSignature), &five); err != errInvalidKey {
+		t.Fatal("expected an error for an invalid key")
+	}
+}
+
+func TestStarknetMessageHash(t *testing.T) {
+
+	// starknet.js typed data example
+	shortString := func(s string) *fp.Element {
+		return new(fp.Element).SetBytes([]byte(s))
+	}
+	hexString := func(s string) *fp.Element {
+		res, err := new(fp.Element).SetString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res
+	}
+	typeHash := func(s string) *fp.Element {
+		res := StarknetKeccak([]byte(s))
+		return &res
+	}
+	structHash := func(elems ...*fp.Element) *fp.Element {
+		res := pedersenhash.PedersenArray(elems...)
+		return &res
+	}
+
+	const person = "Person(name:felt,wallet:felt)"
+	domain := structHash(
+		typeHash("StarkNetDomain(name:felt,version:felt,chainId:felt)"),
+		shortString("StarkNet Mail"),
+		new(fp.Element).SetOne(),
+		new(fp.Element).SetOne(),
+	)
+	mail := structHash(
+		typeHash("Mail(from:Person,to:Person,contents:felt)"+person),
+		structHash(typeHash(person), shortString("Cow"), hexString("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")),
+		structHash(typeHash(person), shortString("Bob"), hexString("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")),
+		shortString("Hello, Bob!"),
+	)
+	account := hexString("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+
+	got := StarknetMessageHash(domain, account, mail)
+	if want := hexString("0x6fcff244f63e38b9d88b9e3378d44757710d1b244282b435cb472053c8d78d0"); !got.Equal(want) {
+		t.Fatal("wrong message hash")
+	}
+}
+
+func TestStarknetKeccak(t *testing.T) {
+	// selector of the __execute__ entry point
+	got := StarknetKeccak([]byte("__execute__"))
+	var want fp.Element
+	want.SetString("0x15d40a3d6ca2ac30f4031e42be28da9b056fef9bb7357ac5e85627ee876e5ad")
+	if !got.Equal(&want) {
+		t.Fatal("wrong selector", got.Text(16))
+	}
+}
+
+func BenchmarkSignStarknet(b *testing.B) {
+	privKey, _ := GenerateKey(rand.Reader)
+	var msgHash fp.Element
+	msgHash.SetString("0x7f15c38ea577a26f4f553282fcfe4f1feeb8ecfaad8f221ae41abf8224cbddd")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		privKey.SignStarknet(&msgHash)
+	}
+}
@@ -0,0 +1,123 @@
+// Package poseidon implements the Poseidon hash of StarkNet, built on the Hades
+// permutation over the STARK field.
+//
+// # See also
+//
+// https://docs.starknet.io/documentation/architecture_and_concepts/Cryptography/hash-functions/#poseidon_hash
+// https://eprint.iacr.org/2019/458.pdf (Poseidon)
+package poseidon
+
+import (
+	"crypto/sha256"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+const (
+	// Width is the number of field elements of the state of the permutation
+	Width = 3
+
+	fullRounds    = 8
+	partialRounds = 83
+)
+
+var roundKeys [fullRounds + partialRounds][Width]fp.Element
+
+func init() {
+	// The round keys come from the [reference implementation]: the j-th key of
+	// the i-th round is sha256("Hades" ∥ decimal(3i+j)) mod p.
+	//
+	// [reference implementation]: https://github.com/starkware-libs/cairo-lang/blob/master/src/starkware/cairo/common/poseidon_utils.py
+	for i := range roundKeys {
+		for j := range roundKeys[i] {
+			h := sha256.Sum256([]byte("Hades" + strconv.Itoa(Width*i+j)))
+			roundKeys[i][j].SetBytes(h[:])
+		}
+	}
+}
+
+// Permutation applies the Hades permutation of StarkNet to the state: 4 full
+// rounds, 83 partial rounds and 4 full rounds, each adding the round keys,
+// cubing the state (only its last element in partial rounds) and multiplying
+// it by the MDS matrix
+//
+//	⎛3  1  1⎞
+//	⎜1 -1  1⎟
+//	⎝1  1 -2⎠
+func Permutation(state *[Width]fp.Element) {
+	for i := range roundKeys {
+		full := i < fullRounds/2 || i >= fullRounds/2+partialRounds
+		round(state, &roundKeys[i], full)
+	}
+}
+
+func round(state *[Width]fp.Element, keys *[Width]fp.Element, full bool) {
+	var sq fp.Element
+	for j := range state {
+		state[j].Add(&state[j], &keys[j])
+	}
+	if full {
+		for j := 0; j < Width-1; j++ {
+			sq.Square(&state[j])
+			state[j].Mul(&state[j], &sq)
+		}
+	}
+	sq.Square(&state[Width-1])
+	state[Width-1].Mul(&state[Width-1], &sq)
+
+	// s = x₀ + x₁ + x₂
+	// (x₀, x₁, x₂) ← (s + 2x₀, s - 2x₁, s - 3x₂)
+	var s, t fp.Element
+	s.Add(&state[0], &state[1]).Add(&s, &state[2])
+	state[0].Double(&state[0]).Add(&state[0], &s)
+	state[1].Double(&state[1]).Sub(&s, &state[1])
+	t.Double(&state[2]).Add(&t, &state[2])
+	state[2].Sub(&s, &t)
+}
+
+// Hash implements the [Poseidon hash] of two elements, the first element of the
+// permutation of (a, b, 2).
+//
+// [Poseidon hash]: https://docs.starknet.io/documentation/architecture_and_concepts/Cryptography/hash-functions/#poseidon_hash
+func Hash(a, b *fp.Element) fp.Element {
+	state := [Width]fp.Element{*a, *b}
+	state[2].SetUint64(2)
+	Permutation(&state)
+	return state[0]
+}
+
+// HashSingle returns the Poseidon hash of a single element, the first element of
+// the permutation of (a, 0, 1).
+func HashSingle(a *fp.Element) fp.Element {
+	state := [Width]fp.Element{*a}
+	state[2].SetOne()
+	Permutation(&state)
+	return state[0]
+}
+
+// HashMany implements [Poseidon array hashing]: the elements, padded with 1
+// and then 0 if needed to an even length, are absorbed two by two in a sponge
+// of rate 2 and capacity 1.
+//
+// [Poseidon array hashing]: https://docs.starknet.io/documentation/architecture_and_concepts/Cryptography/hash-functions/#poseidon_array_hash
+func HashMany(elems ...*fp.Element) fp.Element {
+	var state [Width]fp.Element
+	for len(elems) >= 2 {
+		state[0].Add(&state[0], elems[0])
+		state[1].Add(&state[1], elems[1])
+		Permutation(&state)
+		elems = elems[2:]
+	}
+
+	var one fp.Element
+	one.SetOne()
+	if len(elems) == 1 {
+		state[0].Add(&state[0], elems[0])
+		state[1].Add(&state[1], &one)
+	} else {
+		state[0].Add(&state[0], &one)
+	}
+	Permutation(&state)
+	return state[0]
+}
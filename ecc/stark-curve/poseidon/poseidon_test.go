@@ -0,0 +1,103 @@
+package poseidon
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/stark-curve/fp"
+)
+
+func TestRoundKeys(t *testing.T) {
+	// first and last round keys of the reference implementation
+	tests := []struct {
+		round, index int
+		want         string
+	}{
+		{0, 0, "2950795762459345168613727575620414179244544320470208355568817838579231751791"},
+		{0, 1, "1587446564224215276866294500450702039420286416111469274423465069420553242820"},
+		{0, 2, "1645965921169490687904413452218868659025437693527479459426157555728339600137"},
+		{90, 0, "2792703718581084537295613508201818489836796608902614779596544185252826291584"},
+		{90, 1, "2294173715793292812015960640392421991604150133581218254866878921346561546149"},
+		{90, 2, "2770011224727997178743274791849308200493823127651418989170761007078565678171"},
+	}
+	for _, tt := range tests {
+		var want fp.Element
+		if _, err := want.SetString(tt.want); err != nil {
+			t.Fatal(err)
+		}
+		if !roundKeys[tt.round][tt.index].Equal(&want) {
+			t.Errorf("round key (%d, %d) = %s, want %s", tt.round, tt.index, roundKeys[tt.round][tt.index].String(), tt.want)
+		}
+	}
+}
+
+func TestHash(t *testing.T) {
+	a := new(fp.Element).SetUint64(1)
+	b := new(fp.Element).SetUint64(2)
+	want, err := new(fp.Element).SetString("0x5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Hash(a, b); !got.Equal(want) {
+		t.Errorf("Hash(1, 2) = %s, want %s", got.Text(16), want.Text(16))
+	}
+
+	// HashSingle and Hash are the first element of the permutation of (a, 0, 1)
+	// and (a, b, 2)
+	state := [Width]fp.Element{*a}
+	state[2].SetOne()
+	Permutation(&state)
+	if got := HashSingle(a); !got.Equal(&state[0]) {
+		t.Errorf("HashSingle(1) = %s, want %s", got.Text(16), state[0].Text(16))
+	}
+}
+
+func TestHashMany(t *testing.T) {
+	// starknet.js / cairo-lang poseidon_hash_many
+	tests := []struct {
+		input []uint64
+		want  string
+	}{
+		{
+			[]uint64{},
+			"0x2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc",
+		},
+		{
+			[]uint64{0, 1, 2},
+			"0x7a01142da8aecae3782ba66fc3285fd02fcd2c55aa868fe50fd95c089068d16",
+		},
+		{
+			[]uint64{0, 1, 2, 3},
+			"0x7b8f30ac298ea12d170c0873f1fa631a18c00756c6e7d1fd273b9a239d0d413",
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("TestHashMany %d", i), func(t *testing.T) {
+			data := make([]*fp.Element, len(tt.input))
+			for j, v := range tt.input {
+				data[j] = new(fp.Element).SetUint64(v)
+			}
+			want, err := new(fp.Element).SetString(tt.want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := HashMany(data...); !got.Equal(want) {
+				t.Errorf("HashMany(%v) = %s, want %s", tt.input, got.Text(16), want.Text(16))
+			}
+		})
+	}
+}
+
+func BenchmarkHashMany(b *testing.B) {
+	elems := make([]*fp.Element, 16)
+	for i := range elems {
+		elems[i] = new(fp.Element)
+		if _, err := elems[i].SetRandom(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		HashMany(elems...)
+	}
+}
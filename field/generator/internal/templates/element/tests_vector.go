@@ -69,6 +69,21 @@ func TestVectorEmptyRoundTrip(t *testing.T) {
 	assert.True(reflect.DeepEqual(v3,v2))
 }
 
+func TestVectorForgedLength(t *testing.T) {
+	assert := require.New(t)
+
+	v1 := make(Vector, 1)
+	v1[0].SetUint64(1)
+
+	b, err := v1.MarshalBinary()
+	assert.NoError(err)
+
+	// the length is not trusted: decoding fails once the data is exhausted
+	b[0], b[1], b[2], b[3] = 0xff, 0xff, 0xff, 0xff
+	var v2 Vector
+	assert.Error(v2.UnmarshalBinary(b))
+}
+
 
 
 func (vector *Vector) unmarshalBinaryAsync(data []byte) error {
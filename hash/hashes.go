@@ -12,25 +12,58 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Package hash provides MiMC hash function defined over curves implemented in gnark-crypto/ecc.
+// Package hash provides MiMC, Anemoi, Griffin and Rescue-Prime hash functions defined over curves implemented in gnark-crypto/ecc,
+// and a registry to select them, or other hash functions registered with Register, by name.
 //
 // Originally developed and used in a ZKP context.
 package hash
 
 import (
+	"fmt"
 	"hash"
+	"sync"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	anemoibls377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/anemoi"
+	griffinbls377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/griffin"
 	bls377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/mimc"
+	rescuebls377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr/rescue"
+	anemoibls378 "github.com/consensys/gnark-crypto/ecc/bls12-378/fr/anemoi"
+	griffinbls378 "github.com/consensys/gnark-crypto/ecc/bls12-378/fr/griffin"
 	bls378 "github.com/consensys/gnark-crypto/ecc/bls12-378/fr/mimc"
+	rescuebls378 "github.com/consensys/gnark-crypto/ecc/bls12-378/fr/rescue"
+	anemoibls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/anemoi"
+	griffinbls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/griffin"
 	bls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/mimc"
+	rescuebls381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr/rescue"
+	anemoibls315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/anemoi"
+	griffinbls315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/griffin"
 	bls315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/mimc"
+	rescuebls315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr/rescue"
+	anemoibls317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/anemoi"
+	griffinbls317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/griffin"
 	bls317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/mimc"
+	rescuebls317 "github.com/consensys/gnark-crypto/ecc/bls24-317/fr/rescue"
+	anemoibn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/anemoi"
+	griffinbn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/griffin"
 	bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	rescuebn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr/rescue"
+	anemoibw633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/anemoi"
+	griffinbw633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/griffin"
 	bw633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/mimc"
+	rescuebw633 "github.com/consensys/gnark-crypto/ecc/bw6-633/fr/rescue"
+	anemoibw756 "github.com/consensys/gnark-crypto/ecc/bw6-756/fr/anemoi"
+	griffinbw756 "github.com/consensys/gnark-crypto/ecc/bw6-756/fr/griffin"
 	bw756 "github.com/consensys/gnark-crypto/ecc/bw6-756/fr/mimc"
+	rescuebw756 "github.com/consensys/gnark-crypto/ecc/bw6-756/fr/rescue"
+	anemoibw761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/anemoi"
+	griffinbw761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/griffin"
 	bw761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/mimc"
+	rescuebw761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr/rescue"
 )
 
+// Hash identifies a hash function: one of the constants below, or a hash
+// function registered with Register.
 type Hash uint
 
 const (
@@ -43,75 +76,185 @@ const (
 	MIMC_BLS24_317
 	MIMC_BW6_633
 	MIMC_BW6_756
+	ANEMOI_BN254
+	ANEMOI_BLS12_381
+	ANEMOI_BLS12_377
+	ANEMOI_BLS12_378
+	ANEMOI_BW6_761
+	ANEMOI_BLS24_315
+	ANEMOI_BLS24_317
+	ANEMOI_BW6_633
+	ANEMOI_BW6_756
+	GRIFFIN_BN254
+	GRIFFIN_BLS12_381
+	GRIFFIN_BLS12_377
+	GRIFFIN_BLS12_378
+	GRIFFIN_BW6_761
+	GRIFFIN_BLS24_315
+	GRIFFIN_BLS24_317
+	GRIFFIN_BW6_633
+	GRIFFIN_BW6_756
+	RESCUE_BN254
+	RESCUE_BLS12_381
+	RESCUE_BLS12_377
+	RESCUE_BLS12_378
+	RESCUE_BW6_761
+	RESCUE_BLS24_315
+	RESCUE_BLS24_317
+	RESCUE_BW6_633
+	RESCUE_BW6_756
 )
 
-// size of digests in bytes
-var digestSize = []uint8{
-	MIMC_BN254:     32,
-	MIMC_BLS12_381: 48,
-	MIMC_BLS12_377: 48,
-	MIMC_BLS12_378: 48,
-	MIMC_BW6_761:   96,
-	MIMC_BLS24_315: 48,
-	MIMC_BLS24_317: 48,
-	MIMC_BW6_633:   80,
-	MIMC_BW6_756:   96,
+// registration describes a hash function of the registry
+type registration struct {
+	name  string
+	field ecc.ID
+	size  int
+	new   func() hash.Hash
 }
 
-// New creates the corresponding mimc hash function.
+var (
+	registryLock sync.RWMutex
+	registry     = []registration{
+		MIMC_BN254:        {name: "MIMC_BN254", field: ecc.BN254, size: 32, new: func() hash.Hash { return bn254.NewMiMC() }},
+		MIMC_BLS12_381:    {name: "MIMC_BLS381", field: ecc.BLS12_381, size: 48, new: func() hash.Hash { return bls381.NewMiMC() }},
+		MIMC_BLS12_377:    {name: "MIMC_BLS377", field: ecc.BLS12_377, size: 48, new: func() hash.Hash { return bls377.NewMiMC() }},
+		MIMC_BLS12_378:    {name: "MIMC_BLS378", field: ecc.BLS12_378, size: 48, new: func() hash.Hash { return bls378.NewMiMC() }},
+		MIMC_BW6_761:      {name: "MIMC_BW761", field: ecc.BW6_761, size: 96, new: func() hash.Hash { return bw761.NewMiMC() }},
+		MIMC_BLS24_315:    {name: "MIMC_BLS315", field: ecc.BLS24_315, size: 48, new: func() hash.Hash { return bls315.NewMiMC() }},
+		MIMC_BLS24_317:    {name: "MIMC_BLS317", field: ecc.BLS24_317, size: 48, new: func() hash.Hash { return bls317.NewMiMC() }},
+		MIMC_BW6_633:      {name: "MIMC_BW633", field: ecc.BW6_633, size: 80, new: func() hash.Hash { return bw633.NewMiMC() }},
+		MIMC_BW6_756:      {name: "MIMC_BW756", field: ecc.BW6_756, size: 96, new: func() hash.Hash { return bw756.NewMiMC() }},
+		ANEMOI_BN254:      {name: "ANEMOI_BN254", field: ecc.BN254, size: anemoibn254.BlockSize, new: anemoibn254.NewAnemoi},
+		ANEMOI_BLS12_381:  {name: "ANEMOI_BLS381", field: ecc.BLS12_381, size: anemoibls381.BlockSize, new: anemoibls381.NewAnemoi},
+		ANEMOI_BLS12_377:  {name: "ANEMOI_BLS377", field: ecc.BLS12_377, size: anemoibls377.BlockSize, new: anemoibls377.NewAnemoi},
+		ANEMOI_BLS12_378:  {name: "ANEMOI_BLS378", field: ecc.BLS12_378, size: anemoibls378.BlockSize, new: anemoibls378.NewAnemoi},
+		ANEMOI_BW6_761:    {name: "ANEMOI_BW761", field: ecc.BW6_761, size: anemoibw761.BlockSize, new: anemoibw761.NewAnemoi},
+		ANEMOI_BLS24_315:  {name: "ANEMOI_BLS315", field: ecc.BLS24_315, size: anemoibls315.BlockSize, new: anemoibls315.NewAnemoi},
+		ANEMOI_BLS24_317:  {name: "ANEMOI_BLS317", field: ecc.BLS24_317, size: anemoibls317.BlockSize, new: anemoibls317.NewAnemoi},
+		ANEMOI_BW6_633:    {name: "ANEMOI_BW633", field: ecc.BW6_633, size: anemoibw633.BlockSize, new: anemoibw633.NewAnemoi},
+		ANEMOI_BW6_756:    {name: "ANEMOI_BW756", field: ecc.BW6_756, size: anemoibw756.BlockSize, new: anemoibw756.NewAnemoi},
+		GRIFFIN_BN254:     {name: "GRIFFIN_BN254", field: ecc.BN254, size: griffinbn254.BlockSize, new: griffinbn254.NewGriffin},
+		GRIFFIN_BLS12_381: {name: "GRIFFIN_BLS381", field: ecc.BLS12_381, size: griffinbls381.BlockSize, new: griffinbls381.NewGriffin},
+		GRIFFIN_BLS12_377: {name: "GRIFFIN_BLS377", field: ecc.BLS12_377, size: griffinbls377.BlockSize, new: griffinbls377.NewGriffin},
+		GRIFFIN_BLS12_378: {name: "GRIFFIN_BLS378", field: ecc.BLS12_378, size: griffinbls378.BlockSize, new: griffinbls378.NewGriffin},
+		GRIFFIN_BW6_761:   {name: "GRIFFIN_BW761", field: ecc.BW6_761, size: griffinbw761.BlockSize, new: griffinbw761.NewGriffin},
+		GRIFFIN_BLS24_315: {name: "GRIFFIN_BLS315", field: ecc.BLS24_315, size: griffinbls315.BlockSize, new: griffinbls315.NewGriffin},
+		GRIFFIN_BLS24_317: {name: "GRIFFIN_BLS317", field: ecc.BLS24_317, size: griffinbls317.BlockSize, new: griffinbls317.NewGriffin},
+		GRIFFIN_BW6_633:   {name: "GRIFFIN_BW633", field: ecc.BW6_633, size: griffinbw633.BlockSize, new: griffinbw633.NewGriffin},
+		GRIFFIN_BW6_756:   {name: "GRIFFIN_BW756", field: ecc.BW6_756, size: griffinbw756.BlockSize, new: griffinbw756.NewGriffin},
+		RESCUE_BN254:      {name: "RESCUE_BN254", field: ecc.BN254, size: rescuebn254.BlockSize, new: rescuebn254.NewRescuePrime},
+		RESCUE_BLS12_381:  {name: "RESCUE_BLS381", field: ecc.BLS12_381, size: rescuebls381.BlockSize, new: rescuebls381.NewRescuePrime},
+		RESCUE_BLS12_377:  {name: "RESCUE_BLS377", field: ecc.BLS12_377, size: rescuebls377.BlockSize, new: rescuebls377.NewRescuePrime},
+		RESCUE_BLS12_378:  {name: "RESCUE_BLS378", field: ecc.BLS12_378, size: rescuebls378.BlockSize, new: rescuebls378.NewRescuePrime},
+		RESCUE_BW6_761:    {name: "RESCUE_BW761", field: ecc.BW6_761, size: rescuebw761.BlockSize, new: rescuebw761.NewRescuePrime},
+		RESCUE_BLS24_315:  {name: "RESCUE_BLS315", field: ecc.BLS24_315, size: rescuebls315.BlockSize, new: rescuebls315.NewRescuePrime},
+		RESCUE_BLS24_317:  {name: "RESCUE_BLS317", field: ecc.BLS24_317, size: rescuebls317.BlockSize, new: rescuebls317.NewRescuePrime},
+		RESCUE_BW6_633:    {name: "RESCUE_BW633", field: ecc.BW6_633, size: rescuebw633.BlockSize, new: rescuebw633.NewRescuePrime},
+		RESCUE_BW6_756:    {name: "RESCUE_BW756", field: ecc.BW6_756, size: rescuebw756.BlockSize, new: rescuebw756.NewRescuePrime},
+	}
+	byName map[string]Hash
+)
+
+func init() {
+	byName = make(map[string]Hash, len(registry))
+	for i := range registry {
+		byName[registry[i].name] = Hash(i)
+	}
+}
+
+// Register makes a hash function available under the given name, and returns
+// its identifier. field is the curve on the scalar field of which the hash
+// function operates, or ecc.UNKNOWN if it hashes arbitrary bytes. The digest
+// size is that of a hash returned by the constructor.
+func Register(name string, constructor func() hash.Hash, field ecc.ID) (Hash, error) {
+	// the constructor is user code; call it before taking the lock
+	size := constructor().Size()
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	if _, ok := byName[name]; ok {
+		return 0, fmt.Errorf("hash function %s already registered", name)
+	}
+	m := Hash(len(registry))
+	registry = append(registry, registration{name: name, field: field, size: size, new: constructor})
+	byName[name] = m
+	return m, nil
+}
+
+// Lookup returns the identifier of the hash function registered under the
+// given name, as returned by String.
+func Lookup(name string) (Hash, error) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	if m, ok := byName[name]; ok {
+		return m, nil
+	}
+	return 0, fmt.Errorf("unknown hash function %s", name)
+}
+
+// Available reports whether m identifies a registered hash function.
+func (m Hash) Available() bool {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return int(m) < len(registry)
+}
+
+func (m Hash) registration() registration {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	if int(m) >= len(registry) {
+		panic("Unknown hash ID")
+	}
+	return registry[m]
+}
+
+// New creates the corresponding hash function.
 func (m Hash) New() hash.Hash {
+	return m.registration().new()
+}
+
+// NewPadded creates the corresponding mimc hash function, accepting arbitrary
+// byte streams: its input is split in chunks padded as described by the
+// ChunkPadding mode of the mimc packages. The hash also implements the
+// FieldHasher interface of its mimc package, to write field elements directly.
+func (m Hash) NewPadded() hash.Hash {
 	switch m {
 	case MIMC_BN254:
-		return bn254.NewMiMC()
+		return bn254.NewMiMC(bn254.WithPadding(bn254.ChunkPadding))
 	case MIMC_BLS12_381:
-		return bls381.NewMiMC()
+		return bls381.NewMiMC(bls381.WithPadding(bls381.ChunkPadding))
 	case MIMC_BLS12_377:
-		return bls377.NewMiMC()
+		return bls377.NewMiMC(bls377.WithPadding(bls377.ChunkPadding))
 	case MIMC_BLS12_378:
-		return bls378.NewMiMC()
+		return bls378.NewMiMC(bls378.WithPadding(bls378.ChunkPadding))
 	case MIMC_BW6_761:
-		return bw761.NewMiMC()
+		return bw761.NewMiMC(bw761.WithPadding(bw761.ChunkPadding))
 	case MIMC_BLS24_315:
-		return bls315.NewMiMC()
+		return bls315.NewMiMC(bls315.WithPadding(bls315.ChunkPadding))
 	case MIMC_BLS24_317:
-		return bls317.NewMiMC()
+		return bls317.NewMiMC(bls317.WithPadding(bls317.ChunkPadding))
 	case MIMC_BW6_633:
-		return bw633.NewMiMC()
+		return bw633.NewMiMC(bw633.WithPadding(bw633.ChunkPadding))
 	case MIMC_BW6_756:
-		return bw756.NewMiMC()
+		return bw756.NewMiMC(bw756.WithPadding(bw756.ChunkPadding))
 	default:
 		panic("Unknown mimc ID")
 	}
 }
 
-// String returns the mimc ID to string format.
+// String returns the hash ID to string format.
 func (m Hash) String() string {
-	switch m {
-	case MIMC_BN254:
-		return "MIMC_BN254"
-	case MIMC_BLS12_381:
-		return "MIMC_BLS381"
-	case MIMC_BLS12_377:
-		return "MIMC_BLS377"
-	case MIMC_BLS12_378:
-		return "MIMC_BLS378"
-	case MIMC_BW6_761:
-		return "MIMC_BW761"
-	case MIMC_BLS24_315:
-		return "MIMC_BLS315"
-	case MIMC_BLS24_317:
-		return "MIMC_BLS317"
-	case MIMC_BW6_633:
-		return "MIMC_BW633"
-	case MIMC_BW6_756:
-		return "MIMC_BW756"
-	default:
-		panic("Unknown mimc ID")
-	}
+	return m.registration().name
 }
 
 // Size returns the size of the digest of
 // the corresponding hash function
 func (m Hash) Size() int {
-	return int(digestSize[m])
+	return m.registration().size
+}
+
+// Field returns the curve on the scalar field of which the hash function
+// operates, or ecc.UNKNOWN.
+func (m Hash) Field() ecc.ID {
+	return m.registration().field
 }
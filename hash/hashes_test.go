@@ -0,0 +1,73 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hash
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltins(t *testing.T) {
+	assert := require.New(t)
+	for m := MIMC_BN254; m <= RESCUE_BW6_756; m++ {
+		assert.True(m.Available())
+		found, err := Lookup(m.String())
+		assert.NoError(err)
+		assert.Equal(m, found)
+		assert.NotEqual(ecc.UNKNOWN, m.Field())
+		assert.NotNil(m.New())
+	}
+	assert.Equal(ecc.BLS12_381, GRIFFIN_BLS12_381.Field())
+	assert.Equal(32, RESCUE_BN254.Size())
+}
+
+func TestRegister(t *testing.T) {
+	assert := require.New(t)
+
+	// the registry is global: use a fresh name so that the test can be repeated
+	registryLock.RLock()
+	name := fmt.Sprintf("SHA256_TEST_%d", len(registry))
+	registryLock.RUnlock()
+
+	m, err := Register(name, sha256.New, ecc.UNKNOWN)
+	assert.NoError(err)
+	assert.True(m.Available())
+	assert.Equal(name, m.String())
+	assert.Equal(sha256.Size, m.Size())
+	assert.Equal(ecc.UNKNOWN, m.Field())
+	found, err := Lookup(name)
+	assert.NoError(err)
+	assert.Equal(m, found)
+
+	h := m.New()
+	h.Write([]byte("gnark"))
+	expected := sha256.Sum256([]byte("gnark"))
+	assert.Equal(expected[:], h.Sum(nil))
+
+	_, err = Register(name, sha256.New, ecc.UNKNOWN)
+	assert.Error(err, "name already taken")
+	_, err = Register(MIMC_BN254.String(), func() hash.Hash { return MIMC_BN254.New() }, ecc.BN254)
+	assert.Error(err, "name already taken")
+
+	_, err = Lookup("UNKNOWN_HASH")
+	assert.Error(err)
+	assert.False((m + 1).Available())
+	assert.Panics(func() { (m + 1).New() })
+}
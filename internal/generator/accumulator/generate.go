@@ -0,0 +1,21 @@
+package accumulator
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// pairing-based dynamic accumulator
+	conf.Package = "accumulator"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "accumulator.go"), Templates: []string{"accumulator.go.tmpl"}},
+		{File: filepath.Join(baseDir, "accumulator_test.go"), Templates: []string{"accumulator.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./accumulator/template/", entries...)
+
+}
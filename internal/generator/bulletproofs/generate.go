@@ -0,0 +1,49 @@
+package bulletproofs
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// templateData is the data of the templates, for the G1 group of a curve or
+// for a twisted Edwards companion curve.
+type templateData struct {
+	// Name is the name of the curve, Path the one of the package of the group
+	// under ecc/ and Curve the name of a twisted Edwards curve
+	Name, Path, Curve string
+	Package           string
+
+	// TwistedEdwards is set for a twisted Edwards curve, whose points are
+	// PointAffine, with coordinates in the scalar field of Name
+	TwistedEdwards bool
+}
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// bulletproofs inner product argument and range proofs on G1
+	return generate(templateData{Name: conf.Name, Path: conf.Name}, baseDir, bgen)
+}
+
+// GenerateTwistedEdwards generates the inner product argument and range proofs
+// on a twisted Edwards curve, which must have a scalar field package fr.
+func GenerateTwistedEdwards(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	return generate(templateData{
+		Name:           conf.Name,
+		Path:           conf.Name + "/" + conf.Package,
+		Curve:          conf.Package,
+		TwistedEdwards: true,
+	}, baseDir, bgen)
+}
+
+func generate(data templateData, baseDir string, bgen *bavard.BatchGenerator) error {
+	data.Package = "bulletproofs"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bulletproofs.go"), Templates: []string{"bulletproofs.go.tmpl"}},
+		{File: filepath.Join(baseDir, "range.go"), Templates: []string{"range.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bulletproofs_test.go"), Templates: []string{"bulletproofs.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./bulletproofs/template/", entries...)
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// gnarkArc is the root of the object identifiers of the keys of gnark-crypto
+// which have no standard one. It is derived from a UUID (ITU-T X.667), so it
+// needs no registration.
+const gnarkArc = "2.25.46608167216907221384985006267746188518"
+
+// curveArcs is the arc of each curve under gnarkArc.1 (ECDSA) and gnarkArc.2
+// (EdDSA on the twisted Edwards companion curve). It is the value of its ecc.ID.
+var curveArcs = map[string]int{
+	"bn254":       1,
+	"bls12-377":   2,
+	"bls12-378":   3,
+	"bls12-381":   4,
+	"bls24-315":   5,
+	"bls24-317":   6,
+	"bw6-761":     7,
+	"bw6-633":     8,
+	"bw6-756":     9,
+	"stark-curve": 10,
+}
+
+// ECDSAOID returns the object identifier of the curve in the namedCurve
+// parameters of ECDSA keys: the SEC 2 one for secp256k1, gnarkArc.1.<ecc.ID>
+// otherwise.
+func (c Curve) ECDSAOID() string {
+	if c.Equal(SECP256K1) {
+		return "1.3.132.0.10"
+	}
+	return fmt.Sprintf("%s.1.%d", gnarkArc, curveArcs[c.Name])
+}
+
+// EdDSAOID returns the object identifier of the algorithm of EdDSA keys on the
+// companion curve, gnarkArc.2.<ecc.ID>, as Ed25519 has one in RFC 8410.
+func (c TwistedEdwardsCurve) EdDSAOID() string {
+	return fmt.Sprintf("%s.2.%d", gnarkArc, curveArcs[c.Name])
+}
+
+// DEROID returns the DER encoded contents of the object identifier oid, as a
+// list of Go bytes to be used in templates.
+func DEROID(oid string) string {
+	arcs := strings.Split(oid, ".")
+	values := make([]*big.Int, len(arcs))
+	for i, a := range arcs {
+		var ok bool
+		if values[i], ok = new(big.Int).SetString(a, 10); !ok {
+			panic("invalid object identifier " + oid)
+		}
+	}
+	// the first two arcs are encoded together as 40⋅a₀ + a₁
+	first := new(big.Int).Mul(values[0], big.NewInt(40))
+	values = append([]*big.Int{first.Add(first, values[1])}, values[2:]...)
+
+	var res []string
+	for _, v := range values {
+		// base 128, most significant group first, with the high bit set on
+		// all the groups but the last
+		var groups []byte
+		for v = new(big.Int).Set(v); ; {
+			groups = append([]byte{byte(v.Uint64() & 0x7f)}, groups...)
+			if v.Rsh(v, 7); v.Sign() == 0 {
+				break
+			}
+		}
+		for i, g := range groups {
+			if i != len(groups)-1 {
+				g |= 0x80
+			}
+			res = append(res, fmt.Sprintf("0x%02x", g))
+		}
+	}
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,21 @@
+package anemoi
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	conf.Package = "anemoi"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "anemoi.go"), Templates: []string{"anemoi.go.tmpl"}},
+		{File: filepath.Join(baseDir, "anemoi_test.go"), Templates: []string{"anemoi.test.go.tmpl"}},
+	}
+
+	return bgen.Generate(conf, conf.Package, "./crypto/hash/anemoi/template", entries...)
+
+}
@@ -0,0 +1,21 @@
+package griffin
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	conf.Package = "griffin"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "griffin.go"), Templates: []string{"griffin.go.tmpl"}},
+		{File: filepath.Join(baseDir, "griffin_test.go"), Templates: []string{"griffin.test.go.tmpl"}},
+	}
+
+	return bgen.Generate(conf, conf.Package, "./crypto/hash/griffin/template", entries...)
+
+}
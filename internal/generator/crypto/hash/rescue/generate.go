@@ -0,0 +1,21 @@
+package rescue
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	conf.Package = "rescue"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rescue.go"), Templates: []string{"rescue.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rescue_test.go"), Templates: []string{"rescue.test.go.tmpl"}},
+	}
+
+	return bgen.Generate(conf, conf.Package, "./crypto/hash/rescue/template", entries...)
+
+}
@@ -15,10 +15,32 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "ecdsa.go"), Templates: []string{"ecdsa.go.tmpl"}},
+		{File: filepath.Join(baseDir, "rfc6979.go"), Templates: []string{"rfc6979.go.tmpl"}},
 		{File: filepath.Join(baseDir, "ecdsa_test.go"), Templates: []string{"ecdsa.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"marshal.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "encoding.go"), Templates: []string{"encoding.go.tmpl"}},
+		{File: filepath.Join(baseDir, "encoding_test.go"), Templates: []string{"encoding.test.go.tmpl"}},
 	}
-	return bgen.Generate(conf, conf.Package, "./ecdsa/template", entries...)
+	if conf.Equal(config.SECP256K1) {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "adaptor.go"), Templates: []string{"adaptor.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "adaptor_test.go"), Templates: []string{"adaptor.test.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "ethereum.go"), Templates: []string{"ethereum.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "ethereum_test.go"), Templates: []string{"ethereum.test.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "vectors_test.go"), Templates: []string{"vectors.test.go.tmpl"}},
+		)
+	}
+	if conf.Equal(config.STARK_CURVE) {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "starknet.go"), Templates: []string{"starknet.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "starknet_test.go"), Templates: []string{"starknet.test.go.tmpl"}},
+		)
+	}
+	funcs := make(map[string]interface{})
+	funcs["derOID"] = config.DEROID
+	bavardOpts := []func(*bavard.Bavard) error{bavard.Funcs(funcs)}
+
+	return bgen.GenerateWithOptions(conf, conf.Package, "./ecdsa/template", bavardOpts, entries...)
 
 }
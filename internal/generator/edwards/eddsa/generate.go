@@ -17,7 +17,17 @@ func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.Batc
 		{File: filepath.Join(baseDir, "eddsa.go"), Templates: []string{"eddsa.go.tmpl"}},
 		{File: filepath.Join(baseDir, "eddsa_test.go"), Templates: []string{"eddsa.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "encoding.go"), Templates: []string{"encoding.go.tmpl"}},
+		{File: filepath.Join(baseDir, "encoding_test.go"), Templates: []string{"encoding.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "musig2.go"), Templates: []string{"musig2.go.tmpl"}},
+		{File: filepath.Join(baseDir, "musig2_test.go"), Templates: []string{"musig2.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "frost.go"), Templates: []string{"frost.go.tmpl"}},
+		{File: filepath.Join(baseDir, "frost_test.go"), Templates: []string{"frost.test.go.tmpl"}},
 	}
-	return bgen.Generate(conf, conf.Package, "./edwards/eddsa/template", entries...)
+	funcs := make(map[string]interface{})
+	funcs["derOID"] = config.DEROID
+	bavardOpts := []func(*bavard.Bavard) error{bavard.Funcs(funcs)}
+
+	return bgen.GenerateWithOptions(conf, conf.Package, "./edwards/eddsa/template", bavardOpts, entries...)
 
 }
@@ -19,6 +19,12 @@ func Generate(config Config, baseDir string, bgen *bavard.BatchGenerator) error
 		{File: filepath.Join(baseDir, "gkr.go"), Templates: []string{"gkr.go.tmpl"}},
 	}
 
+	// binary and JSON encodings rely on fr.Vector
+	if config.FieldPackageName == "fr" {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"gkr.marshal.go.tmpl"}})
+	}
+
 	if config.GenerateTests {
 		entries = append(entries,
 			bavard.Entry{File: filepath.Join(baseDir, "gkr_test.go"), Templates: []string{"gkr.test.go.tmpl", "gkr.test.vectors.go.tmpl"}})
@@ -10,8 +10,13 @@ import (
 	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/field/generator"
 	field "github.com/consensys/gnark-crypto/field/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/accumulator"
+	"github.com/consensys/gnark-crypto/internal/generator/bulletproofs"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/anemoi"
+	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/griffin"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/mimc"
+	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/rescue"
 	"github.com/consensys/gnark-crypto/internal/generator/ecc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecdsa"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards"
@@ -29,8 +34,10 @@ import (
 	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
 	"github.com/consensys/gnark-crypto/internal/generator/sis"
 	"github.com/consensys/gnark-crypto/internal/generator/sumcheck"
+	"github.com/consensys/gnark-crypto/internal/generator/tbls"
 	"github.com/consensys/gnark-crypto/internal/generator/test_vector_utils"
 	"github.com/consensys/gnark-crypto/internal/generator/tower"
+	"github.com/consensys/gnark-crypto/internal/generator/zeromorph"
 )
 
 const (
@@ -76,6 +83,9 @@ func main() {
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
+			// generate bulletproofs on G1
+			assertNoError(bulletproofs.Generate(conf, filepath.Join(curveDir, "fr", "bulletproofs"), bgen))
+
 			if conf.Equal(config.SECP256K1) {
 				return
 			}
@@ -99,6 +109,15 @@ func main() {
 			// generate kzg on fr
 			assertNoError(kzg.Generate(conf, filepath.Join(curveDir, "kzg"), bgen))
 
+			// generate zeromorph on fr
+			assertNoError(zeromorph.Generate(conf, filepath.Join(curveDir, "zeromorph"), bgen))
+
+			// generate pairing-based accumulator on fr
+			assertNoError(accumulator.Generate(conf, filepath.Join(curveDir, "fr", "accumulator"), bgen))
+
+			// generate threshold BLS signatures
+			assertNoError(tbls.Generate(conf, filepath.Join(curveDir, "tbls"), bgen))
+
 			// generate pedersen on fr
 			assertNoError(pedersen.Generate(conf, filepath.Join(curveDir, "fr", "pedersen"), bgen))
 
@@ -111,6 +130,11 @@ func main() {
 			// generate mimc on fr
 			assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
 
+			// generate anemoi, griffin and rescue-prime on fr
+			assertNoError(anemoi.Generate(conf, filepath.Join(curveDir, "fr", "anemoi"), bgen))
+			assertNoError(griffin.Generate(conf, filepath.Join(curveDir, "fr", "griffin"), bgen))
+			assertNoError(rescue.Generate(conf, filepath.Join(curveDir, "fr", "rescue"), bgen))
+
 			frInfo := config.FieldDependency{
 				FieldPackagePath: "github.com/consensys/gnark-crypto/ecc/" + conf.Name + "/fr",
 				FieldPackageName: "fr",
@@ -184,6 +208,18 @@ func main() {
 
 			// generate eddsa on companion curves
 			assertNoError(eddsa.Generate(conf, curveDir, bgen))
+
+			if conf.Package == "bandersnatch" {
+				// generate the scalar field of bandersnatch, used by the IPA vector commitment.
+				// it is not performance critical enough to justify the assembly.
+				frConf, err := field.NewFieldConfig("fr", "Element", conf.Order, true)
+				assertNoError(err)
+				frConf.ASM = false
+				assertNoError(generator.GenerateFF(frConf, filepath.Join(curveDir, "fr")))
+
+				// generate bulletproofs on bandersnatch, on top of its scalar field
+				assertNoError(bulletproofs.GenerateTwistedEdwards(conf, filepath.Join(curveDir, "bulletproofs"), bgen))
+			}
 		}(conf)
 
 	}
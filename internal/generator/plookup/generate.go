@@ -15,6 +15,8 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "vector.go"), Templates: []string{"vector.go.tmpl"}},
 		{File: filepath.Join(baseDir, "table.go"), Templates: []string{"table.go.tmpl"}},
+		{File: filepath.Join(baseDir, "logup.go"), Templates: []string{"logup.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "plookup_test.go"), Templates: []string{"plookup.test.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./plookup/template/", entries...)
@@ -11,5 +11,15 @@ func Generate(conf config.FieldDependency, baseDir string, bgen *bavard.BatchGen
 		{File: filepath.Join(baseDir, "sumcheck.go"), Templates: []string{"sumcheck.go.tmpl"}},
 		{File: filepath.Join(baseDir, "sumcheck_test.go"), Templates: []string{"sumcheck.test.go.tmpl"}},
 	}
+
+	// sums of products of multilinear tables and binary encoding, for actual fields only
+	if conf.FieldPackageName == "fr" {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"marshal.test.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "product.go"), Templates: []string{"product.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "product_test.go"), Templates: []string{"product.test.go.tmpl"}},
+		)
+	}
 	return bgen.Generate(conf, "sumcheck", "./sumcheck/template/", entries...)
 }
@@ -0,0 +1,25 @@
+package tbls
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// threshold BLS signatures
+	conf.Package = "tbls"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "shamir.go"), Templates: []string{"shamir.go.tmpl"}},
+		{File: filepath.Join(baseDir, "feldman.go"), Templates: []string{"feldman.go.tmpl"}},
+		{File: filepath.Join(baseDir, "bls.go"), Templates: []string{"bls.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dkg.go"), Templates: []string{"dkg.go.tmpl"}},
+		{File: filepath.Join(baseDir, "tbls_test.go"), Templates: []string{"tbls.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dkg_test.go"), Templates: []string{"dkg.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./tbls/template/", entries...)
+
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/consensys/gnark-crypto/internal/parallel"
 	"github.com/consensys/gnark-crypto/utils"
 	"math/big"
+	"math/bits"
+	"reflect"
 	"strconv"
 	"sync"
 )
@@ -857,13 +859,155 @@ func frToBigInts(dst []*big.Int, src []small_rational.SmallRational) {
 	}
 }
 
-// Gates defined by name
-var Gates = map[string]Gate{
-	"identity": IdentityGate{},
-	"add":      AddGate{},
-	"sub":      SubGate{},
-	"neg":      NegGate{},
-	"mul":      MulGate(2),
+// gates defined by name, accessed through RegisterGate, GetGate and GateName
+var gates = map[string]Gate{
+	"identity":      IdentityGate{},
+	"add":           AddGate{},
+	"sub":           SubGate{},
+	"neg":           NegGate{},
+	"mul":           MulGate(2),
+	"select":        SelectGate{},
+	"poseidon-sbox": SBoxGate{Exponent: 5},
+	"mimc":          MiMCRoundGate{Exponent: 7},
+}
+
+// Gates is the registry of the gates defined by name.
+//
+// Deprecated: use RegisterGate and GetGate, which are safe for concurrent use.
+var Gates = gates
+
+var gatesLock sync.RWMutex
+
+// RegisterGate adds a gate with nbIn inputs under the given name, so that
+// circuits using it can be referred to by gate names. It fails if the name is
+// already taken or if gate.Degree() is not the actual degree of the gate.
+func RegisterGate(name string, gate Gate, nbIn int) error {
+	if err := VerifyGateDegree(gate, nbIn); err != nil {
+		return fmt.Errorf("gate \"%s\": %w", name, err)
+	}
+	gatesLock.Lock()
+	defer gatesLock.Unlock()
+	if _, ok := gates[name]; ok {
+		return fmt.Errorf("gate \"%s\" already registered", name)
+	}
+	gates[name] = gate
+	return nil
+}
+
+// GetGate returns the gate registered under the given name, or nil if there is none
+func GetGate(name string) Gate {
+	gatesLock.RLock()
+	defer gatesLock.RUnlock()
+	return gates[name]
+}
+
+// GateName returns the name under which the gate is registered. If it is
+// registered under several names, the first one in lexicographic order is
+// returned, so that serialized circuits are deterministic.
+func GateName(gate Gate) (string, bool) {
+	if gate == nil || !reflect.TypeOf(gate).Comparable() {
+		return "", false
+	}
+	gatesLock.RLock()
+	defer gatesLock.RUnlock()
+	found := false
+	var res string
+	for name, g := range gates {
+		if reflect.TypeOf(g).Comparable() && g == gate && (!found || name < res) {
+			res, found = name, true
+		}
+	}
+	return res, found
+}
+
+// GateFunction is a polynomial function of the gate inputs
+type GateFunction func(...small_rational.SmallRational) small_rational.SmallRational
+
+type functionGate struct {
+	f      GateFunction
+	degree int
+}
+
+func (g *functionGate) Evaluate(x ...small_rational.SmallRational) small_rational.SmallRational {
+	return g.f(x...)
+}
+
+func (g *functionGate) Degree() int {
+	return g.degree
+}
+
+// NewGate returns a gate evaluating f on nbIn inputs, its degree being found by
+// FindGateDegree. It fails if f is not a polynomial of degree at most maxDegree.
+func NewGate(f GateFunction, nbIn, maxDegree int) (Gate, error) {
+	degree, err := FindGateDegree(f, nbIn, maxDegree)
+	if err != nil {
+		return nil, err
+	}
+	return &functionGate{f: f, degree: degree}, nil
+}
+
+// FindGateDegree returns the total degree of f as a polynomial in its nbIn inputs,
+// or an error if it is larger than maxDegree. f is restricted to a random line,
+// and the degree of the restriction is read off its finite differences. It is
+// thus correct with high probability.
+func FindGateDegree(f GateFunction, nbIn, maxDegree int) (int, error) {
+	if maxDegree < 0 || nbIn < 0 {
+		return -1, fmt.Errorf("invalid parameters")
+	}
+
+	// x = a + t·d for t = 0, ..., maxDegree+1
+	a := make([]small_rational.SmallRational, nbIn)
+	d := make([]small_rational.SmallRational, nbIn)
+	for i := range a {
+		if _, err := a[i].SetRandom(); err != nil {
+			return -1, err
+		}
+		if _, err := d[i].SetRandom(); err != nil {
+			return -1, err
+		}
+	}
+	values := make([]small_rational.SmallRational, maxDegree+2)
+	x := make([]small_rational.SmallRational, nbIn)
+	for t := range values {
+		copy(x, a)
+		values[t] = f(x...)
+		for i := range a {
+			a[i].Add(&a[i], &d[i])
+		}
+	}
+
+	// after k passes, values[0] = Δᵏp(0), which is constant for k = deg p and zero for k > deg p
+	degree := -1
+	for k := 0; k < len(values); k++ {
+		if !values[0].IsZero() {
+			degree = k
+		}
+		for t := 0; t < len(values)-k-1; t++ {
+			values[t].Sub(&values[t+1], &values[t])
+		}
+	}
+
+	if degree > maxDegree {
+		return -1, fmt.Errorf("degree larger than %d", maxDegree)
+	}
+	if degree < 0 { // the zero polynomial
+		degree = 0
+	}
+	return degree, nil
+}
+
+// VerifyGateDegree checks that gate.Degree() is the total degree of the gate
+// with nbIn inputs, as found by FindGateDegree.
+func VerifyGateDegree(gate Gate, nbIn int) error {
+	claimed := gate.Degree()
+	degree, err := FindGateDegree(gate.Evaluate, nbIn, claimed)
+	if err != nil {
+		return fmt.Errorf("claimed degree %d too small: %w", claimed, err)
+	}
+	if degree != claimed {
+		return fmt.Errorf("claimed degree %d but found %d", claimed, degree)
+	}
+	return nil
 }
 
 type IdentityGate struct{}
@@ -944,3 +1088,72 @@ func (g NegGate) Evaluate(element ...small_rational.SmallRational) (neg small_ra
 func (g NegGate) Degree() int {
 	return 1
 }
+
+// MiMCRoundGate computes a round of the MiMC cipher: (x + k + Ark)^Exponent,
+// for inputs x and k. The built-in "mimc" gate is the round of exponent 7 with
+// no round constant.
+type MiMCRoundGate struct {
+	Ark      small_rational.SmallRational
+	Exponent int
+}
+
+func (g MiMCRoundGate) Evaluate(input ...small_rational.SmallRational) (res small_rational.SmallRational) {
+	if len(input) != 2 {
+		panic("mimc round gate takes two inputs")
+	}
+	var sum small_rational.SmallRational
+	sum.Add(&input[0], &input[1]).Add(&sum, &g.Ark)
+	return pow(sum, g.Exponent)
+}
+
+func (g MiMCRoundGate) Degree() int {
+	return g.Exponent
+}
+
+// SBoxGate computes (x + Ark)^Exponent, as in the rounds of Poseidon.
+type SBoxGate struct {
+	Ark      small_rational.SmallRational
+	Exponent int
+}
+
+func (g SBoxGate) Evaluate(input ...small_rational.SmallRational) (res small_rational.SmallRational) {
+	if len(input) != 1 {
+		panic("univariate gate")
+	}
+	var sum small_rational.SmallRational
+	sum.Add(&input[0], &g.Ark)
+	return pow(sum, g.Exponent)
+}
+
+func (g SBoxGate) Degree() int {
+	return g.Exponent
+}
+
+// SelectGate computes b·x + (1-b)·y = b·(x-y) + y for inputs b, x, y, i.e. x if b = 1 and y if b = 0.
+type SelectGate struct{}
+
+func (g SelectGate) Evaluate(input ...small_rational.SmallRational) (res small_rational.SmallRational) {
+	if len(input) != 3 {
+		panic("select gate takes three inputs")
+	}
+	res.Sub(&input[1], &input[2]).
+		Mul(&res, &input[0]).
+		Add(&res, &input[2])
+	return
+}
+
+func (g SelectGate) Degree() int {
+	return 2
+}
+
+// pow computes x^e by square-and-multiply
+func pow(x small_rational.SmallRational, e int) (res small_rational.SmallRational) {
+	res.SetOne()
+	for i := bits.Len(uint(e)) - 1; i >= 0; i-- {
+		res.Square(&res)
+		if (e>>i)&1 == 1 {
+			res.Mul(&res, &x)
+		}
+	}
+	return
+}
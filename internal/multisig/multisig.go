@@ -0,0 +1,147 @@
+// Package multisig implements the parts of the MuSig2 and FROST protocols that
+// don't depend on the group: the checks of the participants and the arithmetic
+// of the scalars mod the order of the group.
+//
+// It is shared by the schnorr package of secp256k1 and the eddsa packages of
+// the twisted Edwards curves, which implement the group operations, the hashes
+// and the encodings.
+package multisig
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+)
+
+var (
+	ErrNoSigner          = errors.New("at least one signer is needed")
+	ErrNonceUsed         = errors.New("secret nonce already used")
+	ErrNonceMismatch     = errors.New("secret nonce was generated for another key")
+	ErrUnknownSigner     = errors.New("public key is not part of the aggregate key")
+	ErrNbPartials        = errors.New("wrong number of partial signatures")
+	ErrInvalidThreshold  = errors.New("threshold must be in [1, number of participants]")
+	ErrInvalidID         = errors.New("participant identifier must be in [1, number of participants]")
+	ErrDuplicateID       = errors.New("duplicate participant identifier")
+	ErrNbPackages        = errors.New("wrong number of packages")
+	ErrInvalidProof      = errors.New("invalid proof of knowledge of the secret")
+	ErrInvalidShare      = errors.New("secret share doesn't match the commitments of its sender")
+	ErrDKGState          = errors.New("distributed key generation rounds called out of order")
+	ErrNotEnoughSigners  = errors.New("not enough signers to reach the threshold")
+	ErrNotInSession      = errors.New("signer is not part of the session")
+	ErrCommitmentInvalid = errors.New("nonce commitment doesn't match the secret nonce")
+	ErrGroupKeyMismatch  = errors.New("key share was generated for another group key")
+)
+
+// CheckParameters checks the identifier of a participant to the key generation
+// of a threshold-out-of-nbParticipants key.
+func CheckParameters(id, threshold, nbParticipants uint32) error {
+	if threshold == 0 || threshold > nbParticipants {
+		return ErrInvalidThreshold
+	}
+	if id == 0 || id > nbParticipants {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// Participants records the participants, identified by 1, …, len(Participants),
+// a package was received from.
+type Participants []bool
+
+// Add records the participant id, which must be valid and not recorded yet.
+func (p Participants) Add(id uint32) error {
+	if id == 0 || id > uint32(len(p)) {
+		return ErrInvalidID
+	}
+	if p[id-1] {
+		return ErrDuplicateID
+	}
+	p[id-1] = true
+	return nil
+}
+
+// SortIDs sorts the identifiers of the signers of a session, which must be
+// non-zero and distinct.
+func SortIDs(ids []uint32) error {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for i := range ids {
+		if ids[i] == 0 {
+			return ErrInvalidID
+		}
+		if i > 0 && ids[i] == ids[i-1] {
+			return ErrDuplicateID
+		}
+	}
+	return nil
+}
+
+// Index returns the position of id in the sorted identifiers of the signers of
+// a session.
+func Index(ids []uint32, id uint32) (int, error) {
+	i := sort.Search(len(ids), func(i int) bool { return ids[i] >= id })
+	if i == len(ids) || ids[i] != id {
+		return 0, ErrNotInSession
+	}
+	return i, nil
+}
+
+// Evaluate returns f(x) mod order, where f has the given coefficients, lowest
+// degree first.
+func Evaluate(coefficients []big.Int, x uint32, order *big.Int) *big.Int {
+	var bx big.Int
+	bx.SetUint64(uint64(x))
+	res := new(big.Int)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		res.Mul(res, &bx).
+			Add(res, &coefficients[i]).
+			Mod(res, order)
+	}
+	return res
+}
+
+// LagrangeCoefficient returns the Lagrange coefficient at 0 of the signer
+// ids[i] among the signers ids, λᵢ = ∏_{j≠i} j/(j-i) mod order.
+func LagrangeCoefficient(ids []uint32, i int, order *big.Int) *big.Int {
+	var num, den, diff big.Int
+	num.SetUint64(1)
+	den.SetUint64(1)
+	for j := range ids {
+		if j == i {
+			continue
+		}
+		num.Mul(&num, diff.SetUint64(uint64(ids[j])))
+		diff.Sub(&diff, new(big.Int).SetUint64(uint64(ids[i])))
+		den.Mul(&den, &diff)
+	}
+	den.Mod(&den, order).
+		ModInverse(&den, order)
+	return num.Mul(&num, &den).
+		Mod(&num, order)
+}
+
+// Response returns the partial signature k + c⋅a⋅x mod order of a signer with
+// the secret x, where k = k₁ + b⋅k₂ is negated if negateNonce is set, c is the
+// challenge and a the coefficient of the signer: aᵢ in MuSig2, λᵢ in FROST.
+func Response(k1, k2, b, c, a, x *big.Int, negateNonce bool, order *big.Int) *big.Int {
+	var k big.Int
+	k.Mul(k2, b).
+		Add(&k, k1)
+	if negateNonce {
+		k.Neg(&k)
+	}
+	res := new(big.Int)
+	return res.Mul(c, a).
+		Mul(res, x).
+		Add(res, &k).
+		Mod(res, order)
+}
+
+// Sum returns ∑ scalars mod order, where the scalars are big endian integers.
+func Sum(scalars [][]byte, order *big.Int) *big.Int {
+	var s big.Int
+	res := new(big.Int)
+	for i := range scalars {
+		res.Add(res, s.SetBytes(scalars[i]))
+	}
+	return res.Mod(res, order)
+}
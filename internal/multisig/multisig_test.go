@@ -0,0 +1,65 @@
+package multisig
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestLagrangeCoefficient(t *testing.T) {
+	order := big.NewInt(101)
+	f := make([]big.Int, 3)
+	f[0].SetInt64(42)
+	f[1].SetInt64(7)
+	f[2].SetInt64(99)
+
+	// any 3 evaluations of f interpolate f(0)
+	ids := []uint32{5, 2, 9}
+	if err := SortIDs(ids); err != nil {
+		t.Fatal(err)
+	}
+	var sum, term big.Int
+	for i := range ids {
+		term.Mul(LagrangeCoefficient(ids, i, order), Evaluate(f, ids[i], order))
+		sum.Add(&sum, &term)
+	}
+	if sum.Mod(&sum, order).Cmp(&f[0]) != 0 {
+		t.Fatal("interpolation at 0 should give the constant coefficient")
+	}
+}
+
+func TestIDs(t *testing.T) {
+	if err := CheckParameters(1, 3, 2); err != ErrInvalidThreshold {
+		t.Fatal("expected an invalid threshold error")
+	}
+	if err := CheckParameters(0, 1, 2); err != ErrInvalidID {
+		t.Fatal("expected an invalid identifier error")
+	}
+
+	received := make(Participants, 3)
+	if err := received.Add(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := received.Add(2); err != ErrDuplicateID {
+		t.Fatal("expected a duplicate identifier error")
+	}
+	if err := received.Add(4); err != ErrInvalidID {
+		t.Fatal("expected an invalid identifier error")
+	}
+
+	if err := SortIDs([]uint32{3, 1, 3}); err != ErrDuplicateID {
+		t.Fatal("expected a duplicate identifier error")
+	}
+	if err := SortIDs([]uint32{1, 0}); err != ErrInvalidID {
+		t.Fatal("expected an invalid identifier error")
+	}
+	ids := []uint32{7, 3, 5}
+	if err := SortIDs(ids); err != nil {
+		t.Fatal(err)
+	}
+	if i, err := Index(ids, 5); err != nil || i != 1 {
+		t.Fatal("wrong index of a signer")
+	}
+	if _, err := Index(ids, 4); err != ErrNotInSession {
+		t.Fatal("expected a signer not in session error")
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright © 2023 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schnorr
+
+import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	schnorr_secp256k1 "github.com/consensys/gnark-crypto/ecc/secp256k1/schnorr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+// New takes a source of randomness and returns a new BIP-340 key pair
+func New(ss ecc.ID, r io.Reader) (signature.Signer, error) {
+	switch ss {
+	case ecc.SECP256K1:
+		return schnorr_secp256k1.GenerateKey(r)
+	default:
+		panic("not implemented")
+	}
+}
@@ -70,3 +70,25 @@ type Signer interface {
 	// It returns the number byte read.
 	SetBytes(buf []byte) (int, error)
 }
+
+// AdaptorSigner is a Signer that also produces adaptor signatures. A
+// pre-signature for an adaptor point T = t⋅G becomes a valid signature once
+// adapted with the secret t, and t can be extracted from the pre-signature and
+// the adapted signature.
+type AdaptorSigner interface {
+	Signer
+
+	// PreSign returns the pre-signature of a message for the adaptor point,
+	// given in binary. If hFunc is not provided, implementation may consider
+	// the message to be pre-hashed, else, will use hFunc to hash the message.
+	PreSign(message, adaptor []byte, hFunc hash.Hash) ([]byte, error)
+}
+
+// AdaptorPublicKey is a PublicKey that also verifies pre-signatures.
+type AdaptorPublicKey interface {
+	PublicKey
+
+	// PreVerify verifies a pre-signature of a message for the adaptor point,
+	// given in binary. hFunc is used as in Verify.
+	PreVerify(preSigBin, message, adaptor []byte, hFunc hash.Hash) (bool, error)
+}